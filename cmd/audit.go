@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/domain"
+)
+
+// audit 하위 명령 (현재 lookup만 지원)
+func runAudit(auditLog *audit.Log, args []string) error {
+	if len(args) == 0 || args[0] != "lookup" {
+		return errors.New("사용법: audit lookup --email <이메일> --phone <전화번호>")
+	}
+
+	flags := flag.NewFlagSet("audit lookup", flag.ContinueOnError)
+	email := flags.String("email", "", "조회할 이메일")
+	phone := flags.String("phone", "", "조회할 전화번호 (하이픈 유무 무관)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	entries, err := auditLog.Lookup(audit.Query{Email: *email, PhoneNumber: *phone})
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("일치하는 감사 기록이 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("=== 감사 기록 %d건 ===\n", len(entries))
+	for _, entry := range entries {
+		printAuditEntry(entry)
+	}
+	return nil
+}
+
+// 키 파일의 HMAC 키로 감사 기록 열기 (기록과 조회에 같은 키 사용)
+func openAuditLog() (*audit.Log, error) {
+	hasher, err := audit.LoadKey(*auditKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewLog(*auditLogPath, hasher), nil
+}
+
+func printAuditEntry(entry audit.Entry) {
+	fmt.Println()
+	fmt.Printf("실행 %s  %s #%d  (%s)\n", entry.RunID, entry.Source, entry.Record,
+		entry.Time.In(domain.KST).Format("2006-01-02 15:04:05 KST"))
+
+	values := []string{entry.Email, entry.PhoneNumber, fmt.Sprintf("상승=%t", entry.CreditUp)}
+	if entry.Score != "" {
+		values = append(values, "점수="+entry.Score)
+	}
+	if entry.Bureau != "" {
+		values = append(values, "평가사="+entry.Bureau)
+	}
+	fmt.Printf("- 입력: %s\n", strings.Join(values, ", "))
+
+	switch {
+	case entry.ReplayOf != "":
+		fmt.Printf("- 대상 판단: 아웃박스 재전송 (실행 %s 에서 보관, 다시 보낼 때 규칙과 수신 거부 목록을 다시 적용)\n", entry.ReplayOf)
+	case entry.Eligible == nil:
+		fmt.Println("- 대상 판단: 대기열에서 꺼낸 레코드 (보관할 때 대상)")
+	case entry.ExcludedBy != "" && !*entry.Eligible:
+		fmt.Printf("- 대상 판단: 제외 (규칙 %s)\n", entry.ExcludedBy)
+	case entry.ExcludedBy != "":
+		fmt.Printf("- 대상 판단: 대상 (채널 규칙 %s 로 제외)\n", entry.ExcludedBy)
+	default:
+		fmt.Println("- 대상 판단: 대상")
+	}
+
+	if entry.DuplicateKey != "" {
+		fmt.Printf("- 중복: 키 %s 가 먼저 처리됨\n", entry.DuplicateKey)
+	}
+
+	printChannelDecision("이메일", entry.EmailChannel)
+	printChannelDecision("SMS", entry.SMSChannel)
+}
+
+func printChannelDecision(name string, decision audit.ChannelDecision) {
+	line := fmt.Sprintf("- %s: %s", name, decision.Status)
+	if decision.Error != "" {
+		line += " (" + decision.Error + ")"
+	}
+	fmt.Println(line)
+
+	for _, attempt := range decision.Attempts {
+		result := "성공"
+		if attempt.Error != "" {
+			result = "실패: " + attempt.Error
+		}
+		fmt.Printf("    %s 전송 시도 %s\n", attempt.Time.In(domain.KST).Format("15:04:05.000"), result)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/processor"
+)
+
+// 값이 다른 중복 레코드를 신용평가사에 전달할 수 있도록 CSV로 누적 기록 (레코드당 한 행)
+func writeConflictReport(path, inputPath string, conflicts []processor.Conflict) error {
+	_, statErr := os.Stat(path)
+	isNew := os.IsNotExist(statErr)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrap(err, "충돌 보고서 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close conflict report")
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	if isNew {
+		if err := writer.Write([]string{"input", "key", "fields", "record", "kept", "email", "phone_number", "credit_up", "score", "bureau"}); err != nil {
+			return errors.Wrap(err, "충돌 보고서 기록 실패")
+		}
+	}
+
+	for _, conflict := range conflicts {
+		for i, record := range conflict.Records {
+			row := []string{
+				inputPath,
+				conflict.Key,
+				strings.Join(conflict.Fields, "|"),
+				strconv.Itoa(i + 1),
+				strconv.FormatBool(i == conflict.Kept),
+				record.Email,
+				record.PhoneNumber,
+				strconv.FormatBool(record.CreditUp),
+				record.Score,
+				record.Bureau,
+			}
+			if err := writer.Write(row); err != nil {
+				return errors.Wrap(err, "충돌 보고서 기록 실패")
+			}
+		}
+	}
+
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "충돌 보고서 기록 실패")
+}
+
+func printConflicts(conflicts []processor.Conflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	fmt.Printf("- 값이 다른 중복 레코드: %d건 (%s 정책 적용, %s에 기록)\n", len(conflicts), *mergePolicy, *conflictReport)
+}
+
+// identity 기준으로 병합된 연락처 묶음을 CSV로 누적 기록 (묶음당 한 행, 연락처는 | 로 구분)
+func writeClusterReport(path, inputPath string, clusters []processor.IdentityCluster) error {
+	_, statErr := os.Stat(path)
+	isNew := os.IsNotExist(statErr)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrap(err, "연락처 묶음 보고서 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close cluster report")
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	if isNew {
+		if err := writer.Write([]string{"input", "key", "records", "email", "phone_number", "emails", "phones"}); err != nil {
+			return errors.Wrap(err, "연락처 묶음 보고서 기록 실패")
+		}
+	}
+
+	for _, cluster := range clusters {
+		row := []string{
+			inputPath,
+			cluster.Key,
+			strconv.Itoa(cluster.Records),
+			cluster.Email,
+			cluster.PhoneNumber,
+			strings.Join(cluster.Emails, "|"),
+			strings.Join(cluster.Phones, "|"),
+		}
+		if err := writer.Write(row); err != nil {
+			return errors.Wrap(err, "연락처 묶음 보고서 기록 실패")
+		}
+	}
+
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "연락처 묶음 보고서 기록 실패")
+}
+
+func printClusters(clusters []processor.IdentityCluster) {
+	if len(clusters) == 0 {
+		return
+	}
+
+	records := 0
+	for _, cluster := range clusters {
+		records += cluster.Records
+	}
+	fmt.Printf("- 연락처를 공유한 레코드 묶음: %d개 (레코드 %d건, %s에 기록)\n", len(clusters), records, *clusterReport)
+}
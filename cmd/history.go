@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/job"
+)
+
+// 작업 ID가 없으면 전체 이력, 있으면 해당 작업의 상세 내용 출력
+func showHistory(store *job.Store, id string) error {
+	if id != "" {
+		record, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		printRecord(record)
+		return nil
+	}
+
+	records, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		fmt.Println("작업 이력이 없습니다.")
+		return nil
+	}
+
+	fmt.Println("=== 작업 이력 ===")
+	fmt.Printf("%-24s %-10s %-19s %-10s %8s %8s %8s  %s\n",
+		"작업 ID", "상태", "시작(KST)", "소요", "전체", "이메일", "SMS", "입력")
+	for _, record := range records {
+		fmt.Printf("%-24s %-10s %-19s %-10s %8d %8d %8d  %s\n",
+			record.ID,
+			record.Status,
+			record.StartTime.In(domain.KST).Format("2006-01-02 15:04:05"),
+			formatDuration(record),
+			record.Counts.TotalUsers,
+			record.Counts.EmailSuccess,
+			record.Counts.SMSSuccess,
+			record.Source,
+		)
+	}
+
+	return nil
+}
+
+func printRecord(record *job.Record) {
+	fmt.Println("=== 작업 상세 ===")
+	fmt.Printf("작업 ID: %s\n", record.ID)
+	fmt.Printf("상태: %s\n", record.Status)
+	fmt.Printf("입력: %s\n", record.Source)
+	fmt.Printf("입력 해시: %s\n", record.InputHash)
+	fmt.Printf("시작: %s\n", record.StartTime.In(domain.KST).Format("2006-01-02 15:04:05 KST"))
+	if record.EndTime != nil {
+		fmt.Printf("종료: %s\n", record.EndTime.In(domain.KST).Format("2006-01-02 15:04:05 KST"))
+	}
+	fmt.Printf("소요 시간: %s\n", formatDuration(record))
+	if record.Error != "" {
+		fmt.Printf("오류: %s\n", record.Error)
+	}
+
+	counts := record.Counts
+	fmt.Println()
+	fmt.Printf("전체 사용자: %d명\n", counts.TotalUsers)
+	fmt.Printf("신용점수 상승: %d명\n", counts.EligibleUsers)
+	fmt.Printf("중복 제거 후: %d명\n", counts.UniqueUsers)
+	fmt.Printf("수신 거부 제외: 이메일 %d명, SMS %d명\n", counts.EmailSuppressed, counts.SMSSuppressed)
+	fmt.Printf("SMS 대기열 보관: %d명\n", counts.SMSDeferred)
+	if counts.SMSReleased > 0 {
+		fmt.Printf("SMS 대기열에서 꺼냄: %d명\n", counts.SMSReleased)
+	}
+	fmt.Printf("전송 대상: 이메일 %d명, SMS %d명\n", counts.EmailTargets, counts.SMSTargets)
+	fmt.Printf("전송 성공: 이메일 %d명, SMS %d명\n", counts.EmailSuccess, counts.SMSSuccess)
+}
+
+func formatDuration(record *job.Record) string {
+	if record.EndTime == nil {
+		return "-"
+	}
+	return record.EndTime.Sub(record.StartTime).Round(time.Millisecond).String()
+}
@@ -2,53 +2,121 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"runtime"
 	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
-	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/ingest"
+	"banksalad-backend-task/internal/job"
+	"banksalad-backend-task/internal/output"
 	"banksalad-backend-task/internal/parser"
-	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/server"
 	"banksalad-backend-task/internal/service"
 )
 
-var KST = MustLoadKST()
-
-func MustLoadKST() *time.Location {
-	loc, err := time.LoadLocation("Asia/Seoul")
-	if err != nil {
-		panic(err)
-	}
-	return loc
-}
+var (
+	inputPath        = flag.String("input", "files/input/data.txt", "입력 파일, 디렉토리 또는 glob 패턴 (파일명 순으로 처리)")
+	inputFormat      = flag.String("format", "auto", "입력 형식 (auto, fixed, csv, tsv, jsonl; auto는 확장자로 판단)")
+	columnsPath      = flag.String("columns", "", "CSV/TSV 컬럼 설정 파일 (JSON, 비어 있으면 헤더 이름이 필드 이름과 같다고 가정)")
+	inputEncoding    = flag.String("encoding", "auto", "입력 인코딩 (auto, utf-8, euc-kr, cp949; auto는 UTF-8이 아니면 EUC-KR로 판단)")
+	layoutPath       = flag.String("layout", "", "고정 폭 컬럼 위치 설정 파일 (JSON, 바이트 단위, 비어 있으면 공백 구분)")
+	parseWorkers     = flag.Int("parse-workers", runtime.NumCPU(), "큰 입력 파일의 병렬 파싱 작업자 수 (1이면 순차 파싱)")
+	maxLineBytes     = flag.Int("max-line-bytes", parser.DefaultMaxLineBytes, "라인 최대 길이 (바이트, 넘는 라인은 거부 라인으로 건너뜀)")
+	processedPath    = flag.String("processed-store", "files/state/processed_files.txt", "처리 완료 파일 해시 기록")
+	forceReprocess   = flag.Bool("force", false, "이미 처리한 파일도 다시 처리")
+	watchMode        = flag.Bool("watch", false, "입력 경로를 계속 감시하며 새 파일 처리 (두 번의 확인에서 크기, 수정 시각이 같은 파일만)")
+	watchInterval    = flag.Duration("watch-interval", 30*time.Second, "감시 모드의 입력 경로 확인 주기")
+	watchReplay      = flag.Bool("watch-replay", true, "감시 모드에서 확인 주기마다 회로 차단기가 닫힌 채널의 회로 차단 보관 알림을 아웃박스에서 다시 전송")
+	progressDir      = flag.String("progress-dir", "files/state/progress", "입력 파일별 전송 진행 기록 디렉토리 (중단된 파일을 다시 처리할 때 이미 시도한 전송을 건너뜀)")
+	minScoreDelta    = flag.Int("min-score-delta", 0, "알림 대상 최소 신용점수 상승폭 (0이면 미적용)")
+	scoreThreshold   = flag.Int("score-threshold", 0, "이번에 넘어선 경우에만 알림을 보낼 기준 점수 (0이면 미적용)")
+	rulesPath        = flag.String("rules", "", "알림 대상 규칙 설정 파일 (JSON, 비어 있으면 신용점수 상승 여부만 확인)")
+	dedupBackend     = flag.String("dedup", "memory", "중복 제거 방식 (memory, bloom; bloom은 Bloom 필터와 디스크로 메모리 사용을 줄임)")
+	dedupExpected    = flag.Int("dedup-expected", 1000000, "bloom 방식의 예상 사용자 수 (넘으면 오탐률 증가, 결과는 정확)")
+	dedupFPRate      = flag.Float64("dedup-fp-rate", 0.01, "bloom 방식의 Bloom 필터 오탐률 (오탐은 디스크에서 확인)")
+	dedupDir         = flag.String("dedup-dir", "", "bloom 방식의 디스크 파일 위치 (비어 있으면 임시 디렉토리)")
+	dedupKey         = flag.String("dedup-key", "channel", "중복 판단 기준 (channel, email, phone, both, identity; channel은 이메일은 이메일로, SMS는 전화번호로 중복 제거, 기본값이 email에서 channel로 바뀌었으므로 이전 동작은 email 지정)")
+	clusterReport    = flag.String("cluster-report", "files/output/identity_clusters.csv", "identity 기준으로 병합된 연락처 묶음 보고서 (CSV, 누적 기록)")
+	mergePolicy      = flag.String("merge-policy", "first-wins", "값이 다른 중복 레코드 병합 정책 (first-wins, last-wins, any-y-wins, union-phones)")
+	conflictReport   = flag.String("conflict-report", "files/output/conflicts.csv", "값이 다른 중복 레코드 보고서 (CSV, 누적 기록)")
+	suppressPath     = flag.String("suppression", "", "수신 거부 목록 파일 (비어 있으면 미적용)")
+	smsWindow        = flag.String("sms-window", "", "SMS 전송 허용 시간대 (KST, 예: 08:00-21:00, 비어 있으면 미적용)")
+	deferredQueue    = flag.String("deferred-queue", "files/state/deferred_sms.jsonl", "허용 시간대 밖 SMS 대기열 파일")
+	jobStorePath     = flag.String("job-store", "files/state/jobs.jsonl", "작업 이력 파일")
+	auditLogPath     = flag.String("audit-log", "files/state/audit.jsonl", "입력 레코드별 알림 판단 감사 기록 파일 (추가만 함)")
+	auditKeyPath     = flag.String("audit-key", "files/state/audit.key", "감사 기록의 연락처 해시(HMAC) 키 파일 (없으면 만듦, 바꾸면 이전 기록은 조회되지 않음)")
+	summaryPath      = flag.String("summary", "files/output/summary.json", "실행 요약 파일 (JSON, 종료할 때마다 덮어씀)")
+	partialThreshold = flag.Float64("partial-threshold", 0.01, "입력 파일의 전송 실패율(실패 / 성공+실패)이 이보다 크면 종료 코드 2 (0~1, 0이면 실패가 하나라도 있으면 2, 회로 차단 보관과 거부 라인은 항상 2)")
+	outboxPath       = flag.String("outbox", "files/state/outbox.jsonl", "보내지 못한 알림 보관 파일 (회로 차단으로 보류한 전송, 강제 종료로 결과를 모르는 전송, replay 로 다시 전송)")
+	replayAttempts   = flag.Int("replay-max-attempts", 3, "replay로 다시 보내다 실패한 항목을 dead로 바꿔 더는 보내지 않을 횟수 (0이면 제한 없음)")
+	shutdownGrace    = flag.Duration("shutdown-grace", 30*time.Second, "종료 요청 후 진행 중인 전송을 기다리는 최대 시간 (넘으면 남은 전송을 기록하고 종료)")
+	breakerRate      = flag.Float64("breaker-failure-rate", 0.5, "채널별 회로 차단기를 여는 실패율 (0~1, 0이면 미적용)")
+	breakerWindow    = flag.Duration("breaker-window", 10*time.Second, "회로 차단기가 실패율을 계산하는 최근 구간")
+	breakerMinReqs   = flag.Int("breaker-min-requests", 20, "구간 안의 전송이 이보다 적으면 회로 차단기를 열지 않음")
+	breakerTimeout   = flag.Duration("breaker-open-timeout", 30*time.Second, "회로 차단기가 열린 뒤 시험 전송을 시작하기까지 대기 시간")
+	serveAddr        = flag.String("serve", "", "HTTP API 서버 주소 (예: :8080, 지정하면 작업 제출을 기다리는 서버로 실행)")
+	serveToken       = flag.String("serve-token", os.Getenv("NOTIFY_API_TOKEN"), "HTTP API 인증 토큰 (Authorization: Bearer, 기본값은 NOTIFY_API_TOKEN 환경 변수, 비어 있으면 서버를 시작하지 않음)")
+	serveInputDir    = flag.String("serve-input-dir", "files/input", "HTTP API에서 file로 지정할 수 있는 입력 파일 디렉토리 (비어 있으면 file 제출 거부)")
+	serveMaxBody     = flag.Int64("serve-max-body", server.DefaultMaxBodyBytes, "HTTP API 요청 본문 최대 크기 (바이트)")
+	jobRetention     = flag.Duration("job-retention", job.DefaultRetention, "HTTP API에서 끝난 작업을 조회할 수 있는 기간 (지나면 작업 이력으로만 확인)")
+	jobMaxFinished   = flag.Int("job-max-finished", job.DefaultMaxFinished, "HTTP API에서 보관하는 끝난 작업 수 (넘으면 오래된 작업부터 제거)")
+)
 
 func main() {
-	// 시작 시간 기록
-	startTime := time.Now().In(KST)
+	flag.Parse()
 
 	// 컨텍스트 설정 (Ctrl+C로 중단 가능)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 시그널 처리 (우아한 종료)
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				log.WithField("panic", r).Error("recovered from panic in signal handler")
-			}
-		}()
+	// 시그널 처리 (진행 중인 전송을 마무리하는 두 단계 종료)
+	stopper = newShutdown(cancel, *shutdownGrace, service.NewOutbox(*outboxPath))
+	go stopper.listen()
+
+	// 작업 이력 조회: history [작업 ID]
+	if flag.Arg(0) == "history" {
+		if err := showHistory(job.NewStore(*jobStorePath), flag.Arg(1)); err != nil {
+			log.WithError(err).Fatal("작업 이력 조회 실패")
+		}
+		return
+	}
+
+	// 감사 기록 조회: audit lookup --email <이메일> --phone <전화번호>
+	if flag.Arg(0) == "audit" {
+		auditLog, err := openAuditLog()
+		if err != nil {
+			log.WithError(err).Fatal("감사 기록 조회 실패")
+		}
+		if err := runAudit(auditLog, flag.Args()[1:]); err != nil {
+			log.WithError(err).Fatal("감사 기록 조회 실패")
+		}
+		return
+	}
+
+	// 입력과 출력 파일 대사: reconcile [실행 ID]
+	if flag.Arg(0) == "reconcile" {
+		if err := runReconcile(ctx, flag.Args()[1:]); err != nil {
+			log.WithError(err).Fatal("대사 실패")
+		}
+		return
+	}
 
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-		fmt.Println("\n프로그램을 종료합니다...")
-		cancel()
-	}()
+	// 여기부터는 알림을 보내므로 이전 프로세스의 출력 파일을 보관하고 빈 출력 파일로 시작
+	archiveOutputs()
+
+	// 아웃박스에 보관한 알림 다시 전송: replay
+	if flag.Arg(0) == "replay" {
+		if err := runReplay(ctx); err != nil {
+			log.WithError(err).Fatal("아웃박스 재전송 실패")
+		}
+		return
+	}
 
 	fmt.Println("=== 뱅크샐러드 신용점수 알림 시스템 ===")
 	fmt.Println()
@@ -58,136 +126,253 @@ func main() {
 		log.WithError(err).Fatal("출력 디렉토리 생성 실패")
 	}
 
-	// 1단계: 파일 파싱
-	fmt.Println("1단계: 데이터 파일 파싱 중...")
-	users, err := parseDataFile(ctx)
+	if *serveAddr != "" {
+		if err := serve(ctx, *serveAddr); err != nil {
+			log.WithError(err).Fatal("서버 실행 실패")
+		}
+		return
+	}
+
+	// 입력 처리 결과를 스케줄러가 읽을 수 있도록 종료할 때 요약 기록
+	summaries = newSummary(*summaryPath)
+
+	store, err := ingest.NewProcessedStore(*processedPath)
 	if err != nil {
-		log.WithError(err).Fatal("파일 파싱 실패")
+		log.WithError(err).Fatal("처리 기록 로딩 실패")
 	}
-	fmt.Printf("✓ 총 %d명의 사용자 데이터를 읽었습니다.\n\n", len(users))
 
-	// 2단계: 신용점수 상승 사용자 필터링
-	fmt.Println("2단계: 신용점수 상승 사용자 필터링 중...")
-	eligibleUsers := listEligibleUsers(users)
-	fmt.Printf("✓ 신용점수 상승 사용자: %d명\n\n", len(eligibleUsers))
+	inputProcessor := ingest.NewProcessor(*inputPath, store, processInput)
+	inputProcessor.SetForce(*forceReprocess)
 
-	// 3단계: 중복 제거
-	fmt.Println("3단계: 중복 사용자 제거 중...")
+	if *watchMode {
+		// 새 파일이 없어도 확인 주기마다 허용 시간대가 열린 SMS 대기열과 회로 차단 보관 알림을 보냄
+		inputProcessor.SetIdleHandler(func(ctx context.Context) {
+			if err := releaseDeferred(ctx); err != nil && ctx.Err() == nil {
+				log.WithError(err).Error("SMS 대기열 전송 실패 (다음 주기에 재시도)")
+			}
+			if *watchReplay {
+				replayParked(ctx)
+			}
+		})
+		fmt.Printf("입력 경로 감시 중: %s (주기 %v)\n\n", *inputPath, *watchInterval)
+		if err := inputProcessor.Watch(ctx, *watchInterval); err != nil && err != context.Canceled {
+			log.WithError(err).Fatal("입력 경로 감시 실패")
+		}
+		if ctx.Err() != nil {
+			stopper.exit()
+		}
+		return
+	}
 
-	// 중복 제거 전략 선택 (현재 Email 기준)
-	uniqueUsers := listUniqueUsers(eligibleUsers, domain.ByEmail)
-	removedDuplicates := len(eligibleUsers) - len(uniqueUsers)
-	if removedDuplicates > 0 {
-		fmt.Printf("✓ 중복 제거 후: %d명 (중복 %d명 제거)\n\n", len(uniqueUsers), removedDuplicates)
-	} else {
-		fmt.Printf("✓ 중복 제거 후: %d명 (중복 없음)\n\n", len(uniqueUsers))
+	processed, err := inputProcessor.ProcessPending(ctx)
+	if ctx.Err() != nil {
+		stopper.exit()
+	}
+	if err != nil {
+		var parseErr *pipeline.ParseError
+		if errors.As(err, &parseErr) {
+			log.WithError(err).Error("입력 파일 파싱 실패")
+			finish(exitParseFailure)
+		}
+		log.WithError(err).Error("입력 파일 처리 실패")
+		finish(exitFailure)
+	}
+	if processed == 0 {
+		fmt.Println("새로 처리할 입력 파일이 없습니다. (이미 처리한 파일은 -force 로 다시 처리)")
 	}
+	finish(summaries.exitCode())
+}
 
-	// 4단계: 알림 전송
-	var emailSuccess, smsSuccess int
-	if len(uniqueUsers) > 0 {
-		fmt.Println("4단계: 알림 전송 중...")
-		fmt.Printf("- SMS 속도 제한: 초당 100건\n")
-		fmt.Printf("- 이메일: 병렬 전송 (제한 없음)\n")
+func processInput(ctx context.Context, path string) error {
+	fmt.Printf(">>> 입력 파일: %s\n\n", path)
 
-		emailSuccess, smsSuccess, err = sendNotifications(ctx, uniqueUsers)
-		if err != nil {
-			log.WithError(err).Fatal("알림 전송 실패")
-		}
+	// 감시 모드에서도 규칙, 수신 거부 목록 변경이 반영되도록 파일마다 새로 구성 (전송 클라이언트와 회로 차단기는 프로세스가 공유)
+	p, err := newPipeline(nil)
+	if err != nil {
+		err = errors.Wrap(err, "처리 흐름 구성 실패")
+		summaries.add(newRunSummary("", path, nil, err, false, nil))
+		return err
+	}
 
-		// 실제 성공 수 출력
-		bothSuccess := min(emailSuccess, smsSuccess)
-		fmt.Printf("✓ 알림 전송 완료: 이메일 %d명, SMS %d명, 양쪽 모두 성공 %d명\n\n",
-			emailSuccess, smsSuccess, bothSuccess)
-	} else {
-		fmt.Println("알림을 보낼 사용자가 없습니다.")
+	inputHash, err := ingest.HashFile(path)
+	if err != nil {
+		summaries.add(newRunSummary("", path, nil, err, false, nil))
+		return err
 	}
 
-	// 결과 요약
-	printResults(startTime, len(users), len(eligibleUsers), len(uniqueUsers), emailSuccess, smsSuccess)
-}
+	// 실행 중에 규칙이나 수신 거부 목록이 바뀌어도 대사에서 알 수 있도록 시작 시점의 설정을 남김
+	runConfig, err := currentRunConfig()
+	if err != nil {
+		summaries.add(newRunSummary("", path, nil, err, false, nil))
+		return err
+	}
 
-func ensureOutputDirectory() error {
-	if err := os.MkdirAll("files/output", 0755); err != nil {
-		return errors.Wrap(err, "디렉토리 생성 실패")
+	// 중단된 실행이 남긴 진행 기록이 있으면 이미 시도한 전송을 건너뜀
+	progress, err := ingest.OpenProgress(*progressDir, inputHash)
+	if err != nil {
+		summaries.add(newRunSummary("", path, nil, err, false, nil))
+		return err
+	}
+	if attempted := progress.Len(); attempted > 0 {
+		fmt.Printf("중단된 이전 실행에서 시도한 전송 %d건은 건너뜁니다.\n\n", attempted)
 	}
-	return nil
-}
 
-func parseDataFile(ctx context.Context) ([]*domain.User, error) {
-	fileParser := parser.NewFileParser("files/input/data.txt")
-	users, err := fileParser.ParseUsers(ctx)
+	jobStore := job.NewStore(*jobStorePath)
+	record := job.NewRecord(path, inputHash)
+	saveRecord(jobStore, record)
+
+	// 이전 실행이 남긴 줄과 섞이지 않도록 출력 파일의 현재 끝 위치를 기록 후 실행
+	outputs := output.NewManager(outputDir)
+	mark, err := outputs.Mark()
 	if err != nil {
-		return nil, errors.Wrap(err, "데이터 파일 파싱 중 오류")
+		return err
 	}
-	return users, nil
-}
 
-func listEligibleUsers(users []*domain.User) []*domain.User {
-	creditProcessor := processor.NewCreditProcessor()
-	return creditProcessor.FilterEligibleUsers(users)
-}
+	// 강제 종료되면 남은 전송을 기록할 수 있도록 실행 중인 결과를 등록
+	run := stopper.begin(record.ID, path, progress)
+	defer stopper.end(run)
 
-func listUniqueUsers(users []*domain.User, strategy domain.DuplicateStrategy) []*domain.User {
-	duplicateFilter := processor.NewDuplicateFilterWithStrategy(strategy)
-	return duplicateFilter.FilterDuplicates(users)
-}
+	// 배치마다 감사 기록, 진행 기록, 아웃박스를 남김 (실행 중에 종료되어도 전송한 배치는 기록됨)
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return err
+	}
+	recorder := auditLog.NewRecorder()
+	result, err := runPipeline(ctx, p, path, pipeline.Hooks{
+		OnStageDone: run.onStageDone,
+		OnSend:      recorder.Observe,
+		OnRecords: func(records []pipeline.Record) {
+			if err := auditLog.Append(recorder.Entries(record.ID, path, records)); err != nil {
+				log.WithError(err).Error("감사 기록 실패")
+			}
+			run.store(stopper.outbox, records)
+		},
+		Attempted: progress.Attempted,
+	})
+	record.Finish(result, err, ctx.Err() == context.Canceled)
+	saveRecord(jobStore, record)
+
+	manifest, collectErr := outputs.Collect(output.Manifest{
+		RunID:     record.ID,
+		Source:    path,
+		InputHash: inputHash,
+		StartTime: &record.StartTime,
+		Config:    runConfig,
+	}, mark)
+	if collectErr != nil {
+		log.WithError(collectErr).Error("실행별 출력 파일 정리 실패")
+	}
 
-func sendNotifications(ctx context.Context, users []*domain.User) (int, int, error) {
-	notificationManager := service.NewNotificationManager()
-	defer func() {
-		if err := notificationManager.Close(); err != nil {
-			log.WithError(err).Error("failed to close notification manager")
+	if result != nil && len(result.Conflicts) > 0 {
+		if err := writeConflictReport(*conflictReport, path, result.Conflicts); err != nil {
+			log.WithError(err).Error("충돌 보고서 기록 실패")
 		}
-	}()
+	}
+	if result != nil && len(result.Clusters) > 0 {
+		if err := writeClusterReport(*clusterReport, path, result.Clusters); err != nil {
+			log.WithError(err).Error("연락처 묶음 보고서 기록 실패")
+		}
+	}
+	// 종료 요청으로 중단되면 남은 전송도 보관하고 부분 결과 출력
+	interrupted := ctx.Err() != nil && err != nil
+	run.record(newRunSummary(record.ID, path, result, err, interrupted, manifest))
+	run.settle(stopper.outbox, interrupted)
+	if err != nil {
+		return err
+	}
+
+	// 처리를 마친 파일은 처리 기록으로 건너뛰므로 진행 기록이 필요 없음
+	if err := progress.Remove(); err != nil {
+		log.WithError(err).Error("진행 기록 삭제 실패")
+	}
+
+	fmt.Printf("작업 ID: %s\n", record.ID)
 
-	emailSuccess, smsSuccess, err := notificationManager.SendNotifications(ctx, users)
+	// 결과 요약
+	printResults(path, result, manifest)
+	fmt.Println()
+	return nil
+}
+
+// 이력 기록 실패로 알림 처리를 중단하지 않음
+func saveRecord(store *job.Store, record *job.Record) {
+	if err := store.Save(record); err != nil {
+		log.WithError(err).WithField("job", record.ID).Error("작업 이력 기록 실패")
+	}
+}
+
+// 이전 프로세스가 남긴 출력 파일을 archive/<시각>/ 으로 옮김 (전송 클라이언트가 출력 파일을 열기 전에 한 번만)
+// 보관하지 못해도 실행별 출력은 실행 전 파일 끝 위치로 나누므로 처리를 중단하지 않음
+func archiveOutputs() {
+	archived, err := output.NewManager(outputDir).Archive()
 	if err != nil {
-		return emailSuccess, smsSuccess, errors.Wrap(err, "알림 전송 중 오류")
+		log.WithError(err).Error("이전 출력 파일 보관 실패")
+		return
+	}
+	if archived != nil {
+		fmt.Printf("이전 출력 파일을 %s 에 보관했습니다.\n\n", archived.Dir)
 	}
+}
 
-	return emailSuccess, smsSuccess, nil
+func ensureOutputDirectory() error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return errors.Wrap(err, "디렉토리 생성 실패")
+	}
+	return nil
 }
 
-func printResults(startTime time.Time, totalUsers, eligibleUsers, uniqueUsers, emailSuccess, smsSuccess int) {
-	duration := time.Since(startTime)
+func printResults(inputPath string, result *pipeline.Result, manifest *output.Manifest) {
+	duration := result.EndTime.Sub(result.StartTime)
 
 	fmt.Println("=== 실행 결과 요약 ===")
-	fmt.Printf("실행 시작: %s\n", startTime.Format("2006-01-02 15:04:05 KST"))
+	fmt.Printf("입력 파일: %s\n", inputPath)
+	fmt.Printf("실행 시작: %s\n", result.StartTime.Format("2006-01-02 15:04:05 KST"))
 	fmt.Printf("총 처리 시간: %v\n", duration)
-	fmt.Printf("전체 사용자: %d명\n", totalUsers)
+	fmt.Printf("전체 사용자: %d명\n", result.TotalUsers)
+	if result.RejectedLines > 0 {
+		fmt.Printf("거부된 라인: %d건\n", result.RejectedLines)
+	}
 	fmt.Printf("신용점수 상승: %d명 (%.1f%%)\n",
-		eligibleUsers, float64(eligibleUsers)/float64(totalUsers)*100)
-	fmt.Printf("중복 제거 후: %d명\n", uniqueUsers)
-	fmt.Printf("이메일 전송 성공: %d명\n", emailSuccess)
-	fmt.Printf("SMS 전송 성공: %d명\n", smsSuccess)
+		result.EligibleUsers, float64(result.EligibleUsers)/float64(result.TotalUsers)*100)
+	fmt.Printf("중복 제거 후: %d명\n", result.UniqueUsers)
+	fmt.Printf("수신 거부 제외: 이메일 %d명, SMS %d명\n", result.EmailSuppressed, result.SMSSuppressed)
+	fmt.Printf("SMS 대기열 보관: %d명\n", result.SMSDeferred)
+	if result.SMSReleased > 0 {
+		fmt.Printf("SMS 대기열에서 꺼냄: %d명\n", result.SMSReleased)
+	}
+	fmt.Printf("이메일 전송 성공: %d명\n", result.EmailSuccess)
+	fmt.Printf("SMS 전송 성공: %d명\n", result.SMSSuccess)
 
-	bothSuccess := min(emailSuccess, smsSuccess)
+	bothSuccess := min(result.EmailSuccess, result.SMSSuccess)
 	fmt.Printf("양쪽 모두 성공: %d명\n", bothSuccess)
+	if email, sms := result.Counts(); email.Parked > 0 || sms.Parked > 0 {
+		fmt.Printf("회로 차단으로 보관: 이메일 %d명, SMS %d명 (%s, replay 로 다시 전송)\n", email.Parked, sms.Parked, *outboxPath)
+	}
 
-	if uniqueUsers > 0 {
-		avgTimePerUser := duration / time.Duration(uniqueUsers)
+	if result.UniqueUsers > 0 {
+		avgTimePerUser := duration / time.Duration(result.UniqueUsers)
 		fmt.Printf("사용자당 평균 처리 시간: %v\n", avgTimePerUser)
 	}
 
 	fmt.Println("\n=== 출력 파일 ===")
-
-	// 파일 존재 여부 확인
-	checkOutputFiles()
+	printOutputFiles(manifest)
 }
 
-func checkOutputFiles() {
-	files := []string{
-		"files/output/notified_emails.txt",
-		"files/output/notified_phone_numbers.txt",
+// 전송 클라이언트가 출력 파일을 만드는 디렉토리 (clients 패키지와 같은 경로)
+const outputDir = "files/output"
+
+func printOutputFiles(manifest *output.Manifest) {
+	if manifest == nil {
+		fmt.Println("✗ 실행별 출력 파일을 정리하지 못했습니다.")
+		return
 	}
 
-	for _, filePath := range files {
-		if info, err := os.Stat(filePath); err == nil {
-			fmt.Printf("✓ %s (크기: %d bytes)\n", filePath, info.Size())
-		} else {
-			fmt.Printf("✗ %s (파일 없음)\n", filePath)
-		}
+	fmt.Printf("실행별 출력: %s\n", manifest.Dir)
+	if len(manifest.Files) == 0 {
+		fmt.Println("- 전송한 알림 없음")
+	}
+	for _, file := range manifest.Files {
+		fmt.Printf("✓ %s (%d줄, 크기: %d bytes)\n", file.Name, file.Lines, file.Bytes)
 	}
 }
 
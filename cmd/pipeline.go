@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/clients"
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/ingest"
+	"banksalad-backend-task/internal/message"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/parser"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/rule"
+	"banksalad-backend-task/internal/service"
+	"banksalad-backend-task/internal/suppression"
+)
+
+// 입력 파일 하나에 대해 파싱부터 알림 전송까지 실행하며 단계별 진행 상황 출력 (extra의 훅은 출력 후 호출)
+func runPipeline(ctx context.Context, p *pipeline.Pipeline, inputPath string, extra pipeline.Hooks) (*pipeline.Result, error) {
+	// 1단계: 파일 파싱
+	fmt.Println("1단계: 데이터 파일 파싱 중...")
+
+	hooks := pipeline.Hooks{
+		OnStageDone: func(stage pipeline.Stage, result *pipeline.Result) {
+			defer func() {
+				if extra.OnStageDone != nil {
+					extra.OnStageDone(stage, result)
+				}
+			}()
+
+			switch stage {
+			case pipeline.StageParsing:
+				fmt.Printf("✓ 총 %d명의 사용자 데이터를 읽었습니다.\n", result.TotalUsers)
+				printRejects(result.Rejects, result.RejectedLines)
+				fmt.Println()
+
+				// 2단계: 신용점수 상승 사용자 필터링
+				fmt.Println("2단계: 신용점수 상승 사용자 필터링 중...")
+
+			case pipeline.StageFiltering:
+				printExclusions(result.Exclusions, false)
+				fmt.Printf("✓ 신용점수 상승 사용자: %d명\n\n", result.EligibleUsers)
+
+				// 3단계: 중복 제거 (-dedup-key 기준)
+				fmt.Println("3단계: 중복 사용자 제거 중...")
+
+			case pipeline.StageDeduplicating:
+				removedDuplicates := result.EligibleUsers - result.UniqueUsers
+				if removedDuplicates > 0 {
+					fmt.Printf("✓ 중복 제거 후: %d명 (중복 %d명 제거)\n\n", result.UniqueUsers, removedDuplicates)
+				} else {
+					fmt.Printf("✓ 중복 제거 후: %d명 (중복 없음)\n\n", result.UniqueUsers)
+				}
+				if *dedupKey == "channel" {
+					fmt.Println("- 채널 기준: 이메일이나 전화번호만 같은 레코드는 전송하면서 채널 주소별로 병합 (-merge-policy 적용)")
+					fmt.Println()
+				}
+
+				if result.UniqueUsers == 0 {
+					fmt.Println("알림을 보낼 사용자가 없습니다.")
+					return
+				}
+
+				// 4단계: 알림 전송
+				fmt.Println("4단계: 알림 전송 중...")
+				fmt.Printf("- SMS 속도 제한: 초당 100건\n")
+				fmt.Printf("- 이메일: 병렬 전송 (제한 없음)\n")
+
+			case pipeline.StageSending:
+				if result.UniqueUsers == 0 {
+					return
+				}
+
+				// 연락처 묶음과 충돌은 전송하면서 확정됨
+				printClusters(result.Clusters)
+				printConflicts(result.Conflicts)
+				printExclusions(result.Exclusions, true)
+				if result.EmailDuplicates > 0 || result.SMSDuplicates > 0 {
+					fmt.Printf("- 채널 주소 중복 제외: 이메일 %d명, SMS %d명\n", result.EmailDuplicates, result.SMSDuplicates)
+				}
+				fmt.Printf("- 수신 거부 제외: 이메일 %d명, SMS %d명\n", result.EmailSuppressed, result.SMSSuppressed)
+				if result.SMSDeferred > 0 {
+					fmt.Printf("- SMS 허용 시간대(%s) 밖: %d명 대기열 보관\n", *smsWindow, result.SMSDeferred)
+				}
+				if result.SMSReleased > 0 {
+					fmt.Printf("- SMS 대기열에서 꺼냄: %d명 (규칙, 수신 거부 다시 적용)\n", result.SMSReleased)
+				}
+				if result.SkippedSends > 0 {
+					fmt.Printf("- 중단된 이전 실행에서 시도한 전송 건너뜀: %d건\n", result.SkippedSends)
+				}
+				fmt.Printf("- 채널별 대상: 이메일 %d명, SMS %d명\n", result.EmailTargets, result.SMSTargets)
+
+				if ctx.Err() != nil {
+					fmt.Printf("✗ 종료 요청으로 전송 중단: 이메일 %d명, SMS %d명 성공\n\n", result.EmailSuccess, result.SMSSuccess)
+					return
+				}
+
+				// 실제 성공 수 출력
+				bothSuccess := min(result.EmailSuccess, result.SMSSuccess)
+				fmt.Printf("✓ 알림 전송 완료: 이메일 %d명, SMS %d명, 양쪽 모두 성공 %d명\n\n",
+					result.EmailSuccess, result.SMSSuccess, bothSuccess)
+			}
+		},
+		OnSend:    extra.OnSend,
+		OnRecords: extra.OnRecords,
+		Attempted: extra.Attempted,
+	}
+
+	// 실패해도 진행된 단계까지의 결과는 작업 이력에 남길 수 있도록 함께 반환
+	result, err := p.RunFile(ctx, inputPath, hooks)
+	if err != nil {
+		return result, errors.Wrap(err, "알림 처리 실패")
+	}
+
+	return result, nil
+}
+
+// 실행 옵션으로 처리 흐름 구성 (notifierFactory가 nil이면 프로세스가 공유하는 클라이언트 사용)
+func newPipeline(notifierFactory pipeline.NotifierFactory) (*pipeline.Pipeline, error) {
+	if notifierFactory == nil {
+		renderer, err := loadRenderer()
+		if err != nil {
+			return nil, err
+		}
+		notifierFactory = func() *service.NotificationManager {
+			emailClient, smsClient := newChannelClients()
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithRenderer(emailClient, renderer),
+				service.NewSMSServiceWithRenderer(smsClient, renderer),
+			)
+		}
+	}
+
+	cfg, err := newPipelineConfig(notifierFactory)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.New(cfg), nil
+}
+
+// 실행 옵션의 처리 흐름 설정 (대사는 같은 설정으로 전송 없이 다시 실행)
+func newPipelineConfig(notifierFactory pipeline.NotifierFactory) (pipeline.Config, error) {
+	parserFactory, err := newParserFactory()
+	if err != nil {
+		return pipeline.Config{}, errors.Wrap(err, "입력 형식 설정 오류")
+	}
+
+	creditProcessor, err := newCreditProcessor()
+	if err != nil {
+		return pipeline.Config{}, errors.Wrap(err, "알림 대상 규칙 로딩 실패")
+	}
+
+	dedup, err := newDedupConfig()
+	if err != nil {
+		return pipeline.Config{}, err
+	}
+
+	strategy, err := domain.ParseDuplicateStrategy(*dedupKey)
+	if err != nil {
+		return pipeline.Config{}, err
+	}
+
+	policy, err := processor.ParseMergePolicy(*mergePolicy)
+	if err != nil {
+		return pipeline.Config{}, err
+	}
+
+	suppressionList, err := loadSuppressionList()
+	if err != nil {
+		return pipeline.Config{}, errors.Wrap(err, "수신 거부 목록 로딩 실패")
+	}
+
+	smsScheduler, err := newSMSScheduler()
+	if err != nil {
+		return pipeline.Config{}, err
+	}
+
+	return pipeline.Config{
+		NewParser:         parserFactory,
+		ParseWorkers:      *parseWorkers,
+		CreditProcessor:   creditProcessor,
+		DuplicateStrategy: strategy,
+		Dedup:             dedup,
+		MergePolicy:       policy,
+		Suppression:       suppressionList,
+		SMSScheduler:      smsScheduler,
+		NewNotifier:       notifierFactory,
+	}, nil
+}
+
+func newParserFactory() (pipeline.ParserFactory, error) {
+	encoding, err := parser.ParseEncoding(*inputEncoding)
+	if err != nil {
+		return nil, err
+	}
+	opts := parser.Options{
+		Encoding:     encoding,
+		MaxLineBytes: *maxLineBytes,
+	}
+
+	if *columnsPath != "" {
+		opts.Columns, err = parser.LoadColumnConfig(*columnsPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if *layoutPath != "" {
+		opts.Layout, err = parser.LoadFixedWidthLayout(*layoutPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if *inputFormat == "" || *inputFormat == "auto" {
+		return func(path string) (parser.Parser, error) {
+			return parser.New(parser.DetectFormat(path), opts)
+		}, nil
+	}
+
+	format, err := parser.ParseFormat(*inputFormat)
+	if err != nil {
+		return nil, err
+	}
+	return func(path string) (parser.Parser, error) {
+		return parser.New(format, opts)
+	}, nil
+}
+
+func newCreditProcessor() (*processor.CreditProcessor, error) {
+	rules := rule.DefaultRuleSet()
+	if *rulesPath != "" {
+		loaded, err := rule.LoadRuleSet(*rulesPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "규칙 설정 로딩 중 오류")
+		}
+		rules = loaded
+	}
+
+	if *minScoreDelta > 0 {
+		rules = rules.With(rule.MinDelta(*minScoreDelta))
+	}
+	if *scoreThreshold > 0 {
+		rules = rules.With(rule.ThresholdCrossed(*scoreThreshold))
+	}
+
+	return processor.NewCreditProcessorWithRules(rules), nil
+}
+
+// 매니페스트에 남길 대상 판단 설정 (대사에서 실행 뒤에 설정이 바뀌었는지 확인)
+func currentRunConfig() (*output.RunConfig, error) {
+	cfg := &output.RunConfig{
+		MinScoreDelta:  *minScoreDelta,
+		ScoreThreshold: *scoreThreshold,
+		DedupKey:       *dedupKey,
+		MergePolicy:    *mergePolicy,
+		SMSWindow:      *smsWindow,
+	}
+
+	var err error
+	if *rulesPath != "" {
+		if cfg.RulesHash, err = ingest.HashFile(*rulesPath); err != nil {
+			return nil, errors.Wrap(err, "규칙 설정 파일 해시 실패")
+		}
+	}
+	if *suppressPath != "" {
+		if cfg.SuppressionHash, err = ingest.HashFile(*suppressPath); err != nil {
+			return nil, errors.Wrap(err, "수신 거부 목록 해시 실패")
+		}
+	}
+	return cfg, nil
+}
+
+// 설정을 확인하지 못해도 전송은 이미 끝났으므로 매니페스트에서만 뺌
+func runConfigOrNil() *output.RunConfig {
+	cfg, err := currentRunConfig()
+	if err != nil {
+		log.WithError(err).Warn("매니페스트에 실행 설정을 남기지 못했습니다")
+	}
+	return cfg
+}
+
+// 제외 사유별 인원 출력 (channel이 true면 채널별 규칙, false면 공통 규칙)
+func printExclusions(exclusions []pipeline.ExclusionCount, channel bool) {
+	for _, exclusion := range exclusions {
+		if (exclusion.Channel != "") != channel {
+			continue
+		}
+
+		ruleName := exclusion.Rule
+		if channel {
+			ruleName = exclusion.Channel + ":" + exclusion.Rule
+		}
+		fmt.Printf("- 규칙 %s 로 제외: %d명\n", ruleName, exclusion.Count)
+	}
+}
+
+// 거부된 라인은 위치를 확인할 수 있도록 앞부분만 출력 (total은 거부된 전체 라인 수)
+func printRejects(rejects []parser.Reject, total int) {
+	const maxPrinted = 10
+
+	for i, reject := range rejects {
+		if i == maxPrinted {
+			break
+		}
+		fmt.Printf("- 거부: %s\n", reject)
+	}
+	if printed := min(len(rejects), maxPrinted); total > printed {
+		fmt.Printf("- ... 외 %d건\n", total-printed)
+	}
+}
+
+func newDedupConfig() (processor.DedupConfig, error) {
+	backend, err := processor.ParseDedupBackend(*dedupBackend)
+	if err != nil {
+		return processor.DedupConfig{}, err
+	}
+
+	return processor.DedupConfig{
+		Backend:           backend,
+		ExpectedKeys:      *dedupExpected,
+		FalsePositiveRate: *dedupFPRate,
+		Dir:               *dedupDir,
+	}, nil
+}
+
+func loadSuppressionList() (*suppression.List, error) {
+	if *suppressPath == "" {
+		return suppression.NewList(nil), nil
+	}
+
+	suppressionList, err := suppression.Load(*suppressPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "수신 거부 목록 로딩 중 오류")
+	}
+	return suppressionList, nil
+}
+
+func newSMSScheduler() (*service.SMSScheduler, error) {
+	if *smsWindow == "" {
+		return nil, nil
+	}
+
+	policy, err := service.NewQuietHoursPolicy(*smsWindow, domain.KST)
+	if err != nil {
+		return nil, errors.Wrap(err, "SMS 허용 시간대 설정 오류")
+	}
+
+	return service.NewSMSScheduler(policy, service.NewDeferredQueue(*deferredQueue)), nil
+}
+
+// 프로세스가 공유하는 전송 클라이언트 (클라이언트는 출력 파일을 열어 두고 닫지 않으므로 입력 파일마다 만들지 않음)
+var (
+	clientsOnce       sync.Once
+	sharedEmailClient *clients.EmailClient
+	sharedSMSClient   *clients.SmsClient
+)
+
+func sharedClients() (*clients.EmailClient, *clients.SmsClient) {
+	clientsOnce.Do(func() {
+		sharedEmailClient = clients.NewEmailClient()
+		sharedSMSClient = clients.NewSmsClient()
+	})
+	return sharedEmailClient, sharedSMSClient
+}
+
+// 프로세스가 공유하는 채널별 전송 클라이언트 (-breaker-failure-rate가 0보다 크면 채널마다 회로 차단기를 거쳐 전송)
+// 회로 차단기도 프로세스에 하나씩 두어 감시 모드의 다음 입력 파일과 서버의 모든 작업이 장애 상태를 이어받음
+var (
+	channelClientsOnce sync.Once
+	emailSender        service.EmailSender
+	smsSender          service.SMSSender
+	emailBreaker       *service.CircuitBreaker // 회로 차단기를 쓰지 않으면 nil
+	smsBreaker         *service.CircuitBreaker
+)
+
+func newChannelClients() (service.EmailSender, service.SMSSender) {
+	channelClientsOnce.Do(func() {
+		emailClient, smsClient := sharedClients()
+		if *breakerRate <= 0 {
+			emailSender, smsSender = emailClient, smsClient
+			return
+		}
+
+		cfg := service.DefaultBreakerConfig()
+		cfg.FailureRate = *breakerRate
+		cfg.Window = *breakerWindow
+		cfg.MinRequests = *breakerMinReqs
+		cfg.OpenTimeout = *breakerTimeout
+
+		emailBreaker = service.NewCircuitBreaker("email", cfg)
+		smsBreaker = service.NewCircuitBreaker("sms", cfg)
+		emailSender = service.NewBreakerSender(emailClient, emailBreaker)
+		smsSender = service.NewBreakerSender(smsClient, smsBreaker)
+	})
+	return emailSender, smsSender
+}
+
+// 채널의 회로 차단기가 닫혀 있으면 true (시험 전송 중인 반열림은 false, 회로 차단기를 쓰지 않으면 true)
+func breakerClosed(channel domain.NotificationChannel) bool {
+	newChannelClients()
+
+	breaker := emailBreaker
+	if channel == domain.SMSChannel {
+		breaker = smsBreaker
+	}
+	return breaker == nil || breaker.State() == service.BreakerClosed
+}
+
+func loadRenderer() (*message.Renderer, error) {
+	renderer, err := message.LoadRenderer("files/templates")
+	if err != nil {
+		return nil, errors.Wrap(err, "메시지 템플릿 로딩 중 오류")
+	}
+	return renderer, nil
+}
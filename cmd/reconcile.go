@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/parser"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/reconcile"
+	"banksalad-backend-task/internal/service"
+)
+
+// 실행 하나의 입력 파일 알림 대상과 그 실행의 출력 파일 비교 (args는 실행 ID 또는 출력 디렉토리, 없으면 최근 실행)
+// 입력 파일은 매니페스트에 기록된 입력 (서버 실행처럼 입력이 없으면 -input)
+// 대상 판단은 실행과 같은 설정(규칙, 수신 거부 목록, -dedup-key, -merge-policy)으로 전송 없이 다시 실행하여 구하고
+// 실행 시각에 따라 달라지는 SMS 허용 시간대의 판단은 그 실행의 감사 기록 사용
+// 매니페스트에 남은 실행 설정과 지금 설정이 다르면 다시 실행한 결과는 비교하지 않고 입력만으로 확인하는 검사만 함
+func runReconcile(ctx context.Context, args []string) error {
+	outputs := output.NewManager(outputDir)
+
+	var manifest *output.Manifest
+	var err error
+	if len(args) > 0 {
+		manifest, err = outputs.Find(args[0])
+	} else {
+		manifest, err = outputs.Latest()
+	}
+	if err != nil {
+		return err
+	}
+
+	source := manifest.Source
+	if source == "" {
+		source = *inputPath
+	}
+
+	changed, err := runConfigChanges(manifest)
+	if err != nil {
+		return err
+	}
+
+	history, err := loadRunHistory(manifest.RunID)
+	if err != nil {
+		return err
+	}
+
+	emailPath := manifest.Path(domain.EmailChannel)
+	emails, err := reconcile.ReadAddressFile(emailPath)
+	if err != nil {
+		return err
+	}
+	smsPath := manifest.Path(domain.SMSChannel)
+	phoneNumbers, err := reconcile.ReadAddressFile(smsPath)
+	if err != nil {
+		return err
+	}
+
+	emailCheck, smsCheck, err := loadInputChecks(ctx, source, history)
+	if err != nil {
+		return err
+	}
+	emailInput := emailCheck.Compare(emails)
+	smsInput := smsCheck.Compare(phoneNumbers)
+
+	fmt.Println("=== 대사 결과 ===")
+	fmt.Printf("실행: %s\n", manifest.Dir)
+	fmt.Printf("입력: %s\n", source)
+	printInputReport("이메일", emailPath, emailInput)
+	printInputReport("SMS", smsPath, smsInput)
+	inputOK := emailInput.OK() && smsInput.OK()
+
+	if len(changed) > 0 {
+		fmt.Printf("\n실행 뒤에 바뀐 설정(%s)이 있어 대상 판단은 비교하지 않았습니다. 실행과 같은 설정으로 다시 대사하세요.\n", strings.Join(changed, ", "))
+		if !inputOK {
+			return reconcile.ErrMismatch
+		}
+		return reconcile.ErrConfigChanged
+	}
+
+	emailExpectation, smsExpectation, err := loadExpectations(ctx, source, history)
+	if err != nil {
+		return err
+	}
+	emailReport := emailExpectation.Compare(emails)
+	smsReport := smsExpectation.Compare(phoneNumbers)
+
+	printChannelReport("이메일", emailPath, emailReport)
+	printChannelReport("SMS", smsPath, smsReport)
+
+	if !inputOK || !emailReport.OK() || !smsReport.OK() {
+		return reconcile.ErrMismatch
+	}
+	fmt.Println("\n✓ 입력과 출력 파일이 일치합니다.")
+	return nil
+}
+
+// 매니페스트에 남은 실행 설정과 지금 설정이 다른 항목 (설정이 남지 않은 이전 실행은 확인하지 못해 경고만 함)
+func runConfigChanges(manifest *output.Manifest) ([]string, error) {
+	if manifest.Config == nil {
+		log.WithField("run", manifest.RunID).Warn("매니페스트에 실행 설정이 없어 지금 설정이 실행과 같은지 확인하지 못했습니다")
+		return nil, nil
+	}
+
+	current, err := currentRunConfig()
+	if err != nil {
+		return nil, err
+	}
+	changed := manifest.Config.Diff(*current)
+	if len(changed) > 0 {
+		log.WithField("run", manifest.RunID).WithField("changed", changed).Warn("실행 뒤에 대상 판단 설정이 바뀌었습니다")
+	}
+	return changed, nil
+}
+
+// 처리 흐름을 거치지 않고 입력 파일의 레코드만 읽어 채널별 검사를 만듦
+// 대기열에서 꺼내 보낸 SMS는 이전 실행의 입력이라 이번 입력에 없을 수 있으므로 두 검사에서 제외
+func loadInputChecks(ctx context.Context, source string, history *runHistory) (*reconcile.InputCheck, *reconcile.InputCheck, error) {
+	newParser, err := newParserFactory()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emailCheck := reconcile.NewInputCheck()
+	smsCheck := reconcile.NewInputCheck()
+	smsCheck.SetReleased(history.wasReleased)
+
+	err = parser.NewFileParserWithFactory(source, newParser).ParseStream(ctx, func(user *domain.User) error {
+		emailCheck.Add(user.Email, user.CreditUp)
+		smsCheck.Add(user.PhoneNumber, user.CreditUp)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "%s 읽기 실패", source)
+	}
+	return emailCheck, smsCheck, nil
+}
+
+// 입력 파일을 전송 없이 처리 흐름에 다시 통과시켜 채널별 기대 수신자를 구함
+// 허용 시간대(-sms-window)는 적용하지 않고 감사 기록에서 실행 당시 대기열에 보관한 SMS와 대기열에서 꺼내 보낸 SMS를 확인
+// 감사 기록이 없으면 지금 대기열에 남은 번호를 보관한 SMS로 봄
+func loadExpectations(ctx context.Context, source string, history *runHistory) (*reconcile.Expectation, *reconcile.Expectation, error) {
+	cfg, err := newPipelineConfig(func() *service.NotificationManager {
+		return service.NewNotificationManagerWithServices(
+			&plannedSends{channel: domain.EmailChannel},
+			&plannedSends{channel: domain.SMSChannel},
+		)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg.SMSScheduler = nil
+
+	emailExpectation := reconcile.NewExpectation()
+	smsExpectation := reconcile.NewExpectation()
+	smsExpectation.SetReleased(history.wasReleased)
+
+	_, err = pipeline.New(cfg).RunFile(ctx, source, pipeline.Hooks{
+		OnRecords: func(records []pipeline.Record) {
+			for _, record := range records {
+				addExpectation(emailExpectation, record.User.Email, record.Target.Email, record.Report.EmailStatus)
+				addExpectation(smsExpectation, record.User.PhoneNumber, record.Target.PhoneNumber, record.Report.SMSStatus)
+				if _, deferred := history.deferred[record.Index]; deferred {
+					smsExpectation.Defer(record.Target.PhoneNumber)
+				}
+			}
+		},
+		Attempted: history.attempted,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "%s 처리 실패", source)
+	}
+
+	if history.entries == 0 && *smsWindow != "" {
+		log.Warn("실행의 감사 기록이 없어 지금 SMS 대기열에 남은 번호를 보관한 SMS로 봅니다")
+		users, err := service.NewDeferredQueue(*deferredQueue).Users()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, user := range users {
+			smsExpectation.Defer(user.PhoneNumber)
+		}
+	}
+
+	return emailExpectation, smsExpectation, nil
+}
+
+// 입력 주소는 알려진 주소, 전송한 레코드는 전송에 사용한 주소(identity 기준이면 대표 연락처)가 대상
+func addExpectation(expectation *reconcile.Expectation, input, target string, status pipeline.Status) {
+	expectation.Add(input, false)
+	if status == pipeline.StatusSent {
+		expectation.Add(target, true)
+	}
+}
+
+// 실행 당시에만 알 수 있어 다시 계산하지 않는 판단 (감사 기록)
+type runHistory struct {
+	entries      int
+	deferred     map[int]struct{} // SMS를 대기열에 보관한 입력 순서
+	emailSkipped map[int]struct{} // 중단된 이전 실행에서 시도하여 건너뛴 입력 순서
+	smsSkipped   map[int]struct{}
+	released     map[string]struct{} // 대기열에서 꺼내 보낸 전화번호의 해시
+	hasher       *audit.Hasher
+}
+
+func loadRunHistory(runID string) (*runHistory, error) {
+	history := &runHistory{
+		deferred:     make(map[int]struct{}),
+		emailSkipped: make(map[int]struct{}),
+		smsSkipped:   make(map[int]struct{}),
+		released:     make(map[string]struct{}),
+	}
+	if runID == "" {
+		return history, nil
+	}
+
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return nil, err
+	}
+	history.hasher = auditLog.Hasher()
+
+	err = auditLog.Run(runID, func(entry audit.Entry) {
+		history.entries++
+		if entry.Released {
+			if entry.SMSChannel.Status == pipeline.StatusSent {
+				history.released[entry.PhoneHash] = struct{}{}
+			}
+			return
+		}
+
+		index := entry.Record - 1
+		switch entry.SMSChannel.Status {
+		case pipeline.StatusDeferred:
+			history.deferred[index] = struct{}{}
+		case pipeline.StatusSkipped:
+			history.smsSkipped[index] = struct{}{}
+		}
+		if entry.EmailChannel.Status == pipeline.StatusSkipped {
+			history.emailSkipped[index] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "감사 기록 읽기 실패")
+	}
+	return history, nil
+}
+
+// 실행에서 대기열로부터 꺼내 보낸 전화번호이면 true
+func (h *runHistory) wasReleased(phoneNumber string) bool {
+	if len(h.released) == 0 {
+		return false
+	}
+	_, released := h.released[h.hasher.Phone(phoneNumber)]
+	return released
+}
+
+// 실행에서 이미 시도했다고 건너뛴 전송이면 true (그 전송은 중단된 이전 실행의 출력에 있음)
+func (h *runHistory) attempted(index int, channel domain.NotificationChannel) bool {
+	skipped := h.smsSkipped
+	if channel == domain.EmailChannel {
+		skipped = h.emailSkipped
+	}
+	_, exists := skipped[index]
+	return exists
+}
+
+// 보내지 않고 성공으로 기록하는 전송 (대사에서 기대 수신자를 구할 때 사용)
+type plannedSends struct {
+	channel  domain.NotificationChannel
+	observer service.SendObserver
+}
+
+func (s *plannedSends) SendEmails(ctx context.Context, users []*domain.User) (int, error) {
+	return s.send(users), nil
+}
+
+func (s *plannedSends) SendSMS(ctx context.Context, users []*domain.User) (int, error) {
+	return s.send(users), nil
+}
+
+func (s *plannedSends) send(users []*domain.User) int {
+	for _, user := range users {
+		s.observer.Notify(user, s.channel, nil)
+	}
+	return len(users)
+}
+
+func (s *plannedSends) SetObserver(observer service.SendObserver) {
+	s.observer = observer
+}
+
+func (s *plannedSends) Stop() {}
+
+func printChannelReport(name, path string, report reconcile.ChannelReport) {
+	const maxPrinted = 10
+
+	if path == "" {
+		path = "출력 파일 없음"
+	}
+	fmt.Printf("\n[%s] %s\n", name, path)
+	fmt.Printf("- 대상 %d명, 출력 %d줄\n", report.Expected, report.Delivered)
+	fmt.Printf("- 누락 %d명, 중복 전송 %d명, 대상 아님 %d명\n", len(report.Missing), len(report.Duplicates), len(report.Unexpected))
+	if len(report.Deferred) > 0 || report.Released > 0 {
+		fmt.Printf("- 허용 시간대 밖이라 대기열 보관 %d명, 대기열에서 꺼내 전송 %d줄\n", len(report.Deferred), report.Released)
+	}
+
+	for i, address := range report.Missing {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 누락 %d명\n", len(report.Missing)-maxPrinted)
+			break
+		}
+		fmt.Printf("  누락: %s\n", address)
+	}
+	for i, duplicate := range report.Duplicates {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 중복 %d명\n", len(report.Duplicates)-maxPrinted)
+			break
+		}
+		fmt.Printf("  중복: %s (%d회)\n", duplicate.Address, duplicate.Count)
+	}
+	for i, unexpected := range report.Unexpected {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 대상 아님 %d명\n", len(report.Unexpected)-maxPrinted)
+			break
+		}
+		fmt.Printf("  대상 아님: %s (%s)\n", unexpected.Address, unexpected.Reason)
+	}
+}
+
+func printInputReport(name, path string, report reconcile.InputReport) {
+	const maxPrinted = 10
+
+	if path == "" {
+		path = "출력 파일 없음"
+	}
+	fmt.Printf("\n[%s 입력 확인] %s\n", name, path)
+	fmt.Printf("- 신용점수 상승 아님 %d명, 입력에 없음 %d명, 중복 %d명\n", len(report.NotCreditUp), len(report.NotInInput), len(report.Duplicates))
+
+	for i, address := range report.NotCreditUp {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 신용점수 상승 아님 %d명\n", len(report.NotCreditUp)-maxPrinted)
+			break
+		}
+		fmt.Printf("  신용점수 상승 아님: %s\n", address)
+	}
+	for i, address := range report.NotInInput {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 입력에 없음 %d명\n", len(report.NotInInput)-maxPrinted)
+			break
+		}
+		fmt.Printf("  입력에 없음: %s\n", address)
+	}
+	for i, duplicate := range report.Duplicates {
+		if i == maxPrinted {
+			fmt.Printf("  ... 외 중복 %d명\n", len(report.Duplicates)-maxPrinted)
+			break
+		}
+		fmt.Printf("  중복: %s (%d회)\n", duplicate.Address, duplicate.Count)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/job"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 새 입력 없이 SMS 대기열에서 전송 시각이 된 사용자를 보냄 (감시 모드의 확인 주기, replay)
+// 입력 파일을 처리할 때도 먼저 꺼내므로 새 파일이 오지 않아도 허용 시간대가 열리면 보내기 위함
+// 허용 시간대 밖이거나 꺼낼 사용자가 없으면 아무것도 하지 않음 (작업 이력, 출력 디렉토리를 만들지 않음)
+func releaseDeferred(ctx context.Context) error {
+	if *smsWindow == "" {
+		return nil
+	}
+
+	p, err := newPipeline(nil)
+	if err != nil {
+		return errors.Wrap(err, "처리 흐름 구성 실패")
+	}
+
+	source := *deferredQueue
+	runConfig := runConfigOrNil()
+	jobStore := job.NewStore(*jobStorePath)
+	record := job.NewRecord(source, "")
+
+	outputs := output.NewManager(outputDir)
+	mark, err := outputs.Mark()
+	if err != nil {
+		return err
+	}
+
+	// 대기열에서 꺼낸 레코드는 진행 기록 없이 대기열로 관리 (보내지 못한 사용자는 대기열에 남음)
+	run := stopper.begin(record.ID, source, nil)
+	defer stopper.end(run)
+
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return err
+	}
+	recorder := auditLog.NewRecorder()
+	result, err := p.Release(ctx, pipeline.Hooks{
+		OnStageDone: run.onStageDone,
+		OnSend:      recorder.Observe,
+		OnRecords: func(records []pipeline.Record) {
+			if err := auditLog.Append(recorder.Entries(record.ID, source, records)); err != nil {
+				log.WithError(err).Error("감사 기록 실패")
+			}
+			run.store(stopper.outbox, records)
+		},
+	})
+	if result == nil && err == nil {
+		return nil
+	}
+
+	// 꺼낼 사용자가 있었던 경우만 작업 이력에 남김
+	record.Finish(result, err, ctx.Err() == context.Canceled)
+	saveRecord(jobStore, record)
+
+	manifest, collectErr := outputs.Collect(output.Manifest{
+		RunID:     record.ID,
+		Source:    source,
+		StartTime: &record.StartTime,
+		Config:    runConfig,
+	}, mark)
+	if collectErr != nil {
+		log.WithError(collectErr).Error("실행별 출력 파일 정리 실패")
+	}
+
+	interrupted := ctx.Err() != nil && err != nil
+	run.record(newRunSummary(record.ID, source, result, err, interrupted, manifest))
+	run.settle(stopper.outbox, interrupted)
+	if err != nil {
+		return err
+	}
+
+	printReleased(record.ID, result, manifest)
+	return nil
+}
+
+func printReleased(runID string, result *pipeline.Result, manifest *output.Manifest) {
+	_, sms := result.Counts()
+
+	fmt.Println("=== SMS 대기열 전송 결과 ===")
+	fmt.Printf("작업 ID: %s\n", runID)
+	fmt.Printf("SMS 대기열에서 꺼냄: %d명 (허용 시간대 %s)\n", result.SMSReleased, *smsWindow)
+	fmt.Printf("SMS: 성공 %d명, 실패 %d명, 회로 차단 %d명 (실패와 회로 차단은 대기열에 남아 다음에 다시 꺼냄)\n", sms.Sent, sms.Failed, sms.Parked)
+	if result.SMSSuppressed > 0 {
+		fmt.Printf("수신 거부 제외: %d명\n", result.SMSSuppressed)
+	}
+	printOutputFiles(manifest)
+	fmt.Println()
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+// 아웃박스를 다시 보낼 때 한 번에 보내는 항목 수 (배치마다 보낸 항목을 아웃박스에서 지움)
+const replayBatchSize = 1000
+
+// 아웃박스에 보관한 알림 다시 전송: replay
+// 결과를 모르는 전송(unknown)은 이미 전달되었을 수 있으므로 보내지 않고 남겨 두며 수신 여부는 직접 확인
+// 다시 보내다 실패한 항목은 시도 횟수와 오류를 남기고 failed로, -replay-max-attempts 번 실패하면 dead로 바꿔 보내지 않음
+// 보관한 뒤 바뀐 규칙과 수신 거부 목록을 다시 적용하고, SMS 허용 시간대(-sms-window) 밖이면 SMS는 남겨 둠
+// 서버나 다른 replay가 같은 -outbox 를 쓰는 동안 실행하지 않음
+// 허용 시간대가 열렸으면 SMS 대기열에서 전송 시각이 된 사용자도 먼저 보냄
+func runReplay(ctx context.Context) error {
+	if err := releaseDeferred(ctx); err != nil {
+		return errors.Wrap(err, "SMS 대기열 전송 실패")
+	}
+	if ctx.Err() != nil {
+		stopper.exit()
+	}
+
+	plan, run, manifest, err := replayOutbox(ctx, nil)
+	if plan == nil {
+		if err == nil {
+			fmt.Printf("%s 에 다시 보낼 알림이 없습니다.\n", *outboxPath)
+		}
+		return err
+	}
+
+	printReplay(plan, run, manifest)
+	if ctx.Err() != nil {
+		stopper.exit()
+	}
+	return err
+}
+
+// 감시 모드의 확인 주기마다 회로 차단기로 보관한 항목을 다시 보냄 (회로 차단기가 닫힌 채널만)
+// 같은 프로세스의 회로 차단기를 쓰므로 장애가 이어지는 동안에는 아웃박스에 그대로 남김
+func replayParked(ctx context.Context) {
+	plan, run, manifest, err := replayOutbox(ctx, func(entry service.OutboxEntry, channel domain.NotificationChannel) bool {
+		return entry.Reason == service.OutboxCircuitOpen && breakerClosed(channel)
+	})
+	if err != nil && ctx.Err() == nil {
+		log.WithError(err).Error("회로 차단으로 보관한 알림 재전송 실패 (다음 주기에 재시도)")
+	}
+	if plan != nil && (len(plan.sends) > 0 || len(plan.dropped) > 0) {
+		printReplay(plan, run, manifest)
+		fmt.Println()
+	}
+}
+
+// replay가 이번에 처리할 항목 (false면 아웃박스에 그대로 남김)
+type replayFilter func(entry service.OutboxEntry, channel domain.NotificationChannel) bool
+
+// 아웃박스를 꺼내 filter가 고른 항목을 다시 보내고 보낸 항목, 제외된 항목을 지움 (꺼낸 항목이 없으면 plan이 nil)
+func replayOutbox(ctx context.Context, filter replayFilter) (*replayPlan, *replayRun, *output.Manifest, error) {
+	claim, err := stopper.outbox.Claim()
+	if err != nil || len(claim.Entries) == 0 {
+		return nil, nil, nil, err
+	}
+
+	plan, err := newReplayPlan(claim.Entries, filter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := claim.Commit(plan.dropped); err != nil {
+		return nil, nil, nil, err
+	}
+
+	run := stopper.beginReplay(claim)
+	defer stopper.endReplay(run)
+	if len(plan.sends) == 0 {
+		return plan, run, nil, nil
+	}
+
+	// 다시 보낸 전송마다 감사 기록을 남겨 audit lookup에서 보관 이후의 결과를 확인
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	outputs := output.NewManager(outputDir)
+	mark, err := outputs.Mark()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	startTime := time.Now().In(domain.KST)
+	runID := startTime.Format("20060102-150405") + "-replay"
+	run.audit(runID, auditLog)
+
+	sendErr := run.send(ctx, plan.sends)
+
+	manifest, err := outputs.Collect(output.Manifest{
+		RunID:     runID,
+		StartTime: &startTime,
+	}, mark)
+	if err != nil {
+		log.WithError(err).Error("실행별 출력 파일 정리 실패")
+	}
+	return plan, run, manifest, sendErr
+}
+
+// 다시 보낼 항목 하나 (index는 꺼낸 항목의 순서)
+type replaySend struct {
+	index   int
+	channel domain.NotificationChannel
+	user    *domain.User
+}
+
+// 꺼낸 항목의 처리 방법
+type replayPlan struct {
+	sends      []replaySend
+	dropped    []int // 규칙, 수신 거부로 제외했거나 이미 다시 보낼 항목과 주소가 같아 지울 항목
+	unknown    int   // 결과를 몰라 남겨 둔 항목
+	dead       int   // 다시 보내다 최대 횟수만큼 실패해 남겨 둔 항목
+	deferred   int   // SMS 허용 시간대 밖이라 남겨 둔 항목
+	invalid    int   // 채널을 알 수 없어 남겨 둔 항목
+	skipped    int   // filter가 고르지 않아 남겨 둔 항목
+	excluded   int
+	suppressed int
+	duplicates int
+}
+
+func newReplayPlan(entries []service.OutboxEntry, filter replayFilter) (*replayPlan, error) {
+	creditProcessor, err := newCreditProcessor()
+	if err != nil {
+		return nil, errors.Wrap(err, "알림 대상 규칙 로딩 실패")
+	}
+
+	suppressionList, err := loadSuppressionList()
+	if err != nil {
+		return nil, errors.Wrap(err, "수신 거부 목록 로딩 실패")
+	}
+
+	var quietHours *service.QuietHoursPolicy
+	if *smsWindow != "" {
+		quietHours, err = service.NewQuietHoursPolicy(*smsWindow, domain.KST)
+		if err != nil {
+			return nil, errors.Wrap(err, "SMS 허용 시간대 설정 오류")
+		}
+	}
+	now := time.Now()
+
+	plan := &replayPlan{}
+	seen := make(map[string]struct{}, len(entries))
+	for index, entry := range entries {
+		switch entry.Reason {
+		case service.OutboxUnknown:
+			plan.unknown++
+			continue
+		case service.OutboxDead:
+			plan.dead++
+			continue
+		}
+
+		channel, err := domain.ParseNotificationChannel(entry.Channel)
+		if err != nil || entry.User == nil {
+			log.WithField("run", entry.RunID).WithField("channel", entry.Channel).Warn("아웃박스 항목을 다시 보낼 수 없어 남겨 둡니다")
+			plan.invalid++
+			continue
+		}
+
+		if filter != nil && !filter(entry, channel) {
+			plan.skipped++
+			continue
+		}
+
+		address := domain.NormalizeEmail(entry.User.Email)
+		if channel == domain.SMSChannel {
+			address = domain.NormalizePhoneNumber(entry.User.PhoneNumber)
+		}
+
+		switch {
+		case !creditProcessor.EvaluateChannel(entry.User, channel).Eligible:
+			plan.excluded++
+			plan.dropped = append(plan.dropped, index)
+		case suppressionList.IsSuppressed(entry.User, channel):
+			plan.suppressed++
+			plan.dropped = append(plan.dropped, index)
+		case channel == domain.SMSChannel && quietHours != nil && !quietHours.IsAllowed(now):
+			plan.deferred++
+		default:
+			key := channel.String() + ":" + address
+			if _, exists := seen[key]; exists {
+				plan.duplicates++
+				plan.dropped = append(plan.dropped, index)
+				continue
+			}
+			seen[key] = struct{}{}
+			plan.sends = append(plan.sends, replaySend{index: index, channel: channel, user: entry.User})
+		}
+	}
+	return plan, nil
+}
+
+// 실행 중인 replay (강제 종료 시 보낸 항목을 지우고 결과를 모르는 항목을 표시할 대상)
+type replayRun struct {
+	claim *service.OutboxClaim
+
+	mu       sync.Mutex
+	sending  map[*domain.User]int // 전송할 사용자 → 항목 순서 (현재 배치)
+	started  map[int]struct{}     // 전송을 시작했지만 결과를 받지 못한 항목
+	sent     []int                // 보냈지만 아직 아웃박스에서 지우지 않은 항목
+	failures map[int]error        // 실패했지만 아직 아웃박스에 기록하지 않은 항목 (회로 차단 제외)
+	aborted  bool
+
+	runID    string
+	auditLog *audit.Log
+	recorder *audit.Recorder
+	audits   []audit.Entry // 아직 감사 기록에 남기지 않은 전송
+
+	success int
+	failed  int
+	parked  int
+}
+
+// 실행 ID와 감사 기록 (전송 전에 호출)
+func (r *replayRun) audit(runID string, auditLog *audit.Log) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runID = runID
+	r.auditLog = auditLog
+	r.recorder = auditLog.NewRecorder()
+}
+
+func (r *replayRun) send(ctx context.Context, sends []replaySend) error {
+	if len(sends) == 0 {
+		return nil
+	}
+
+	renderer, err := loadRenderer()
+	if err != nil {
+		return err
+	}
+	emailClient, smsClient := newChannelClients()
+	manager := service.NewNotificationManagerWithServices(
+		service.NewEmailServiceWithRenderer(emailClient, renderer),
+		service.NewSMSServiceWithRenderer(smsClient, renderer),
+	)
+	defer manager.Close()
+	manager.SetStartObserver(r.onStart)
+	manager.SetObserver(r.onResult)
+
+	for start := 0; start < len(sends); start += replayBatchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		batch := sends[start:min(start+replayBatchSize, len(sends))]
+		var emailUsers, smsUsers []*domain.User
+		r.mu.Lock()
+		r.sending = make(map[*domain.User]int, len(batch))
+		for _, send := range batch {
+			r.sending[send.user] = send.index
+			if send.channel == domain.EmailChannel {
+				emailUsers = append(emailUsers, send.user)
+			} else {
+				smsUsers = append(smsUsers, send.user)
+			}
+		}
+		r.mu.Unlock()
+
+		_, _, sendErr := manager.SendChannelNotifications(ctx, emailUsers, smsUsers)
+		if err := r.commit(); err != nil {
+			return err
+		}
+		if sendErr != nil {
+			return sendErr
+		}
+	}
+	return nil
+}
+
+func (r *replayRun) onStart(user *domain.User, channel domain.NotificationChannel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if index, exists := r.sending[user]; exists {
+		r.started[index] = struct{}{}
+	}
+}
+
+// 보낸 항목만 지우고 실패하거나 회로 차단으로 보내지 못한 항목은 남겨 다음 replay에서 다시 보냄
+// 회로 차단은 시도로 세지 않고 circuit_open으로 남겨 감시 모드가 다시 보내며, 실패는 failed로 바꿔 replay에서만 다시 보냄
+func (r *replayRun) onResult(user *domain.User, channel domain.NotificationChannel, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, exists := r.sending[user]
+	if !exists {
+		return
+	}
+	delete(r.started, index)
+
+	status := pipeline.StatusSent
+	switch {
+	case err == nil:
+		r.success++
+		r.sent = append(r.sent, index)
+	case errors.Is(err, service.ErrCircuitOpen):
+		status = pipeline.StatusParked
+		r.parked++
+	default:
+		status = pipeline.StatusFailed
+		r.failed++
+		r.failures[index] = err
+	}
+	r.record(index, user, channel, status, err)
+}
+
+// 전송 하나의 감사 기록 (잠금 안에서 호출)
+func (r *replayRun) record(index int, user *domain.User, channel domain.NotificationChannel, status pipeline.Status, err error) {
+	if r.recorder == nil {
+		return
+	}
+	if status != pipeline.StatusUnknown {
+		r.recorder.Observe(user, channel, err)
+	}
+	r.audits = append(r.audits, r.recorder.Replayed(r.runID, *outboxPath, r.claim.Entries[index].RunID, user, channel, status, err))
+}
+
+// 보낸 항목을 아웃박스에서 지우고 실패한 항목의 시도를 기록 (강제 종료 경로와 겹치지 않도록 잠금 안에서)
+func (r *replayRun) commit() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.aborted {
+		return nil
+	}
+	return r.flush()
+}
+
+func (r *replayRun) flush() error {
+	if err := r.claim.Commit(r.sent); err != nil {
+		return err
+	}
+	r.sent = nil
+	if err := r.claim.MarkFailed(r.failures, *replayAttempts); err != nil {
+		return err
+	}
+	r.failures = make(map[int]error)
+
+	if r.auditLog != nil {
+		if err := r.auditLog.Append(r.audits); err != nil {
+			log.WithError(err).Error("감사 기록 실패")
+		}
+	}
+	r.audits = nil
+	return nil
+}
+
+// 강제 종료: 보낸 항목을 지우고 전송 중인 항목은 결과 모름으로 바꿔 다음 replay에서 보내지 않음
+func (r *replayRun) abort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.aborted {
+		return
+	}
+	r.aborted = true
+
+	started := make([]int, 0, len(r.started))
+	for user, index := range r.sending {
+		if _, exists := r.started[index]; !exists {
+			continue
+		}
+		started = append(started, index)
+		channel, _ := domain.ParseNotificationChannel(r.claim.Entries[index].Channel)
+		r.record(index, user, channel, pipeline.StatusUnknown, nil)
+	}
+	if err := r.flush(); err != nil {
+		log.WithError(err).Error("아웃박스 정리 실패")
+	}
+	if err := r.claim.MarkUnknown(started); err != nil {
+		log.WithError(err).Error("아웃박스 정리 실패")
+	}
+	if len(started) > 0 {
+		fmt.Printf("전송 중 중단되어 결과를 모르는 %d건은 %s 에 unknown으로 남겼습니다.\n", len(started), *outboxPath)
+	}
+}
+
+func printReplay(plan *replayPlan, run *replayRun, manifest *output.Manifest) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	fmt.Println("=== 아웃박스 재전송 결과 ===")
+	fmt.Printf("다시 보낸 알림: 성공 %d건, 실패 %d건, 회로 차단 %d건 (실패는 failed, 회로 차단은 circuit_open으로 남겨 둠)\n", run.success, run.failed, run.parked)
+	fmt.Printf("제외하여 지움: 규칙 %d건, 수신 거부 %d건, 주소 중복 %d건\n", plan.excluded, plan.suppressed, plan.duplicates)
+	if plan.deferred > 0 {
+		fmt.Printf("SMS 허용 시간대(%s) 밖이라 남겨 둠: %d건\n", *smsWindow, plan.deferred)
+	}
+	if plan.skipped > 0 {
+		fmt.Printf("이번 재전송 대상이 아니라 남겨 둠: %d건 (감시 모드는 회로 차단기가 닫힌 채널의 회로 차단 보관만, 나머지는 replay)\n", plan.skipped)
+	}
+	if plan.invalid > 0 {
+		fmt.Printf("채널을 알 수 없어 남겨 둠: %d건\n", plan.invalid)
+	}
+	if plan.dead > 0 {
+		fmt.Printf("%d번 실패해 보내지 않음: %d건 (%s 의 last_error를 확인한 뒤 직접 정리)\n", *replayAttempts, plan.dead, *outboxPath)
+	}
+	if plan.unknown > 0 {
+		fmt.Printf("결과를 몰라 보내지 않음: %d건 (이미 전달되었을 수 있으므로 수신 여부를 확인한 뒤 %s 에서 직접 정리)\n", plan.unknown, *outboxPath)
+	}
+	if manifest != nil {
+		fmt.Printf("실행별 출력: %s\n", manifest.Dir)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/job"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/server"
+	"banksalad-backend-task/internal/service"
+)
+
+// HTTP API로 알림 작업을 받아 처리 (모든 작업이 클라이언트, 회로 차단기, SMS 속도 제한기를 공유)
+// 클라이언트가 출력 파일을 하나씩 열어 두므로 출력 파일은 서버 실행 단위로 분리 (작업별 전송 내역은 감사 기록)
+func serve(ctx context.Context, addr string) error {
+	if *serveToken == "" {
+		return errors.New("-serve-token 또는 NOTIFY_API_TOKEN 환경 변수로 인증 토큰을 지정해야 합니다")
+	}
+
+	renderer, err := loadRenderer()
+	if err != nil {
+		return err
+	}
+
+	outputs := output.NewManager(outputDir)
+	mark, err := outputs.Mark()
+	if err != nil {
+		return err
+	}
+	startTime := time.Now().In(domain.KST)
+	runConfig := runConfigOrNil()
+
+	emailClient, smsClient := newChannelClients()
+	rateLimiter := service.NewRateLimiter(100, time.Second)
+	defer rateLimiter.Stop()
+
+	p, err := newPipeline(func() *service.NotificationManager {
+		return service.NewNotificationManagerWithServices(
+			service.NewEmailServiceWithRenderer(emailClient, renderer),
+			service.NewSMSServiceWithRateLimiter(smsClient, renderer, rateLimiter),
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return err
+	}
+
+	manager := job.NewManager(ctx, job.NewStore(*jobStorePath))
+	manager.SetAuditLog(auditLog)
+	manager.SetOutbox(stopper.outbox)
+	manager.SetRetention(*jobRetention, *jobMaxFinished)
+	httpServer := &http.Server{
+		Addr: addr,
+		Handler: server.New(manager, p, server.Config{
+			Token:        *serveToken,
+			InputDir:     *serveInputDir,
+			MaxBodyBytes: *serveMaxBody,
+		}).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		// 새 요청은 받지 않고 진행 중인 요청만 마무리 (작업은 ctx로 취소되어 남은 전송을 아웃박스에 보관)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownGrace)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.WithError(err).Error("failed to shutdown http server")
+		}
+	}()
+
+	fmt.Printf("알림 작업 API 대기 중: %s\n\n", addr)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return errors.Wrap(err, "HTTP 서버 실행 실패")
+	}
+
+	// 취소된 작업이 진행 중인 전송을 마무리할 때까지 대기 후 속도 제한기 중지 (유예 시간을 넘으면 강제 종료)
+	manager.Wait()
+
+	manifest, err := outputs.Collect(output.Manifest{
+		RunID:     startTime.Format("20060102-150405") + "-serve",
+		StartTime: &startTime,
+		Config:    runConfig,
+	}, mark)
+	if err != nil {
+		return errors.Wrap(err, "서버 실행 출력 파일 정리 실패")
+	}
+	fmt.Printf("출력 파일을 %s 에 정리했습니다.\n", manifest.Dir)
+	return nil
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/ingest"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+// 시그널 처리 (main에서 설정)
+var stopper *shutdown
+
+// 두 단계 종료
+// 1단계(첫 시그널): 컨텍스트를 취소하여 새 입력 파일과 새 전송을 시작하지 않고, 이미 시작한 전송은 마무리
+// 2단계(유예 시간 초과 또는 두 번째 시그널): 실행 중인 입력의 남은 전송을 아웃박스에 기록하고 즉시 종료
+type shutdown struct {
+	cancel context.CancelFunc
+	grace  time.Duration
+	outbox *service.Outbox
+
+	mu     sync.Mutex
+	run    *activeRun
+	replay *replayRun
+}
+
+func newShutdown(cancel context.CancelFunc, grace time.Duration, outbox *service.Outbox) *shutdown {
+	return &shutdown{
+		cancel: cancel,
+		grace:  grace,
+		outbox: outbox,
+	}
+}
+
+// 시그널을 기다림 (고루틴으로 실행)
+func (s *shutdown) listen() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithField("panic", r).Error("recovered from panic in signal handler")
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 2)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+	fmt.Printf("\n종료 요청을 받았습니다. 진행 중인 전송을 마무리합니다... (최대 %v, 다시 누르면 즉시 종료)\n", s.grace)
+	s.cancel()
+
+	select {
+	case <-sigChan:
+		fmt.Println("\n즉시 종료합니다...")
+	case <-time.After(s.grace):
+		fmt.Printf("\n유예 시간(%v) 안에 전송이 끝나지 않아 종료합니다...\n", s.grace)
+	}
+	s.force()
+}
+
+// 실행 중인 입력의 남은 전송을 기록하고 종료 (시작하지 않은 전송은 미전송, 끝나지 않은 전송은 결과 모름으로 기록)
+func (s *shutdown) force() {
+	s.mu.Lock()
+	run, replay := s.run, s.replay
+	s.mu.Unlock()
+
+	if replay != nil {
+		replay.abort()
+	}
+	if run != nil {
+		run.settle(s.outbox, true)
+		run.record(newRunSummary(run.id, run.source, run.current(), context.Canceled, true, nil))
+	}
+	finish(exitInterrupted)
+}
+
+// 진행 중인 전송을 마무리한 뒤 종료
+func (s *shutdown) exit() {
+	fmt.Println("종료 요청으로 처리를 중단했습니다.")
+	finish(exitInterrupted)
+}
+
+// 입력 파일 하나의 실행 시작 (강제 종료 시 남은 전송을 기록할 대상)
+func (s *shutdown) begin(runID, source string, progress *ingest.Progress) *activeRun {
+	run := &activeRun{
+		id:       runID,
+		source:   source,
+		progress: progress,
+	}
+
+	s.mu.Lock()
+	s.run = run
+	s.mu.Unlock()
+	return run
+}
+
+func (s *shutdown) end(run *activeRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.run == run {
+		s.run = nil
+	}
+}
+
+// 아웃박스 재전송 시작 (강제 종료 시 보낸 항목과 결과를 모르는 항목을 정리할 대상)
+func (s *shutdown) beginReplay(claim *service.OutboxClaim) *replayRun {
+	replay := &replayRun{
+		claim:    claim,
+		started:  make(map[int]struct{}),
+		failures: make(map[int]error),
+	}
+
+	s.mu.Lock()
+	s.replay = replay
+	s.mu.Unlock()
+	return replay
+}
+
+func (s *shutdown) endReplay(replay *replayRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.replay == replay {
+		s.replay = nil
+	}
+}
+
+type activeRun struct {
+	id       string
+	source   string
+	progress *ingest.Progress // 다시 처리할 때 건너뛸 전송
+
+	mu       sync.Mutex
+	result   *pipeline.Result // 파싱이 끝난 뒤 설정
+	stored   int              // 배치마다 아웃박스에 기록한 항목 수
+	settled  sync.Once
+	recorded sync.Once
+}
+
+func (r *activeRun) onStageDone(stage pipeline.Stage, result *pipeline.Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.result = result
+}
+
+func (r *activeRun) current() *pipeline.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.result
+}
+
+// 끝난 배치의 시도한 전송을 진행 기록에, 회로 차단기로 보관한 전송과 결과를 모르는 전송을 아웃박스에 기록
+// 시작하지 못한 전송은 아웃박스에 넣지 않고 같은 입력 파일을 다시 처리할 때 진행 기록으로 건너뛴 나머지를 전송
+func (r *activeRun) store(outbox *service.Outbox, records []pipeline.Record) {
+	if err := r.progress.Append(attemptedSends(records)); err != nil {
+		log.WithError(err).WithField("job", r.id).Error("진행 기록 실패")
+	}
+
+	entries := keptEntries(pipeline.OutboxEntries(r.id, records))
+	if len(entries) == 0 {
+		return
+	}
+	if err := outbox.Append(entries); err != nil {
+		log.WithError(err).WithField("job", r.id).Error("아웃박스 기록 실패")
+		return
+	}
+
+	r.mu.Lock()
+	r.stored += len(entries)
+	r.mu.Unlock()
+}
+
+// 다시 처리할 때 보내지 않을 전송 (전송 성공, 실패, 회로 차단 보관, 대기열 보관, 결과 모름), 대기열에서 꺼낸 레코드는 대기열에서 관리
+func attemptedSends(records []pipeline.Record) []ingest.ProgressEntry {
+	entries := make([]ingest.ProgressEntry, 0, len(records))
+	for _, record := range records {
+		if record.Report.Released {
+			continue
+		}
+		for _, channel := range []struct {
+			channel domain.NotificationChannel
+			status  pipeline.Status
+		}{
+			{domain.EmailChannel, record.Report.EmailStatus},
+			{domain.SMSChannel, record.Report.SMSStatus},
+		} {
+			switch channel.status {
+			case pipeline.StatusSent, pipeline.StatusFailed, pipeline.StatusParked, pipeline.StatusDeferred, pipeline.StatusUnknown:
+				entries = append(entries, ingest.ProgressEntry{Index: record.Index, Channel: channel.channel})
+			}
+		}
+	}
+	return entries
+}
+
+// 회로 차단기로 보관한 전송과 결과를 모르는 전송만 (시작하지 못한 전송은 진행 기록으로 다시 처리)
+func keptEntries(entries []service.OutboxEntry) []service.OutboxEntry {
+	kept := make([]service.OutboxEntry, 0, len(entries))
+	for _, entry := range entries {
+		switch entry.Reason {
+		case service.OutboxCircuitOpen, service.OutboxUnknown:
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// 실행 요약에 한 번만 추가 (처리 흐름이 끝난 경로와 강제 종료 경로 중 먼저 호출한 쪽)
+func (r *activeRun) record(run runSummary) {
+	r.recorded.Do(func() {
+		if summaries != nil {
+			summaries.add(run)
+		}
+	})
+}
+
+// 전송 중인 배치를 store와 같이 기록하고 중단되었으면 부분 결과 출력 (끝난 배치는 OnRecords에서 기록)
+// 처리 흐름이 끝난 경로와 강제 종료 경로 중 먼저 호출한 쪽만 실행 (나중 쪽은 끝날 때까지 대기)
+func (r *activeRun) settle(outbox *service.Outbox, interrupted bool) {
+	r.settled.Do(func() {
+		result := r.current()
+		if result == nil {
+			if interrupted {
+				fmt.Printf("작업 %s: 파싱 중에 중단되어 전송한 알림이 없습니다.\n", r.id)
+			}
+			return
+		}
+
+		r.store(outbox, result.Pending())
+		if interrupted {
+			r.mu.Lock()
+			stored := r.stored
+			r.mu.Unlock()
+			printInterrupted(r.id, r.source, result, stored)
+		}
+	})
+}
+
+func printInterrupted(runID, source string, result *pipeline.Result, stored int) {
+	email, sms := result.Counts()
+
+	fmt.Println("\n=== 중단된 실행 요약 ===")
+	fmt.Printf("작업 ID: %s\n", runID)
+	fmt.Printf("입력 파일: %s\n", source)
+	fmt.Printf("전체 사용자: %d명\n", result.TotalUsers)
+	fmt.Printf("이메일: 성공 %d명, 실패 %d명, 미전송 %d명\n", email.Sent, email.Failed, email.Unsent)
+	fmt.Printf("SMS: 성공 %d명, 실패 %d명, 미전송 %d명\n", sms.Sent, sms.Failed, sms.Unsent)
+	if email.Parked > 0 || sms.Parked > 0 {
+		fmt.Printf("회로 차단으로 보관: 이메일 %d명, SMS %d명\n", email.Parked, sms.Parked)
+	}
+	if email.Unknown > 0 || sms.Unknown > 0 {
+		fmt.Printf("전송 중 중단되어 결과를 모름: 이메일 %d명, SMS %d명\n", email.Unknown, sms.Unknown)
+	}
+	if stored > 0 {
+		fmt.Printf("회로 차단으로 보내지 못하거나 결과를 모르는 %d건을 %s 에 보관했습니다. (회로 차단 보관은 replay 로 다시 전송)\n", stored, *outboxPath)
+	}
+	if email.Unknown > 0 || sms.Unknown > 0 {
+		fmt.Println("결과를 모르는 알림은 이미 전달되었을 수 있어 replay가 다시 보내지 않습니다. 수신 여부를 확인한 뒤 직접 처리하세요.")
+	}
+	if email.Unsent > 0 || sms.Unsent > 0 {
+		fmt.Println("미전송 알림은 같은 입력 파일을 다시 처리하면 이미 시도한 전송을 건너뛰고 보냅니다.")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/output"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 스케줄러(cron, Airflow 등)가 실행 결과를 구분하기 위한 종료 코드
+const (
+	exitSuccess      = 0
+	exitFailure      = 1   // 설정 오류, 처리 중 오류
+	exitPartial      = 2   // 처리는 끝났지만 전송 실패율이 -partial-threshold 를 넘거나 회로 차단으로 보관한 전송, 거부 라인이 있음
+	exitParseFailure = 3   // 입력 파일을 파싱하지 못함
+	exitInterrupted  = 130 // 종료 요청으로 중단 (128 + SIGINT)
+)
+
+// 입력 파일 하나의 처리 상태
+const (
+	runCompleted   = "completed"
+	runPartial     = "partial"
+	runParseFailed = "parse_failed"
+	runFailed      = "failed"
+	runCancelled   = "cancelled"
+)
+
+// 실행 요약 (입력 처리 모드에서 main이 설정)
+var summaries *summary
+
+// 프로세스 한 번의 실행 요약 (JSON, 종료 직전에 기록)
+type summary struct {
+	Status    string       `json:"status"` // success, partial, parse_failed, failed, cancelled
+	ExitCode  int          `json:"exit_code"`
+	StartTime time.Time    `json:"start_time"`
+	EndTime   time.Time    `json:"end_time"`
+	Runs      []runSummary `json:"runs"` // 처리한 입력 파일 순서
+
+	path string
+	mu   sync.Mutex
+}
+
+// 입력 파일 하나의 처리 결과 (printResults와 같은 통계)
+type runSummary struct {
+	RunID            string         `json:"run_id,omitempty"`
+	Source           string         `json:"source"`
+	Status           string         `json:"status"`
+	Error            string         `json:"error,omitempty"`
+	StartTime        *time.Time     `json:"start_time,omitempty"`
+	EndTime          *time.Time     `json:"end_time,omitempty"`
+	DurationMS       int64          `json:"duration_ms"`
+	TotalUsers       int            `json:"total_users"`
+	RejectedLines    int            `json:"rejected_lines"`
+	EligibleUsers    int            `json:"eligible_users"`
+	EligibleRate     float64        `json:"eligible_rate"` // 전체 사용자 대비 비율 (%)
+	UniqueUsers      int            `json:"unique_users"`
+	Email            channelSummary `json:"email"`
+	SMS              channelSummary `json:"sms"`
+	BothSuccess      int            `json:"both_success"`
+	FailureRate      float64        `json:"failure_rate"`            // 시도한 전송(성공, 실패) 대비 실패 비율 (0~1, 두 채널 합산)
+	SkippedSends     int            `json:"skipped_sends,omitempty"` // 중단된 이전 실행에서 시도하여 건너뛴 전송
+	AvgTimePerUserMS float64        `json:"avg_time_per_user_ms"`
+	OutputDir        string         `json:"output_dir,omitempty"`
+	OutputFiles      []output.File  `json:"output_files,omitempty"`
+}
+
+// 채널별 인원 (parked는 회로 차단기로 보관한 전송, unsent는 중단되어 시작하지 않은 전송, unknown은 전송 중 강제 종료되어 결과를 모르는 전송)
+type channelSummary struct {
+	Targets    int `json:"targets"`
+	Suppressed int `json:"suppressed"`
+	Deferred   int `json:"deferred"`
+	Released   int `json:"released"` // 이전 실행의 대기열에서 꺼낸 인원 (SMS)
+	Duplicates int `json:"duplicates"`
+	Success    int `json:"success"`
+	Failed     int `json:"failed"`
+	Parked     int `json:"parked"`
+	Unsent     int `json:"unsent"`
+	Unknown    int `json:"unknown,omitempty"`
+}
+
+func newSummary(path string) *summary {
+	return &summary{
+		StartTime: time.Now().In(domain.KST),
+		Runs:      make([]runSummary, 0),
+		path:      path,
+	}
+}
+
+// 처리 결과로 입력 파일 하나의 요약 생성 (result가 nil이면 파싱 전에 끝난 경우)
+func newRunSummary(runID, source string, result *pipeline.Result, err error, cancelled bool, manifest *output.Manifest) runSummary {
+	run := runSummary{
+		RunID:  runID,
+		Source: source,
+	}
+
+	var parseErr *pipeline.ParseError
+	switch {
+	case cancelled:
+		run.Status = runCancelled
+	case errors.As(err, &parseErr):
+		run.Status = runParseFailed
+	case err != nil:
+		run.Status = runFailed
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	if manifest != nil {
+		run.OutputDir = manifest.Dir
+		run.OutputFiles = manifest.Files
+	}
+
+	if result == nil {
+		return run
+	}
+
+	run.StartTime = &result.StartTime
+	if !result.EndTime.IsZero() {
+		run.EndTime = &result.EndTime
+		run.DurationMS = result.EndTime.Sub(result.StartTime).Milliseconds()
+	}
+	run.TotalUsers = result.TotalUsers
+	run.RejectedLines = result.RejectedLines
+	run.EligibleUsers = result.EligibleUsers
+	if result.TotalUsers > 0 {
+		run.EligibleRate = float64(result.EligibleUsers) / float64(result.TotalUsers) * 100
+	}
+	run.UniqueUsers = result.UniqueUsers
+	if result.UniqueUsers > 0 {
+		run.AvgTimePerUserMS = float64(result.EndTime.Sub(result.StartTime).Microseconds()) / float64(result.UniqueUsers) / 1000
+	}
+
+	email, sms := result.Counts()
+	run.Email = channelSummary{
+		Targets:    result.EmailTargets,
+		Suppressed: result.EmailSuppressed,
+		Duplicates: result.EmailDuplicates,
+		Success:    email.Sent,
+		Failed:     email.Failed,
+		Parked:     email.Parked,
+		Unsent:     email.Unsent,
+		Unknown:    email.Unknown,
+	}
+	run.SMS = channelSummary{
+		Targets:    result.SMSTargets,
+		Suppressed: result.SMSSuppressed,
+		Deferred:   result.SMSDeferred,
+		Released:   result.SMSReleased,
+		Duplicates: result.SMSDuplicates,
+		Success:    sms.Sent,
+		Failed:     sms.Failed,
+		Parked:     sms.Parked,
+		Unsent:     sms.Unsent,
+		Unknown:    sms.Unknown,
+	}
+	run.BothSuccess = min(email.Sent, sms.Sent)
+	run.SkippedSends = result.SkippedSends
+	if attempted := email.Sent + email.Failed + sms.Sent + sms.Failed; attempted > 0 {
+		run.FailureRate = float64(email.Failed+sms.Failed) / float64(attempted)
+	}
+
+	if run.Status == "" {
+		run.Status = runCompleted
+		if run.partial(*partialThreshold) {
+			run.Status = runPartial
+		}
+	}
+	return run
+}
+
+// 전송 실패율이 threshold를 넘거나 회로 차단으로 보관한 전송, 거부 라인이 있으면 부분 실패
+// 전송 클라이언트는 일정 비율로 무작위 실패하므로 threshold 이하의 실패는 성공으로 봄 (0이면 실패가 하나라도 있으면 부분 실패)
+func (r runSummary) partial(threshold float64) bool {
+	if r.Email.Parked > 0 || r.SMS.Parked > 0 || r.RejectedLines > 0 {
+		return true
+	}
+	if r.Email.Failed+r.SMS.Failed == 0 {
+		return false
+	}
+	return r.FailureRate > threshold
+}
+
+func (s *summary) add(run runSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Runs = append(s.Runs, run)
+}
+
+// 처리한 입력 파일 중 하나라도 부분 실패가 있으면 exitPartial
+func (s *summary) exitCode() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, run := range s.Runs {
+		if run.Status == runPartial {
+			return exitPartial
+		}
+	}
+	return exitSuccess
+}
+
+// 임시 파일에 기록 후 교체하여 스케줄러가 쓰다 만 파일을 읽지 않도록 함
+func (s *summary) write(exitCode int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ExitCode = exitCode
+	s.Status = exitStatus(exitCode)
+	s.EndTime = time.Now().In(domain.KST)
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "실행 요약 생성 실패")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.Wrap(err, "실행 요약 디렉토리 생성 실패")
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "실행 요약 기록 실패")
+	}
+	return errors.Wrap(os.Rename(tmpPath, s.path), "실행 요약 파일 교체 실패")
+}
+
+func exitStatus(exitCode int) string {
+	switch exitCode {
+	case exitSuccess:
+		return "success"
+	case exitPartial:
+		return runPartial
+	case exitParseFailure:
+		return runParseFailed
+	case exitInterrupted:
+		return runCancelled
+	default:
+		return runFailed
+	}
+}
+
+// 실행 요약을 기록하고 종료 코드로 종료 (요약 기록 실패로 종료 코드를 바꾸지 않음)
+func finish(exitCode int) {
+	if summaries != nil {
+		if err := summaries.write(exitCode); err != nil {
+			log.WithError(err).Error("실행 요약 기록 실패")
+		}
+	}
+	os.Exit(exitCode)
+}
@@ -0,0 +1,253 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+type mockClient struct {
+	mu      sync.Mutex
+	failFor string
+}
+
+func (m *mockClient) Send(to string, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if to == m.failFor {
+		return errors.New("전송 실패")
+	}
+	return nil
+}
+
+func TestRecorder_Entries(t *testing.T) {
+	// Given: 전송, 제외, 중복, 전송 실패 레코드를 처리한 실행
+	users := []*domain.User{
+		createUser(t, "sent@example.com", "010-1234-0001", true),
+		createUser(t, "down@example.com", "010-1234-0002", false),
+		createUser(t, "sent@example.com", "010-1234-0003", true),
+		createUser(t, "fail@example.com", "010-1234-0004", true),
+	}
+	p := pipeline.New(pipeline.Config{
+		DuplicateStrategy: domain.ByEmail,
+		BatchSize:         3,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{failFor: "fail@example.com"}),
+				service.NewSMSServiceWithClient(&mockClient{}),
+			)
+		},
+	})
+	recorder := NewRecorder(newTestHasher(t))
+
+	// When: 배치마다 감사 기록 생성
+	var entries []Entry
+	_, err := p.Run(context.Background(), users, pipeline.Hooks{
+		OnSend: recorder.Observe,
+		OnRecords: func(records []pipeline.Record) {
+			entries = append(entries, recorder.Entries("run-1", "data.txt", records)...)
+		},
+	})
+	require.NoError(t, err)
+
+	// Then: 입력 레코드마다 한 건, 연락처는 마스킹, 기록한 레코드의 전송 시도는 남기지 않음
+	require.Len(t, entries, 4)
+	assert.Empty(t, recorder.attempts)
+	sent := entries[0]
+	assert.Equal(t, "run-1", sent.RunID)
+	assert.Equal(t, 1, sent.Record)
+	assert.Equal(t, "se**@example.com", sent.Email)
+	assert.Equal(t, "010-****-0001", sent.PhoneNumber)
+	require.NotNil(t, sent.Eligible)
+	assert.True(t, *sent.Eligible)
+	assert.Equal(t, pipeline.StatusSent, sent.EmailChannel.Status)
+	require.Len(t, sent.EmailChannel.Attempts, 1)
+	assert.Empty(t, sent.EmailChannel.Attempts[0].Error)
+
+	excluded := entries[1]
+	require.NotNil(t, excluded.Eligible)
+	assert.False(t, *excluded.Eligible)
+	assert.Equal(t, "credit_up", excluded.ExcludedBy)
+	assert.Empty(t, excluded.EmailChannel.Attempts)
+
+	assert.Equal(t, sent.KeyID, excluded.KeyID)
+
+	duplicate := entries[2]
+	require.NotNil(t, duplicate.Eligible)
+	assert.True(t, *duplicate.Eligible)
+	assert.Equal(t, "se**@example.com", duplicate.DuplicateKey)
+	assert.Equal(t, pipeline.StatusDuplicate, duplicate.SMSChannel.Status)
+
+	failed := entries[3]
+	assert.Equal(t, 4, failed.Record)
+	assert.Equal(t, pipeline.StatusFailed, failed.EmailChannel.Status)
+	require.Len(t, failed.EmailChannel.Attempts, 1)
+	assert.Equal(t, "전송 실패", failed.EmailChannel.Attempts[0].Error)
+	assert.Equal(t, pipeline.StatusSent, failed.SMSChannel.Status)
+}
+
+func TestRecorder_Replayed(t *testing.T) {
+	// Given: 아웃박스에서 다시 보낸 SMS 전송의 시도
+	recorder := NewRecorder(newTestHasher(t))
+	user := createUser(t, "user@example.com", "010-1234-5678", true)
+	recorder.Observe(user, domain.SMSChannel, nil)
+
+	// When: 감사 기록 생성
+	entry := recorder.Replayed("run-2", "outbox.jsonl", "run-1", user, domain.SMSChannel, pipeline.StatusSent, nil)
+
+	// Then: 보관한 실행과 같은 키의 해시, 다시 보낸 채널만 결과와 시도를 남김
+	assert.Equal(t, "run-2", entry.RunID)
+	assert.Equal(t, "run-1", entry.ReplayOf)
+	assert.Equal(t, 0, entry.Record)
+	assert.Equal(t, recorder.hasher.Phone("01012345678"), entry.PhoneHash)
+	assert.Equal(t, pipeline.StatusSent, entry.SMSChannel.Status)
+	require.Len(t, entry.SMSChannel.Attempts, 1)
+	assert.Equal(t, pipeline.StatusNone, entry.EmailChannel.Status)
+	assert.Empty(t, recorder.attempts)
+
+	// When: 실패한 재전송
+	failed := recorder.Replayed("run-2", "outbox.jsonl", "run-1", user, domain.EmailChannel, pipeline.StatusFailed, errors.New("전송 실패"))
+
+	// Then: 오류를 남김
+	assert.Equal(t, pipeline.StatusFailed, failed.EmailChannel.Status)
+	assert.Equal(t, "전송 실패", failed.EmailChannel.Error)
+}
+
+func TestLog_Lookup(t *testing.T) {
+	// Given: 두 실행의 감사 기록과 다른 키로 남긴 기록
+	hasher := newTestHasher(t)
+	auditLog := NewLog(filepath.Join(t.TempDir(), "audit.jsonl"), hasher)
+	require.NoError(t, auditLog.Append([]Entry{
+		newEntry(hasher, "run-1", "user@example.com", "010-1234-5678"),
+		newEntry(hasher, "run-1", "other@example.com", "010-9999-9999"),
+	}))
+	require.NoError(t, auditLog.Append([]Entry{
+		newEntry(hasher, "run-2", "user@example.com", "010-5555-5555"),
+	}))
+	otherKey, err := NewHasher([]byte("another-secret-key-for-audit"))
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Append([]Entry{
+		newEntry(otherKey, "run-0", "user@example.com", "010-1234-5678"),
+	}))
+
+	// When & Then: 이메일은 대소문자 무관, 다른 키로 남긴 기록은 제외
+	entries, err := auditLog.Lookup(Query{Email: "User@Example.com"})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "run-1", entries[0].RunID)
+	assert.Equal(t, "run-2", entries[1].RunID)
+
+	// When & Then: 전화번호는 하이픈 유무 무관
+	entries, err = auditLog.Lookup(Query{PhoneNumber: "01099999999"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ot***@example.com", entries[0].Email)
+
+	// When & Then: 둘 다 지정하면 하나라도 일치
+	entries, err = auditLog.Lookup(Query{Email: "other@example.com", PhoneNumber: "010-5555-5555"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// When & Then: 조건 없음
+	_, err = auditLog.Lookup(Query{})
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	// When & Then: 실행 하나의 기록 (다른 키로 남긴 기록은 제외)
+	var runEntries []Entry
+	require.NoError(t, auditLog.Run("run-1", func(entry Entry) {
+		runEntries = append(runEntries, entry)
+	}))
+	require.Len(t, runEntries, 2)
+	assert.Equal(t, "ot***@example.com", runEntries[1].Email)
+	require.NoError(t, auditLog.Run("run-0", func(entry Entry) {
+		t.Errorf("다른 키로 남긴 기록 전달: %+v", entry)
+	}))
+}
+
+func TestLog_Lookup_NoFile(t *testing.T) {
+	// Given: 기록이 없는 경로
+	auditLog := NewLog(filepath.Join(t.TempDir(), "audit.jsonl"), newTestHasher(t))
+
+	// When: 조회
+	entries, err := auditLog.Lookup(Query{Email: "user@example.com"})
+
+	// Then: 빈 결과
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLoadKey(t *testing.T) {
+	// Given: 없는 키 파일 경로
+	path := filepath.Join(t.TempDir(), "state", "audit.key")
+
+	// When: 처음 로딩
+	created, err := LoadKey(path)
+
+	// Then: 무작위 키를 만들어 소유자만 읽을 수 있게 저장
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// When: 다시 로딩
+	loaded, err := LoadKey(path)
+
+	// Then: 같은 키로 같은 해시, 키 없는 SHA-256과 다름
+	require.NoError(t, err)
+	assert.Equal(t, created.KeyID(), loaded.KeyID())
+	assert.Equal(t, created.Email("user@example.com"), loaded.Email("USER@example.com"))
+	unkeyed := sha256.Sum256([]byte("email:user@example.com"))
+	assert.NotEqual(t, hex.EncodeToString(unkeyed[:]), loaded.Email("user@example.com"))
+
+	// When & Then: 짧은 키는 거부
+	require.NoError(t, os.WriteFile(path, []byte("abcd\n"), 0600))
+	_, err = LoadKey(path)
+	assert.Error(t, err)
+}
+
+func TestMaskKey(t *testing.T) {
+	// Given & When & Then: 중복 기준별 키
+	assert.Equal(t, "us**@example.com", maskKey("user@example.com"))
+	assert.Equal(t, "010-****-5678", maskKey("010-1234-5678"))
+	assert.Equal(t, "us**@example.com|010-****-5678", maskKey("user@example.com|010-1234-5678"))
+	assert.Equal(t, "email:us**@example.com", maskKey("email:user@example.com"))
+	assert.Equal(t, "email:us**@example.com|sms:010-****-5678", maskKey("email:user@example.com|sms:010-1234-5678"))
+	assert.Equal(t, "", maskKey(""))
+}
+
+func newEntry(hasher *Hasher, runID, email, phoneNumber string) Entry {
+	return Entry{
+		RunID:     runID,
+		KeyID:     hasher.KeyID(),
+		EmailHash: hasher.Email(email),
+		PhoneHash: hasher.Phone(phoneNumber),
+		Email:     maskKey(email),
+	}
+}
+
+func newTestHasher(t *testing.T) *Hasher {
+	t.Helper()
+	hasher, err := NewHasher([]byte("test-secret-key-for-audit"))
+	require.NoError(t, err)
+	return hasher
+}
+
+func createUser(t *testing.T, email, phoneNumber string, creditUp bool) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(email, phoneNumber, creditUp)
+	require.NoError(t, err)
+	return user
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"banksalad-backend-task/internal/message"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 입력 레코드 하나가 알림을 받았거나 받지 못한 이유
+// 연락처는 마스킹하여 남기고 조회는 정규화한 연락처의 키 있는 해시(Hasher)로 함
+type Entry struct {
+	RunID       string    `json:"run_id"`
+	Source      string    `json:"source"`
+	Record      int       `json:"record"` // 입력 순서 (1부터, 대기열에서 꺼낸 레코드와 아웃박스 재전송은 0)
+	Time        time.Time `json:"time"`
+	KeyID       string    `json:"key_id"` // 해시에 사용한 키
+	EmailHash   string    `json:"email_hash"`
+	PhoneHash   string    `json:"phone_hash"`
+	Email       string    `json:"email"`        // 마스킹
+	PhoneNumber string    `json:"phone_number"` // 마스킹
+	CreditUp    bool      `json:"credit_up"`
+	Score       string    `json:"score,omitempty"` // 이전→현재
+	Bureau      string    `json:"bureau,omitempty"`
+	Released    bool      `json:"released,omitempty"`  // 이전 실행의 SMS 대기열에서 꺼낸 레코드
+	ReplayOf    string    `json:"replay_of,omitempty"` // 아웃박스에서 다시 보낸 전송이면 보관한 실행 ID
+
+	Eligible     *bool  `json:"eligible,omitempty"`      // 공통 규칙 통과 여부 (대기열에서 꺼낸 레코드는 판단하지 않아 없음)
+	ExcludedBy   string `json:"excluded_by,omitempty"`   // 제외한 규칙 (채널 규칙은 "채널:규칙")
+	DuplicateKey string `json:"duplicate_key,omitempty"` // 마스킹
+
+	EmailChannel ChannelDecision `json:"email_channel"`
+	SMSChannel   ChannelDecision `json:"sms_channel"`
+}
+
+// 채널별 최종 상태와 전송 시도
+type ChannelDecision struct {
+	Status   pipeline.Status `json:"status"`
+	Error    string          `json:"error,omitempty"`
+	Attempts []Attempt       `json:"attempts,omitempty"`
+}
+
+type Attempt struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+}
+
+// 중복 키의 연락처 부분만 마스킹 (email|phone, identity의 "email:" 접두어, 채널 기준 중복의 "sms:" 접두어 등)
+func maskKey(key string) string {
+	parts := strings.Split(key, "|")
+	for i, part := range parts {
+		prefix, value, found := strings.Cut(part, ":")
+		if !found {
+			prefix, value = "", part
+		} else {
+			prefix += ":"
+		}
+
+		if strings.Contains(value, "@") {
+			parts[i] = prefix + message.MaskEmail(value)
+		} else {
+			parts[i] = prefix + message.MaskPhoneNumber(value)
+		}
+	}
+	return strings.Join(parts, "|")
+}
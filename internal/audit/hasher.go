@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 키 최소 길이 (바이트)
+const minKeyBytes = 16
+
+// 조회용 연락처 해시 (HMAC-SHA256)
+// 연락처는 값의 범위가 좁아 키 없는 해시는 사전 대입으로 원문을 알아낼 수 있으므로 감사 기록과 따로 보관하는 키를 사용
+type Hasher struct {
+	key   []byte
+	keyID string
+}
+
+func NewHasher(key []byte) (*Hasher, error) {
+	if len(key) < minKeyBytes {
+		return nil, errors.Errorf("감사 기록 키는 %d바이트 이상이어야 합니다", minKeyBytes)
+	}
+
+	h := &Hasher{key: key}
+	h.keyID = h.sum("key-id")[:8]
+	return h, nil
+}
+
+// 키 파일(hex)을 읽음, 없으면 무작위 키를 만들어 소유자만 읽을 수 있게 저장
+func LoadKey(path string) (*Hasher, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return createKey(path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 파일을 읽을 수 없습니다")
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 파일 형식 오류 (hex)")
+	}
+	return NewHasher(key)
+}
+
+func createKey(path string) (*Hasher, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 생성 실패")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 디렉토리 생성 실패")
+	}
+	// 동시에 만든 다른 프로세스의 키를 덮어쓰지 않음
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return LoadKey(path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 파일을 만들 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close audit key file")
+		}
+	}()
+
+	if _, err := file.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 저장 실패")
+	}
+	if err := file.Sync(); err != nil {
+		return nil, errors.Wrap(err, "감사 기록 키 저장 실패")
+	}
+	return NewHasher(key)
+}
+
+// 키 식별자 (키를 바꾸면 이전 기록은 새 키로 조회되지 않으므로 기록마다 남김)
+func (h *Hasher) KeyID() string {
+	return h.keyID
+}
+
+// 수신 거부 목록과 같은 방식으로 정규화한 연락처의 해시
+func (h *Hasher) Email(email string) string {
+	return h.sum("email:" + domain.NormalizeEmail(email))
+}
+
+func (h *Hasher) Phone(phoneNumber string) string {
+	return h.sum("phone:" + domain.NormalizePhoneNumber(phoneNumber))
+}
+
+func (h *Hasher) sum(value string) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
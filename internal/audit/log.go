@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrEmptyQuery = errors.New("조회할 이메일이나 전화번호를 지정하세요")
+
+// 감사 기록 파일 (JSON Lines, 추가만 하고 수정하거나 지우지 않음)
+type Log struct {
+	path   string
+	hasher *Hasher
+	mu     sync.Mutex
+}
+
+// hasher는 기록과 조회에 같은 키를 사용
+func NewLog(path string, hasher *Hasher) *Log {
+	return &Log{
+		path:   path,
+		hasher: hasher,
+	}
+}
+
+// 이 기록 파일과 같은 키로 해시하는 한 번의 실행용 Recorder
+func (l *Log) NewRecorder() *Recorder {
+	return NewRecorder(l.hasher)
+}
+
+// 기록과 같은 키의 해시 (대사에서 출력 파일의 주소를 감사 기록과 비교)
+func (l *Log) Hasher() *Hasher {
+	return l.hasher
+}
+
+func (l *Log) Append(entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return errors.Wrap(err, "감사 기록 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "감사 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close audit log file")
+		}
+	}()
+
+	// 배치 하나의 기록을 한 번에 써서 같은 시각에 실행 중인 다른 작업의 기록과 줄이 섞이지 않도록 함
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return errors.Wrap(err, "감사 기록 실패")
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return errors.Wrap(err, "감사 기록 실패")
+	}
+	return errors.Wrap(file.Sync(), "감사 기록 실패")
+}
+
+// 조회 조건 (둘 다 지정하면 둘 중 하나라도 일치하는 기록)
+type Query struct {
+	Email       string
+	PhoneNumber string
+}
+
+// 조회 조건의 해시 (지정하지 않은 조건은 빈 문자열)
+func (q Query) hashes(hasher *Hasher) (string, string) {
+	emailHash, phoneHash := "", ""
+	if q.Email != "" {
+		emailHash = hasher.Email(q.Email)
+	}
+	if q.PhoneNumber != "" {
+		phoneHash = hasher.Phone(q.PhoneNumber)
+	}
+	return emailHash, phoneHash
+}
+
+// 기록된 순서대로 일치하는 기록 반환 (다른 키로 기록한 줄은 조회할 수 없어 건너뜀)
+func (l *Log) Lookup(query Query) ([]Entry, error) {
+	if query.Email == "" && query.PhoneNumber == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	emailHash, phoneHash := query.hashes(l.hasher)
+	matched := make([]Entry, 0)
+	err := l.scan(func(entry Entry) {
+		if (emailHash != "" && entry.EmailHash == emailHash) || (phoneHash != "" && entry.PhoneHash == phoneHash) {
+			matched = append(matched, entry)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matched, nil
+}
+
+// 실행 하나의 기록을 기록된 순서대로 전달 (대사처럼 실행 전체를 확인할 때, 기록을 모아 두지 않음)
+func (l *Log) Run(runID string, fn func(entry Entry)) error {
+	return l.scan(func(entry Entry) {
+		if entry.RunID == runID {
+			fn(entry)
+		}
+	})
+}
+
+// 이 키로 해시한 줄을 순서대로 전달
+func (l *Log) scan(fn func(entry Entry)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "감사 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close audit log file")
+		}
+	}()
+
+	otherKeys := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return errors.Wrapf(err, "감사 기록 %d번째 줄 파싱 실패", lineNumber)
+		}
+		if entry.KeyID != l.hasher.KeyID() {
+			otherKeys++
+			continue
+		}
+		fn(entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "감사 기록 파일 읽기 오류")
+	}
+
+	if otherKeys > 0 {
+		log.WithField("entries", otherKeys).Warn("다른 키로 기록된 감사 기록은 조회하지 않았습니다")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/message"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 한 번의 실행에서 채널별 전송 시도를 모아 입력 레코드별 감사 기록을 만듦
+type Recorder struct {
+	hasher   *Hasher
+	mu       sync.Mutex
+	attempts map[*domain.User]map[domain.NotificationChannel][]Attempt
+	now      func() time.Time
+}
+
+func NewRecorder(hasher *Hasher) *Recorder {
+	return &Recorder{
+		hasher:   hasher,
+		attempts: make(map[*domain.User]map[domain.NotificationChannel][]Attempt),
+		now:      func() time.Time { return time.Now().In(domain.KST) },
+	}
+}
+
+// service.SendObserver로 사용 (여러 고루틴에서 호출)
+func (r *Recorder) Observe(user *domain.User, channel domain.NotificationChannel, err error) {
+	attempt := Attempt{Time: r.now()}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.attempts[user] == nil {
+		r.attempts[user] = make(map[domain.NotificationChannel][]Attempt)
+	}
+	r.attempts[user][channel] = append(r.attempts[user][channel], attempt)
+}
+
+// 배치로 전달받은 레코드마다 한 건 (pipeline.Hooks.OnRecords에서 호출, 넘긴 레코드의 전송 시도는 지워 메모리를 비움)
+func (r *Recorder) Entries(runID, source string, records []pipeline.Record) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	entries := make([]Entry, 0, len(records))
+	for _, record := range records {
+		user, report := record.User, record.Report
+		entry := Entry{
+			RunID:        runID,
+			Source:       source,
+			Record:       record.Index + 1,
+			Time:         now,
+			KeyID:        r.hasher.KeyID(),
+			EmailHash:    r.hasher.Email(report.Email),
+			PhoneHash:    r.hasher.Phone(report.PhoneNumber),
+			Email:        message.MaskEmail(report.Email),
+			PhoneNumber:  message.MaskPhoneNumber(report.PhoneNumber),
+			CreditUp:     user.CreditUp,
+			Bureau:       user.Bureau,
+			Released:     report.Released,
+			ExcludedBy:   report.ExcludedBy,
+			DuplicateKey: maskKey(report.DuplicateKey),
+			EmailChannel: ChannelDecision{
+				Status:   report.EmailStatus,
+				Error:    report.EmailError,
+				Attempts: r.attempts[user][domain.EmailChannel],
+			},
+			SMSChannel: ChannelDecision{
+				Status:   report.SMSStatus,
+				Error:    report.SMSError,
+				Attempts: r.attempts[user][domain.SMSChannel],
+			},
+		}
+		if user.HasScore() {
+			entry.Score = fmt.Sprintf("%d→%d", user.Score.Previous, user.Score.Current)
+		}
+		if !report.Released {
+			eligible := report.Eligible
+			entry.Eligible = &eligible
+		}
+
+		delete(r.attempts, user)
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// 아웃박스에서 다시 보낸 전송 하나 (replay의 결과 관찰자에서 호출, 다시 보내지 않은 채널은 none)
+// origin은 보관한 실행 ID, 넘긴 사용자의 전송 시도는 지움
+func (r *Recorder) Replayed(runID, source, origin string, user *domain.User, channel domain.NotificationChannel, status pipeline.Status, err error) Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	eligible := true
+	entry := Entry{
+		RunID:        runID,
+		Source:       source,
+		Time:         r.now(),
+		KeyID:        r.hasher.KeyID(),
+		EmailHash:    r.hasher.Email(user.Email),
+		PhoneHash:    r.hasher.Phone(user.PhoneNumber),
+		Email:        message.MaskEmail(user.Email),
+		PhoneNumber:  message.MaskPhoneNumber(user.PhoneNumber),
+		CreditUp:     user.CreditUp,
+		Bureau:       user.Bureau,
+		ReplayOf:     origin,
+		Eligible:     &eligible,
+		EmailChannel: ChannelDecision{Status: pipeline.StatusNone},
+		SMSChannel:   ChannelDecision{Status: pipeline.StatusNone},
+	}
+	if user.HasScore() {
+		entry.Score = fmt.Sprintf("%d→%d", user.Score.Previous, user.Score.Current)
+	}
+
+	decision := ChannelDecision{
+		Status:   status,
+		Attempts: r.attempts[user][channel],
+	}
+	if err != nil {
+		decision.Error = err.Error()
+	}
+	if channel == domain.EmailChannel {
+		entry.EmailChannel = decision
+	} else {
+		entry.SMSChannel = decision
+	}
+
+	delete(r.attempts, user)
+	return entry
+}
@@ -1,5 +1,9 @@
 package domain
 
+import (
+	"github.com/pkg/errors"
+)
+
 type NotificationChannel int
 
 const (
@@ -18,6 +22,17 @@ func (nc NotificationChannel) String() string {
 	}
 }
 
+func ParseNotificationChannel(name string) (NotificationChannel, error) {
+	switch name {
+	case "email":
+		return EmailChannel, nil
+	case "sms":
+		return SMSChannel, nil
+	default:
+		return 0, errors.Errorf("알 수 없는 알림 채널: %s", name)
+	}
+}
+
 type NotificationRequest struct {
 	User    *User
 	Channel NotificationChannel
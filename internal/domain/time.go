@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// 초기화 단계에서 미리 로딩한 KST 타임존
+var KST = MustLoadKST()
+
+func MustLoadKST() *time.Location {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
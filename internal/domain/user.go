@@ -12,8 +12,27 @@ const (
 	ByEmail DuplicateStrategy = iota
 	ByPhone
 	ByBoth
+	ByIdentity // 이메일 또는 전화번호를 하나라도 공유하면 같은 사람 (전체 레코드로 판단)
+	ByChannel  // 레코드는 이메일+전화번호가 모두 같을 때만 중복, 전송은 채널마다 그 채널의 주소로 중복 제거
 )
 
+func ParseDuplicateStrategy(name string) (DuplicateStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "email":
+		return ByEmail, nil
+	case "phone":
+		return ByPhone, nil
+	case "both":
+		return ByBoth, nil
+	case "identity":
+		return ByIdentity, nil
+	case "channel":
+		return ByChannel, nil
+	default:
+		return ByEmail, errors.Errorf("지원하지 않는 중복 기준: %s (email, phone, both, identity, channel)", name)
+	}
+}
+
 func (ds DuplicateStrategy) String() string {
 	switch ds {
 	case ByEmail:
@@ -22,15 +41,50 @@ func (ds DuplicateStrategy) String() string {
 		return "ByPhone"
 	case ByBoth:
 		return "ByBoth"
+	case ByIdentity:
+		return "ByIdentity"
+	case ByChannel:
+		return "ByChannel"
 	default:
 		return "Unknown"
 	}
 }
 
+const (
+	MinCreditScore = 0
+	MaxCreditScore = 1000
+)
+
+type CreditScore struct {
+	Previous int
+	Current  int
+}
+
+func NewCreditScore(previous, current int) (*CreditScore, error) {
+	if previous < MinCreditScore || previous > MaxCreditScore {
+		return nil, errors.Errorf("이전 신용점수 범위 오류: %d", previous)
+	}
+
+	if current < MinCreditScore || current > MaxCreditScore {
+		return nil, errors.Errorf("현재 신용점수 범위 오류: %d", current)
+	}
+
+	return &CreditScore{
+		Previous: previous,
+		Current:  current,
+	}, nil
+}
+
+func (cs *CreditScore) Delta() int {
+	return cs.Current - cs.Previous
+}
+
 type User struct {
 	Email       string
 	PhoneNumber string
 	CreditUp    bool
+	Score       *CreditScore // 입력 데이터에 점수가 없으면 nil
+	Bureau      string       // 신용평가사 (선택)
 }
 
 func NewUser(email, phoneNumber string, creditUp bool) (*User, error) {
@@ -52,21 +106,89 @@ func NewUser(email, phoneNumber string, creditUp bool) (*User, error) {
 	}, nil
 }
 
+// 연락처 비교용 이메일 (앞뒤 공백 제거, 소문자)
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// 연락처 비교용 전화번호 (숫자만 남기고 국가번호 +82는 0으로 바꿈)
+func NormalizePhoneNumber(phoneNumber string) string {
+	phoneNumber = strings.TrimSpace(phoneNumber)
+	international := strings.HasPrefix(phoneNumber, "+")
+
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+
+	if international && strings.HasPrefix(digits, "82") {
+		digits = "0" + strings.TrimPrefix(digits[2:], "0")
+	}
+	return digits
+}
+
+// 이메일이면 NormalizeEmail, 아니면 NormalizePhoneNumber
+func NormalizeContact(contact string) string {
+	if strings.Contains(contact, "@") {
+		return NormalizeEmail(contact)
+	}
+	return NormalizePhoneNumber(contact)
+}
+
 func (u *User) IsEligibleForNotification() bool {
 	return u.CreditUp
 }
 
+func (u *User) HasScore() bool {
+	return u.Score != nil
+}
+
+// 점수 정보가 없으면 0 반환
+func (u *User) ScoreDelta() int {
+	if u.Score == nil {
+		return 0
+	}
+	return u.Score.Delta()
+}
+
+// 이전 점수는 기준 미만, 현재 점수는 기준 이상인 경우
+func (u *User) CrossedThreshold(threshold int) bool {
+	if u.Score == nil {
+		return false
+	}
+	return u.Score.Previous < threshold && u.Score.Current >= threshold
+}
+
+// 보낼 수 있는 이메일 주소 형식(로컬@도메인.최상위)이면 true (입력 칸을 채우려고 넣은 "-", "none" 등은 false)
+func (u *User) HasEmail() bool {
+	local, host, found := strings.Cut(NormalizeEmail(u.Email), "@")
+	if !found || local == "" || strings.ContainsAny(host, "@ ") {
+		return false
+	}
+	dot := strings.LastIndex(host, ".")
+	return dot > 0 && dot < len(host)-1
+}
+
+// 휴대전화 번호(정규화하면 01로 시작하는 10~11자리)면 true (입력 칸을 채우려고 넣은 "000-0000-0000" 등은 false)
+func (u *User) HasPhoneNumber() bool {
+	digits := NormalizePhoneNumber(u.PhoneNumber)
+	return strings.HasPrefix(digits, "01") && (len(digits) == 10 || len(digits) == 11)
+}
+
 func (u *User) UniqueKey() string {
 	return u.Email
 }
 
+// ByIdentity는 다른 레코드와의 연결이 필요하므로 단일 사용자 키는 이메일 (processor.DuplicateFilter가 처리)
 func (u *User) UniqueKeyByStrategy(strategy DuplicateStrategy) string {
 	switch strategy {
 	case ByEmail:
 		return u.Email
 	case ByPhone:
 		return u.PhoneNumber
-	case ByBoth:
+	case ByBoth, ByChannel:
 		return u.Email + "|" + u.PhoneNumber
 	default:
 		return u.Email
@@ -122,6 +122,34 @@ func TestUser_IsEligibleForNotification(t *testing.T) {
 	}
 }
 
+func TestUser_HasContact(t *testing.T) {
+	testCases := []struct {
+		name          string
+		email         string
+		phoneNumber   string
+		expectedEmail bool
+		expectedPhone bool
+	}{
+		{name: "보낼 수 있는 연락처", email: "User@Example.com", phoneNumber: "010-1234-5678", expectedEmail: true, expectedPhone: true},
+		{name: "하이픈 없는 번호와 국가번호", email: "user@mail.example.co.kr", phoneNumber: "+82 10 1234 5678", expectedEmail: true, expectedPhone: true},
+		{name: "칸을 채운 값", email: "-", phoneNumber: "000-0000-0000", expectedEmail: false, expectedPhone: false},
+		{name: "도메인 없는 이메일과 유선 번호", email: "user@localhost", phoneNumber: "02-123-4567", expectedEmail: false, expectedPhone: false},
+		{name: "로컬 부분이 없는 이메일", email: "@example.com", phoneNumber: "010-123-4567", expectedEmail: false, expectedPhone: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 입력 값 그대로의 사용자
+			user := &User{Email: tc.email, PhoneNumber: tc.phoneNumber}
+
+			// When & Then: 보낼 수 있는 연락처인지 확인
+			assert.Equal(t, tc.expectedEmail, user.HasEmail())
+			assert.Equal(t, tc.expectedPhone, user.HasPhoneNumber())
+		})
+	}
+}
+
 func TestUser_UniqueKey(t *testing.T) {
 	// Given: 사용자 객체 준비
 	user := &User{
@@ -138,6 +166,31 @@ func TestUser_UniqueKey(t *testing.T) {
 	assert.Equal(t, expectedKey, actualKey)
 }
 
+func TestNormalizeContact(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contact  string
+		expected string
+	}{
+		{name: "이메일 대소문자와 공백", contact: " User@Example.COM ", expected: "user@example.com"},
+		{name: "하이픈 전화번호", contact: "010-1234-5678", expected: "01012345678"},
+		{name: "공백 전화번호", contact: "010 1234 5678", expected: "01012345678"},
+		{name: "국가번호", contact: "+82-10-1234-5678", expected: "01012345678"},
+		{name: "국가번호와 0", contact: "+82 (0)10 1234 5678", expected: "01012345678"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 연락처 정규화
+			result := NormalizeContact(tc.contact)
+
+			// Then: 결과 검증
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestUser_UniqueKeyByStrategy(t *testing.T) {
 	// Given: 사용자 객체 준비
 	user := &User{
@@ -206,6 +259,16 @@ func TestDuplicateStrategy_String(t *testing.T) {
 			strategy: ByBoth,
 			expected: "ByBoth",
 		},
+		{
+			name:     "연락처 연결 전략 문자열 표현",
+			strategy: ByIdentity,
+			expected: "ByIdentity",
+		},
+		{
+			name:     "채널별 전략 문자열 표현",
+			strategy: ByChannel,
+			expected: "ByChannel",
+		},
 		{
 			name:     "알 수 없는 전략 문자열 표현",
 			strategy: DuplicateStrategy(999),
@@ -225,6 +288,24 @@ func TestDuplicateStrategy_String(t *testing.T) {
 	}
 }
 
+func TestParseDuplicateStrategy(t *testing.T) {
+	// Given & When & Then: 기본값, 대소문자, 잘못된 기준
+	strategy, err := ParseDuplicateStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, ByEmail, strategy)
+
+	strategy, err = ParseDuplicateStrategy("Identity")
+	require.NoError(t, err)
+	assert.Equal(t, ByIdentity, strategy)
+
+	strategy, err = ParseDuplicateStrategy("channel")
+	require.NoError(t, err)
+	assert.Equal(t, ByChannel, strategy)
+
+	_, err = ParseDuplicateStrategy("name")
+	assert.Error(t, err)
+}
+
 func TestUser_UniqueKeyByStrategy_RealWorldScenarios(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -316,3 +397,113 @@ func TestUser_UniqueKeyByStrategy_RealWorldScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCreditScore(t *testing.T) {
+	testCases := []struct {
+		name          string
+		previous      int
+		current       int
+		expectError   bool
+		expectedDelta int
+	}{
+		{
+			name:          "점수 상승",
+			previous:      780,
+			current:       803,
+			expectedDelta: 23,
+		},
+		{
+			name:          "점수 하락",
+			previous:      803,
+			current:       780,
+			expectedDelta: -23,
+		},
+		{
+			name:        "이전 점수 범위 초과",
+			previous:    1001,
+			current:     900,
+			expectError: true,
+		},
+		{
+			name:        "현재 점수 음수",
+			previous:    700,
+			current:     -1,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 신용점수 생성
+			score, err := NewCreditScore(tc.previous, tc.current)
+
+			// Then: 결과 검증
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, score)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDelta, score.Delta())
+		})
+	}
+}
+
+func TestUser_ScoreMethods(t *testing.T) {
+	testCases := []struct {
+		name            string
+		score           *CreditScore
+		threshold       int
+		expectedDelta   int
+		expectedCrossed bool
+	}{
+		{
+			name:            "점수 정보 없음",
+			score:           nil,
+			threshold:       800,
+			expectedDelta:   0,
+			expectedCrossed: false,
+		},
+		{
+			name:            "기준 점수 돌파",
+			score:           &CreditScore{Previous: 790, Current: 810},
+			threshold:       800,
+			expectedDelta:   20,
+			expectedCrossed: true,
+		},
+		{
+			name:            "기준 점수 정확히 도달",
+			score:           &CreditScore{Previous: 799, Current: 800},
+			threshold:       800,
+			expectedDelta:   1,
+			expectedCrossed: true,
+		},
+		{
+			name:            "이미 기준 점수 이상",
+			score:           &CreditScore{Previous: 810, Current: 830},
+			threshold:       800,
+			expectedDelta:   20,
+			expectedCrossed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 점수 정보를 가진 사용자
+			user := &User{
+				Email:       "user@example.com",
+				PhoneNumber: "010-1234-5678",
+				CreditUp:    true,
+				Score:       tc.score,
+			}
+
+			// When & Then: 상승폭 및 기준 돌파 여부 검증
+			assert.Equal(t, tc.score != nil, user.HasScore())
+			assert.Equal(t, tc.expectedDelta, user.ScoreDelta())
+			assert.Equal(t, tc.expectedCrossed, user.CrossedThreshold(tc.threshold))
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package ingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+func TestResolveInputs(t *testing.T) {
+	// Given: 입력 디렉토리에 파일 여러 개 생성
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "20250702.txt"), "b")
+	writeFile(t, filepath.Join(dir, "20250701.txt"), "a")
+	writeFile(t, filepath.Join(dir, "20250701.csv"), "c")
+	writeFile(t, filepath.Join(dir, ".20250703.txt.part"), "전송 중")
+	writeFile(t, filepath.Join(dir, "20250704.txt.part"), "전송 중")
+	writeFile(t, filepath.Join(dir, "20250705.txt.TMP"), "전송 중")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "archive"), 0755))
+
+	testCases := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{
+			name:     "단일 파일",
+			pattern:  filepath.Join(dir, "20250702.txt"),
+			expected: []string{filepath.Join(dir, "20250702.txt")},
+		},
+		{
+			name:    "디렉토리 - 파일명 순, 숨김 파일, 임시 파일, 하위 디렉토리 제외",
+			pattern: dir,
+			expected: []string{
+				filepath.Join(dir, "20250701.csv"),
+				filepath.Join(dir, "20250701.txt"),
+				filepath.Join(dir, "20250702.txt"),
+			},
+		},
+		{
+			name:    "glob 패턴 - 숨김 파일, 임시 파일 제외",
+			pattern: filepath.Join(dir, "*.txt*"),
+			expected: []string{
+				filepath.Join(dir, "20250701.txt"),
+				filepath.Join(dir, "20250702.txt"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 입력 경로 해석
+			paths, err := ResolveInputs(tc.pattern)
+
+			// Then: 결과 검증
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, paths)
+		})
+	}
+
+	t.Run("존재하지 않는 경로", func(t *testing.T) {
+		// When: 없는 경로 해석
+		paths, err := ResolveInputs(filepath.Join(dir, "없는파일.txt"))
+
+		// Then: 에러 확인
+		assert.Error(t, err)
+		assert.Nil(t, paths)
+	})
+}
+
+func TestProcessedStore(t *testing.T) {
+	// Given: 임시 처리 기록 파일
+	path := filepath.Join(t.TempDir(), "state", "processed_files.txt")
+	store, err := NewProcessedStore(path)
+	require.NoError(t, err)
+
+	// When: 처리 완료 기록
+	require.NoError(t, store.MarkProcessed("hash-a", "a.txt"))
+	require.NoError(t, store.MarkProcessed("hash-a", "a-renamed.txt"))
+
+	// Then: 다시 로딩해도 처리 기록 유지, 같은 해시는 한 번만 기록
+	reloaded, err := NewProcessedStore(path)
+	require.NoError(t, err)
+	assert.True(t, reloaded.IsProcessed("hash-a"))
+	assert.False(t, reloaded.IsProcessed("hash-b"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countLines(string(content)))
+}
+
+func TestProcessor_ProcessPending(t *testing.T) {
+	// Given: 내용이 같은 파일 2개와 다른 파일 1개
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "1.txt"), "same")
+	writeFile(t, filepath.Join(dir, "2.txt"), "same")
+	writeFile(t, filepath.Join(dir, "3.txt"), "different")
+
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	var handled []string
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		handled = append(handled, filepath.Base(path))
+		return nil
+	})
+	ctx := context.Background()
+
+	// When: 첫 번째 처리
+	processed, err := processor.ProcessPending(ctx)
+
+	// Then: 내용이 같은 파일은 한 번만 처리
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{"1.txt", "3.txt"}, handled)
+
+	// When: 다시 처리
+	processed, err = processor.ProcessPending(ctx)
+
+	// Then: 이미 처리한 파일은 건너뜀
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	// When: 강제 재처리
+	processor.SetForce(true)
+	processed, err = processor.ProcessPending(ctx)
+
+	// Then: 모든 파일 다시 처리
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+}
+
+func TestProcessor_ProcessPending_HandlerError(t *testing.T) {
+	// Given: 두 번째 파일 처리에 실패하는 핸들러
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "1.txt"), "first")
+	writeFile(t, filepath.Join(dir, "2.txt"), "second")
+	writeFile(t, filepath.Join(dir, "3.txt"), "third")
+
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		if filepath.Base(path) == "2.txt" {
+			return errors.New("처리 실패")
+		}
+		return nil
+	})
+
+	// When: 처리 실행
+	processed, err := processor.ProcessPending(context.Background())
+
+	// Then: 실패한 파일에서 중단하고, 실패한 파일은 처리 기록에 남기지 않음
+	assert.Error(t, err)
+	assert.Equal(t, 1, processed)
+
+	hash, err := HashFile(filepath.Join(dir, "2.txt"))
+	require.NoError(t, err)
+	assert.False(t, store.IsProcessed(hash))
+}
+
+func TestProgress(t *testing.T) {
+	// Given: 입력 해시의 진행 기록
+	dir := filepath.Join(t.TempDir(), "progress")
+	progress, err := OpenProgress(dir, "hash-a")
+	require.NoError(t, err)
+	assert.False(t, progress.Attempted(0, domain.EmailChannel))
+
+	// When: 배치마다 시도한 전송 기록
+	require.NoError(t, progress.Append([]ProgressEntry{
+		{Index: 0, Channel: domain.EmailChannel},
+		{Index: 0, Channel: domain.SMSChannel},
+	}))
+	require.NoError(t, progress.Append([]ProgressEntry{
+		{Index: 0, Channel: domain.SMSChannel},
+		{Index: 2, Channel: domain.SMSChannel},
+	}))
+
+	// Then: 다시 열어도 레코드, 채널별로 유지되고 같은 전송은 한 번만 기록
+	reloaded, err := OpenProgress(dir, "hash-a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, reloaded.Len())
+	assert.True(t, reloaded.Attempted(0, domain.EmailChannel))
+	assert.True(t, reloaded.Attempted(2, domain.SMSChannel))
+	assert.False(t, reloaded.Attempted(2, domain.EmailChannel))
+
+	other, err := OpenProgress(dir, "hash-b")
+	require.NoError(t, err)
+	assert.Equal(t, 0, other.Len())
+
+	// When: 잘린 마지막 줄이 있는 기록
+	path := filepath.Join(dir, "hash-a.progress")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString("3 em")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	reloaded, err = OpenProgress(dir, "hash-a")
+
+	// Then: 잘린 줄만 건너뜀
+	require.NoError(t, err)
+	assert.Equal(t, 3, reloaded.Len())
+
+	// When: 처리 완료 후 삭제
+	require.NoError(t, reloaded.Remove())
+
+	// Then: 기록 파일이 없어짐
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestProcessor_ProcessPending_WaitStable(t *testing.T) {
+	// Given: 감시 중인 입력 디렉토리에 쓰는 중인 파일과 뒤의 파일
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "1.txt"), "first")
+	writeFile(t, filepath.Join(dir, "2.txt"), "second")
+
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	var handled []string
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		handled = append(handled, filepath.Base(path))
+		return nil
+	})
+	ctx := context.Background()
+
+	// When: 처음 확인
+	processed, err := processor.processPending(ctx, true)
+
+	// Then: 처음 본 파일은 처리하지 않음
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	// When: 다음 확인 전에 첫 파일에 내용이 더 쓰임
+	writeFile(t, filepath.Join(dir, "1.txt"), "first, more")
+	processed, err = processor.processPending(ctx, true)
+
+	// Then: 바뀐 파일에서 멈추고 순서를 지키기 위해 뒤의 파일도 처리하지 않음
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+	assert.Empty(t, handled)
+
+	// When: 크기와 수정 시각이 그대로인 다음 확인
+	processed, err = processor.processPending(ctx, true)
+
+	// Then: 파일명 순으로 처리
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{"1.txt", "2.txt"}, handled)
+}
+
+func TestProcessor_ProcessPending_WaitStableFailure(t *testing.T) {
+	// Given: 감시 중인 입력 디렉토리에 처리할 수 없는 파일과 뒤의 파일
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "1.txt"), "first")
+	writeFile(t, filepath.Join(dir, "2.txt"), "broken")
+	writeFile(t, filepath.Join(dir, "3.txt"), "third")
+
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	var handled []string
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		handled = append(handled, filepath.Base(path))
+		if content, _ := os.ReadFile(path); string(content) == "broken" {
+			return errors.New("처리 실패")
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	// When: 두 번 확인 (처음 본 파일은 다음 확인에서 처리)
+	_, err = processor.processPending(ctx, true)
+	require.NoError(t, err)
+	processed, err := processor.processPending(ctx, true)
+
+	// Then: 실패한 파일을 건너뛰고 뒤의 파일을 처리
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{"1.txt", "2.txt", "3.txt"}, handled)
+
+	// When: 파일이 그대로인 다음 확인
+	processed, err = processor.processPending(ctx, true)
+
+	// Then: 실패한 파일을 다시 처리하지 않음
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+	assert.Len(t, handled, 3)
+
+	// When: 실패한 파일을 고친 뒤 두 번 확인
+	writeFile(t, filepath.Join(dir, "2.txt"), "fixed second")
+	_, err = processor.processPending(ctx, true)
+	require.NoError(t, err)
+	processed, err = processor.processPending(ctx, true)
+
+	// Then: 바뀐 파일을 다시 처리
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, []string{"1.txt", "2.txt", "3.txt", "2.txt"}, handled)
+}
+
+func TestProcessor_Watch(t *testing.T) {
+	// Given: 빈 입력 디렉토리 감시
+	dir := t.TempDir()
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	handled := make(chan string, 10)
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		handled <- filepath.Base(path)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- processor.Watch(ctx, 10*time.Millisecond)
+	}()
+
+	// When: 감시 중 새 파일 도착
+	writeFile(t, filepath.Join(dir, "20250701.txt"), "new")
+
+	// Then: 새 파일 처리
+	select {
+	case name := <-handled:
+		assert.Equal(t, "20250701.txt", name)
+	case <-ctx.Done():
+		t.Fatal("새 파일이 처리되지 않음")
+	}
+
+	// When: 감시 종료
+	cancel()
+
+	// Then: 컨텍스트 취소 에러로 종료
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestProcessor_Watch_IdleHandler(t *testing.T) {
+	// Given: 확인을 마칠 때마다 호출할 함수를 지정한 감시
+	dir := t.TempDir()
+	store, err := NewProcessedStore(filepath.Join(t.TempDir(), "processed.txt"))
+	require.NoError(t, err)
+
+	var events []string
+	processor := NewProcessor(dir, store, func(ctx context.Context, path string) error {
+		events = append(events, filepath.Base(path))
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	idle := 0
+	processor.SetIdleHandler(func(ctx context.Context) {
+		events = append(events, "idle")
+		idle++
+		if idle == 1 {
+			writeFile(t, filepath.Join(dir, "20250701.txt"), "new")
+		}
+		if idle == 4 {
+			cancel()
+		}
+	})
+
+	// When: 새 파일이 없는 확인 뒤에 파일이 도착
+	err = processor.Watch(ctx, 10*time.Millisecond)
+
+	// Then: 새 파일이 없어도 호출되고 입력 파일 처리가 끝난 뒤에 호출
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"idle", "idle", "20250701.txt", "idle", "idle"}, events)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func countLines(content string) int {
+	count := 0
+	for _, r := range content {
+		if r == '\n' {
+			count++
+		}
+	}
+	return count
+}
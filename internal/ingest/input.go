@@ -0,0 +1,109 @@
+package ingest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// 파일, 디렉토리 또는 glob 패턴을 처리 순서(파일명 순)대로 정렬된 파일 목록으로 변환
+func ResolveInputs(pattern string) ([]string, error) {
+	if strings.ContainsAny(pattern, "*?[") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "잘못된 glob 패턴: %s", pattern)
+		}
+		return regularFiles(matches), nil
+	}
+
+	info, err := os.Stat(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "입력 경로를 찾을 수 없습니다")
+	}
+
+	if !info.IsDir() {
+		return []string{pattern}, nil
+	}
+
+	entries, err := os.ReadDir(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "입력 디렉토리를 읽을 수 없습니다")
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, filepath.Join(pattern, entry.Name()))
+	}
+
+	return regularFiles(paths), nil
+}
+
+// 전송 중인 파일의 이름 규칙 (업로드, 다운로드 도구가 완료 후 이름을 바꿈)
+var incompleteSuffixes = []string{".tmp", ".part", ".partial", ".crdownload"}
+
+// 숨김 파일이나 전송 중인 임시 파일이면 true
+func IsIncomplete(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	lower := strings.ToLower(name)
+	for _, suffix := range incompleteSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func regularFiles(paths []string) []string {
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if IsIncomplete(path) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, path)
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// 파일 내용 기준 SHA-256 해시 (파일명이 바뀌어도 같은 파일로 인식)
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close file")
+		}
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errors.Wrap(err, "파일 해시 계산 실패")
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// API로 전달된 레코드 등 파일이 아닌 입력의 해시
+func HashBytes(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
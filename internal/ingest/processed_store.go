@@ -0,0 +1,108 @@
+package ingest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 처리 완료한 파일의 내용 해시를 기록하는 저장소
+// 파일 형식: [해시, 처리 시각(KST), 파일 경로]
+type ProcessedStore struct {
+	path   string
+	mu     sync.Mutex
+	hashes map[string]struct{}
+}
+
+func NewProcessedStore(path string) (*ProcessedStore, error) {
+	store := &ProcessedStore{
+		path:   path,
+		hashes: make(map[string]struct{}),
+	}
+
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (ps *ProcessedStore) load() error {
+	file, err := os.Open(ps.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "처리 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close processed store file")
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		ps.hashes[fields[0]] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "처리 기록 파일 읽기 오류")
+	}
+
+	return nil
+}
+
+func (ps *ProcessedStore) IsProcessed(hash string) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	_, exists := ps.hashes[hash]
+	return exists
+}
+
+func (ps *ProcessedStore) MarkProcessed(hash, filePath string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, exists := ps.hashes[hash]; exists {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ps.path), 0755); err != nil {
+		return errors.Wrap(err, "처리 기록 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(ps.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "처리 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close processed store file")
+		}
+	}()
+
+	line := fmt.Sprintf("%s %s %s\n", hash, time.Now().In(domain.KST).Format(time.RFC3339), filePath)
+	if _, err := file.WriteString(line); err != nil {
+		return errors.Wrap(err, "처리 기록 실패")
+	}
+
+	ps.hashes[hash] = struct{}{}
+	return nil
+}
@@ -0,0 +1,160 @@
+package ingest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 입력 파일 하나의 레코드별 전송 진행 기록 (중단된 실행을 다시 처리할 때 이미 시도한 전송을 건너뜀)
+// 입력 내용 해시마다 파일 하나, 파일 형식: 한 줄에 [레코드 순서(0부터), 채널]
+type Progress struct {
+	path string
+	mu   sync.Mutex
+	done map[progressKey]struct{}
+}
+
+type progressKey struct {
+	index   int
+	channel domain.NotificationChannel
+}
+
+// 전송을 시도한 레코드와 채널
+type ProgressEntry struct {
+	Index   int
+	Channel domain.NotificationChannel
+}
+
+// dir 아래 입력 해시의 진행 기록을 읽음 (없으면 빈 기록)
+func OpenProgress(dir, inputHash string) (*Progress, error) {
+	progress := &Progress{
+		path: filepath.Join(dir, inputHash+".progress"),
+		done: make(map[progressKey]struct{}),
+	}
+
+	if err := progress.load(); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (p *Progress) load() error {
+	file, err := os.Open(p.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "진행 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close progress file")
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// 강제 종료로 잘린 마지막 줄은 건너뜀 (그 전송은 다시 시도)
+		if len(fields) != 2 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		channel, err := domain.ParseNotificationChannel(fields[1])
+		if err != nil {
+			continue
+		}
+		p.done[progressKey{index: index, channel: channel}] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "진행 기록 파일 읽기 오류")
+	}
+
+	return nil
+}
+
+// 이전 실행에서 시도한 전송이면 true
+func (p *Progress) Attempted(index int, channel domain.NotificationChannel) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, exists := p.done[progressKey{index: index, channel: channel}]
+	return exists
+}
+
+// 이미 시도한 전송 수
+func (p *Progress) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.done)
+}
+
+// 전송을 시도한 레코드 추가 (배치마다 호출)
+func (p *Progress) Append(entries []ProgressEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return errors.Wrap(err, "진행 기록 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "진행 기록 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close progress file")
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	for _, entry := range entries {
+		key := progressKey{index: entry.Index, channel: entry.Channel}
+		if _, exists := p.done[key]; exists {
+			continue
+		}
+		if _, err := fmt.Fprintf(writer, "%d %s\n", entry.Index, entry.Channel); err != nil {
+			return errors.Wrap(err, "진행 기록 실패")
+		}
+		p.done[key] = struct{}{}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return errors.Wrap(err, "진행 기록 실패")
+	}
+	return nil
+}
+
+// 입력 처리를 마친 뒤 진행 기록 삭제 (처리 완료는 ProcessedStore에 기록)
+func (p *Progress) Remove() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "진행 기록 삭제 실패")
+	}
+	p.done = make(map[progressKey]struct{})
+	return nil
+}
@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// 입력 파일 하나를 처리하는 함수
+type HandlerFunc func(ctx context.Context, path string) error
+
+// 입력 경로의 파일을 순서대로 처리하고, 이미 처리한 내용의 파일은 건너뜀
+type Processor struct {
+	pattern string
+	store   *ProcessedStore
+	handler HandlerFunc
+	force   bool
+	seen    map[string]fileState // 감시 모드에서 직전 확인 때의 파일 크기, 수정 시각
+	failed  map[string]fileState // 감시 모드에서 처리에 실패한 파일의 실패 당시 크기, 수정 시각 (바뀌기 전까지 건너뜀)
+	idle    func(ctx context.Context)
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func NewProcessor(pattern string, store *ProcessedStore, handler HandlerFunc) *Processor {
+	return &Processor{
+		pattern: pattern,
+		store:   store,
+		handler: handler,
+		seen:    make(map[string]fileState),
+		failed:  make(map[string]fileState),
+	}
+}
+
+// 처리 기록과 관계없이 모든 파일을 다시 처리
+func (p *Processor) SetForce(force bool) {
+	p.force = force
+}
+
+// 감시 모드에서 확인을 마칠 때마다 호출할 함수 (새 파일이 없어도 호출, 입력 파일 처리와 겹치지 않음)
+func (p *Processor) SetIdleHandler(idle func(ctx context.Context)) {
+	p.idle = idle
+}
+
+// 현재 입력 경로의 파일을 한 번 처리하고 처리한 파일 수 반환
+func (p *Processor) ProcessPending(ctx context.Context) (int, error) {
+	return p.processPending(ctx, false)
+}
+
+// waitStable이 true면 직전 확인 이후 크기나 수정 시각이 바뀐 파일(쓰는 중인 파일)에서 멈추고 다음 확인에서 처리
+// 파일명 순서를 지키기 위해 뒤의 파일도 처리하지 않음
+// waitStable이 true면(감시 모드) 처리에 실패한 파일은 기록하고 뒤의 파일을 계속 처리하며, 실패한 파일은 크기나 수정 시각이 바뀐 뒤에 다시 처리
+func (p *Processor) processPending(ctx context.Context, waitStable bool) (int, error) {
+	paths, err := ResolveInputs(p.pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	if waitStable {
+		if paths, err = p.stablePrefix(paths); err != nil {
+			return 0, err
+		}
+	}
+
+	processed := 0
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return processed, ctx.Err()
+		default:
+		}
+
+		if waitStable {
+			if state, exists := p.failed[path]; exists {
+				if state == p.seen[path] {
+					log.WithField("file", path).Debug("처리에 실패한 파일 (바뀌기 전까지 건너뜀)")
+					continue
+				}
+				delete(p.failed, path)
+			}
+		}
+
+		hash, err := HashFile(path)
+		if err != nil {
+			return processed, err
+		}
+
+		if !p.force && p.store.IsProcessed(hash) {
+			log.WithField("file", path).Debug("이미 처리한 파일 (건너뜀)")
+			continue
+		}
+
+		if err := p.handler(ctx, path); err != nil {
+			if !waitStable || ctx.Err() != nil {
+				return processed, errors.Wrapf(err, "%s 처리 실패", path)
+			}
+			// 한 파일의 오류로 뒤의 파일을 막지 않음
+			p.failed[path] = p.seen[path]
+			log.WithError(err).WithField("file", path).Error("입력 파일 처리 실패 (파일이 바뀌면 다시 처리)")
+			continue
+		}
+
+		if err := p.store.MarkProcessed(hash, path); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// 직전 확인 때와 크기, 수정 시각이 같은 앞쪽 파일 목록 (처음 본 파일은 바뀐 것으로 봄)
+// 모든 파일의 상태를 기록하여 다음 확인에서 비교
+func (p *Processor) stablePrefix(paths []string) ([]string, error) {
+	seen := make(map[string]fileState, len(paths)) // 없어진 파일은 기록에서 뺌
+	defer func() { p.seen = seen }()
+
+	stable := len(paths)
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s 상태 확인 실패", path)
+		}
+
+		current := fileState{size: info.Size(), modTime: info.ModTime()}
+		previous, exists := p.seen[path]
+		seen[path] = current
+		if (!exists || previous != current) && i < stable {
+			log.WithField("file", path).Debug("쓰는 중인 파일 (다음 확인에서 처리)")
+			stable = i
+		}
+	}
+	return paths[:stable], nil
+}
+
+// 주기적으로 입력 경로를 확인하며 새 파일을 처리 (컨텍스트 취소 시 종료)
+// 새 파일은 연속된 두 번의 확인에서 크기와 수정 시각이 같을 때 처리
+func (p *Processor) Watch(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.processPending(ctx, true); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// 개별 파일 오류로 감시를 중단하지 않음 (다음 주기에 재시도)
+			log.WithError(err).Error("입력 파일 처리 실패 (계속 감시)")
+		}
+		if p.idle != nil && ctx.Err() == nil {
+			p.idle(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
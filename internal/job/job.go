@@ -0,0 +1,247 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+func (s Status) IsFinished() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+}
+
+// 작업 하나를 실행하는 함수 (진행 상황은 훅으로 전달)
+type RunFunc func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error)
+
+// 채널별 전송 진행 상황
+type Progress struct {
+	TotalUsers   int `json:"total_users"`
+	EmailSuccess int `json:"email_success"`
+	EmailFailed  int `json:"email_failed"`
+	SMSSuccess   int `json:"sms_success"`
+	SMSFailed    int `json:"sms_failed"`
+}
+
+// 조회 시점의 작업 상태 (복사본)
+type Snapshot struct {
+	ID         string           `json:"id"`
+	Source     string           `json:"source"`
+	InputHash  string           `json:"input_hash,omitempty"`
+	Status     Status           `json:"status"`
+	Stage      pipeline.Stage   `json:"completed_stage,omitempty"` // 마지막으로 끝난 단계
+	Progress   Progress         `json:"progress"`
+	Result     *pipeline.Result `json:"result,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+// 알림 전송 작업
+type Job struct {
+	run    RunFunc
+	store  *Store          // nil이면 이력을 남기지 않음
+	audit  *audit.Log      // nil이면 감사 기록을 남기지 않음
+	outbox *service.Outbox // nil이면 남은 전송을 보관하지 않음
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	record    *Record
+	stage     pipeline.Stage
+	progress  Progress
+	result    *pipeline.Result
+	reports   []pipeline.UserReport // 앞에서부터 MaxReports건
+	truncated bool
+	err       error
+}
+
+// 작업마다 보관하는 사용자별 결과 수 (넘는 결과는 감사 기록으로 조회)
+const MaxReports = 10000
+
+func (j *Job) ID() string {
+	return j.record.ID
+}
+
+// 작업이 끝나면 닫히는 채널
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// 실행 중이면 컨텍스트를 취소하며, 남은 전송은 시도하지 않음
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+func (j *Job) Snapshot() Snapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	snapshot := Snapshot{
+		ID:        j.record.ID,
+		Source:    j.record.Source,
+		InputHash: j.record.InputHash,
+		Status:    j.record.Status,
+		Stage:     j.stage,
+		Progress:  j.progress,
+		CreatedAt: j.record.StartTime,
+	}
+
+	// 결과는 작업이 끝난 뒤에만 공개 (실행 중에는 파이프라인이 갱신)
+	if j.record.Status.IsFinished() {
+		snapshot.Result = j.result
+		snapshot.FinishedAt = j.record.EndTime
+	}
+	if j.err != nil {
+		snapshot.Error = j.err.Error()
+	}
+
+	return snapshot
+}
+
+// 끝난 시각 (끝나지 않았으면 nil)
+func (j *Job) finishedAt() *time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.record.Status.IsFinished() {
+		return nil
+	}
+	return j.record.EndTime
+}
+
+// 사용자별 처리 결과 (앞에서부터 MaxReports건, 넘으면 truncated, 작업이 끝나기 전에는 ok가 false)
+func (j *Job) Reports() (reports []pipeline.UserReport, truncated bool, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.record.Status.IsFinished() || j.result == nil {
+		return nil, false, false
+	}
+	return append([]pipeline.UserReport{}, j.reports...), j.truncated, true
+}
+
+func (j *Job) execute() {
+	defer close(j.done)
+
+	j.mu.Lock()
+	if j.ctx.Err() != nil {
+		j.finish(nil, j.ctx.Err())
+		j.mu.Unlock()
+		return
+	}
+	j.record.Status = StatusRunning
+	j.save()
+	j.mu.Unlock()
+
+	var recorder *audit.Recorder
+	if j.audit != nil {
+		recorder = j.audit.NewRecorder()
+	}
+	result, err := j.run(j.ctx, pipeline.Hooks{
+		OnStageDone: j.onStageDone,
+		OnSend: func(user *domain.User, channel domain.NotificationChannel, err error) {
+			j.onSend(user, channel, err)
+			if recorder != nil {
+				recorder.Observe(user, channel, err)
+			}
+		},
+		OnRecords: func(records []pipeline.Record) {
+			j.onRecords(recorder, records)
+		},
+	})
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.finish(result, err)
+}
+
+// 배치마다 감사 기록과 아웃박스(회로 차단기로 보관한 전송, 취소로 남은 전송)를 남기고 결과를 보관
+// 기록 실패로 작업 결과를 바꾸지 않음
+func (j *Job) onRecords(recorder *audit.Recorder, records []pipeline.Record) {
+	if recorder != nil {
+		if err := j.audit.Append(recorder.Entries(j.record.ID, j.record.Source, records)); err != nil {
+			log.WithError(err).WithField("job", j.record.ID).Error("감사 기록 실패")
+		}
+	}
+
+	if j.outbox != nil {
+		if err := j.outbox.Append(pipeline.OutboxEntries(j.record.ID, records)); err != nil {
+			log.WithError(err).WithField("job", j.record.ID).Error("아웃박스 기록 실패")
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, record := range records {
+		if len(j.reports) >= MaxReports {
+			j.truncated = true
+			break
+		}
+		j.reports = append(j.reports, record.Report)
+	}
+}
+
+// j.mu를 잡은 상태에서 호출
+func (j *Job) finish(result *pipeline.Result, err error) {
+	j.result = result
+	j.err = err
+	j.record.Finish(result, err, j.ctx.Err() == context.Canceled)
+	j.save()
+	j.cancel()
+}
+
+// j.mu를 잡은 상태에서 호출, 이력 기록 실패로 작업을 중단하지 않음
+func (j *Job) save() {
+	if j.store == nil {
+		return
+	}
+
+	if err := j.store.Save(j.record); err != nil {
+		log.WithError(err).WithField("job", j.record.ID).Error("작업 이력 기록 실패")
+	}
+}
+
+func (j *Job) onStageDone(stage pipeline.Stage, result *pipeline.Result) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.stage = stage
+	if stage == pipeline.StageParsing {
+		j.progress.TotalUsers = result.TotalUsers
+	}
+}
+
+func (j *Job) onSend(user *domain.User, channel domain.NotificationChannel, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	switch {
+	case channel == domain.EmailChannel && err == nil:
+		j.progress.EmailSuccess++
+	case channel == domain.EmailChannel:
+		j.progress.EmailFailed++
+	case err == nil:
+		j.progress.SMSSuccess++
+	default:
+		j.progress.SMSFailed++
+	}
+}
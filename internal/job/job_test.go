@@ -0,0 +1,284 @@
+package job
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+func TestManager_Submit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		runErr         error
+		expectedStatus Status
+	}{
+		{
+			name:           "성공",
+			expectedStatus: StatusCompleted,
+		},
+		{
+			name:           "실패",
+			runErr:         errors.New("파싱 실패"),
+			expectedStatus: StatusFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 진행 상황을 훅으로 전달하는 작업
+			store := NewStore(filepath.Join(t.TempDir(), "jobs.jsonl"))
+			manager := NewManager(context.Background(), store)
+			user := &domain.User{Email: "user@example.com", PhoneNumber: "010-1234-5678", CreditUp: true}
+
+			// When: 작업 제출 후 완료 대기
+			j := manager.Submit("test", "hash", func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+				result, err := pipeline.New(pipeline.Config{}).Run(ctx, nil, pipeline.Hooks{OnStageDone: hooks.OnStageDone})
+				require.NoError(t, err)
+				hooks.OnSend(user, domain.EmailChannel, nil)
+				hooks.OnSend(user, domain.SMSChannel, errors.New("SMS 실패"))
+				return result, tc.runErr
+			})
+			waitDone(t, j)
+
+			// Then: 상태와 진행 상황 검증
+			found, err := manager.Get(j.ID())
+			require.NoError(t, err)
+
+			snapshot := found.Snapshot()
+			assert.Equal(t, tc.expectedStatus, snapshot.Status)
+			assert.Equal(t, pipeline.StageSending, snapshot.Stage)
+			assert.Equal(t, 1, snapshot.Progress.EmailSuccess)
+			assert.Equal(t, 1, snapshot.Progress.SMSFailed)
+			assert.NotNil(t, snapshot.FinishedAt)
+			assert.NotNil(t, snapshot.Result)
+
+			_, truncated, ok := found.Reports()
+			assert.True(t, ok)
+			assert.False(t, truncated)
+
+			// Then: 최종 상태가 이력에 기록됨
+			record, err := store.Get(j.ID())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, record.Status)
+			assert.Equal(t, "hash", record.InputHash)
+			assert.NotNil(t, record.EndTime)
+		})
+	}
+}
+
+func TestManager_Submit_AuditLog(t *testing.T) {
+	// Given: 감사 기록을 남기는 작업 관리자와 SMS 전송이 실패하는 처리 흐름
+	hasher, err := audit.NewHasher([]byte("test-secret-key-for-audit"))
+	require.NoError(t, err)
+	auditLog := audit.NewLog(filepath.Join(t.TempDir(), "audit.jsonl"), hasher)
+	manager := NewManager(context.Background(), nil)
+	manager.SetAuditLog(auditLog)
+	users := []*domain.User{
+		{Email: "user@example.com", PhoneNumber: "010-1234-5678", CreditUp: true},
+		{Email: "down@example.com", PhoneNumber: "010-1234-5679", CreditUp: false},
+	}
+	p := pipeline.New(pipeline.Config{
+		BatchSize: 1,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(noopSender{}),
+				service.NewSMSServiceWithClient(failingSender{}),
+			)
+		},
+	})
+
+	// When: 작업 제출 후 완료 대기
+	j := manager.Submit("test", "hash", func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+		return p.Run(ctx, users, hooks)
+	})
+	waitDone(t, j)
+
+	// Then: 작업 ID로 입력 레코드의 판단과 전송 시도가 배치마다 기록됨
+	entries, err := auditLog.Lookup(audit.Query{PhoneNumber: "01012345678"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, j.ID(), entries[0].RunID)
+	assert.Equal(t, "test", entries[0].Source)
+	assert.Equal(t, pipeline.StatusFailed, entries[0].SMSChannel.Status)
+	require.Len(t, entries[0].SMSChannel.Attempts, 1)
+	assert.Equal(t, "SMS 실패", entries[0].SMSChannel.Attempts[0].Error)
+
+	entries, err = auditLog.Lookup(audit.Query{Email: "down@example.com"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "credit_up", entries[0].ExcludedBy)
+
+	// Then: 사용자별 결과도 입력 순서대로 보관
+	reports, truncated, ok := j.Reports()
+	require.True(t, ok)
+	assert.False(t, truncated)
+	require.Len(t, reports, 2)
+	assert.Equal(t, pipeline.StatusSent, reports[0].EmailStatus)
+	assert.Equal(t, pipeline.StatusExcluded, reports[1].EmailStatus)
+}
+
+func TestManager_Cancel(t *testing.T) {
+	// Given: 취소될 때까지 실행되는 작업
+	manager := NewManager(context.Background(), nil)
+	started := make(chan struct{})
+	j := manager.Submit("test", "", func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	// When: 실행 중인 작업 취소
+	_, err := manager.Cancel(j.ID())
+	require.NoError(t, err)
+	waitDone(t, j)
+
+	// Then: 취소 상태, 결과 없음
+	assert.Equal(t, StatusCancelled, j.Snapshot().Status)
+	_, _, ok := j.Reports()
+	assert.False(t, ok)
+
+	// When & Then: 없는 작업 취소
+	_, err = manager.Cancel("없는작업")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManager_Evict(t *testing.T) {
+	// Given: 끝난 작업을 2개까지 보관하는 작업 관리자와 끝난 작업 3개
+	manager := NewManager(context.Background(), nil)
+	manager.SetRetention(time.Hour, 2)
+	done := func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+		return nil, nil
+	}
+	finished := make([]*Job, 0, 3)
+	for i := 0; i < 3; i++ {
+		j := manager.Submit("test", "", done)
+		waitDone(t, j)
+		finished = append(finished, j)
+	}
+
+	// When: 새 작업 제출
+	release := make(chan struct{})
+	defer close(release)
+	running := manager.Submit("test", "", func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+		<-release
+		return nil, nil
+	})
+
+	// Then: 개수를 넘은 가장 오래된 작업만 제거
+	_, err := manager.Get(finished[0].ID())
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = manager.Get(finished[1].ID())
+	assert.NoError(t, err)
+	_, err = manager.Get(finished[2].ID())
+	assert.NoError(t, err)
+
+	// When: 보관 기간이 지난 뒤 정리
+	manager.mu.Lock()
+	manager.evict(time.Now().Add(2 * time.Hour))
+	manager.mu.Unlock()
+
+	// Then: 끝난 작업은 모두 제거하고 실행 중인 작업은 유지
+	_, err = manager.Get(finished[2].ID())
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = manager.Get(running.ID())
+	assert.NoError(t, err)
+}
+
+func TestManager_Cancel_Outbox(t *testing.T) {
+	// Given: 남은 전송을 아웃박스에 보관하는 작업 관리자
+	outbox := service.NewOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	manager := NewManager(context.Background(), nil)
+	manager.SetOutbox(outbox)
+	user := &domain.User{Email: "user@example.com", PhoneNumber: "010-1234-5678", CreditUp: true}
+	p := pipeline.New(pipeline.Config{
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(noopSender{}),
+				service.NewSMSServiceWithClient(noopSender{}),
+			)
+		},
+	})
+
+	started := make(chan struct{})
+	j := manager.Submit("test", "", func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+		close(started)
+		<-ctx.Done()
+		return p.Run(ctx, []*domain.User{user}, hooks)
+	})
+	<-started
+
+	// When: 전송 전에 취소
+	_, err := manager.Cancel(j.ID())
+	require.NoError(t, err)
+	waitDone(t, j)
+
+	// Then: 채널별 남은 전송이 작업 ID로 보관됨
+	assert.Equal(t, StatusCancelled, j.Snapshot().Status)
+	entries, err := outbox.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, j.ID(), entries[0].RunID)
+	assert.Equal(t, service.OutboxInterrupted, entries[0].Reason)
+	assert.Equal(t, "sms", entries[1].Channel)
+}
+
+func TestStore(t *testing.T) {
+	// Given: 임시 이력 파일
+	store := NewStore(filepath.Join(t.TempDir(), "state", "jobs.jsonl"))
+
+	first := NewRecord("files/input/20250701.txt", "hash-a")
+	second := NewRecord("files/input/20250702.txt", "hash-b")
+	second.StartTime = first.StartTime.Add(time.Hour)
+
+	// When: 시작, 종료 상태를 차례로 기록
+	require.NoError(t, store.Save(first))
+	require.NoError(t, store.Save(second))
+	first.Finish(&pipeline.Result{TotalUsers: 10, EmailSuccess: 7}, nil, false)
+	require.NoError(t, store.Save(first))
+
+	// Then: 작업별 최신 상태를 시작 시각 순으로 조회
+	records, err := NewStore(store.path).List()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, first.ID, records[0].ID)
+	assert.Equal(t, StatusCompleted, records[0].Status)
+	assert.Equal(t, 7, records[0].Counts.EmailSuccess)
+	assert.Equal(t, StatusRunning, records[1].Status)
+
+	// When & Then: 없는 작업 조회
+	_, err = store.Get("없는작업")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func waitDone(t *testing.T, j *Job) {
+	t.Helper()
+	select {
+	case <-j.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("작업이 끝나지 않음")
+	}
+}
+
+type noopSender struct{}
+
+func (noopSender) Send(to string, message string) error {
+	return nil
+}
+
+type failingSender struct{}
+
+func (failingSender) Send(to string, message string) error {
+	return errors.New("SMS 실패")
+}
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/audit"
+	"banksalad-backend-task/internal/service"
+)
+
+var ErrNotFound = errors.New("작업을 찾을 수 없습니다")
+
+// 끝난 작업을 보관하는 기본 기간과 개수 (넘으면 조회할 수 없고 이력은 작업 이력 파일에 남음)
+const (
+	DefaultRetention   = 24 * time.Hour
+	DefaultMaxFinished = 1000
+)
+
+// 제출된 작업을 보관하고 백그라운드에서 실행
+type Manager struct {
+	ctx         context.Context
+	store       *Store
+	audit       *audit.Log
+	outbox      *service.Outbox
+	retention   time.Duration
+	maxFinished int
+	mu          sync.Mutex
+	jobs        map[string]*Job
+	order       []string // 제출 순서 (오래된 작업부터 정리)
+	wg          sync.WaitGroup
+}
+
+// ctx가 취소되면 실행 중인 모든 작업도 취소, store가 nil이면 이력을 남기지 않음
+func NewManager(ctx context.Context, store *Store) *Manager {
+	return &Manager{
+		ctx:         ctx,
+		store:       store,
+		retention:   DefaultRetention,
+		maxFinished: DefaultMaxFinished,
+		jobs:        make(map[string]*Job),
+	}
+}
+
+// 끝난 작업을 retention 동안, 최대 maxFinished개까지 보관 (작업 제출 전에 설정)
+func (m *Manager) SetRetention(retention time.Duration, maxFinished int) {
+	m.retention = retention
+	m.maxFinished = maxFinished
+}
+
+// 작업이 끝날 때마다 입력 레코드별 감사 기록을 남김 (작업 제출 전에 설정)
+func (m *Manager) SetAuditLog(auditLog *audit.Log) {
+	m.audit = auditLog
+}
+
+// 취소된 작업의 남은 전송을 보관 (작업 제출 전에 설정)
+func (m *Manager) SetOutbox(outbox *service.Outbox) {
+	m.outbox = outbox
+}
+
+// inputHash는 입력 내용의 해시 (이력에서 같은 입력의 처리 여부 확인용)
+func (m *Manager) Submit(source, inputHash string, run RunFunc) *Job {
+	record := NewRecord(source, inputHash)
+	record.Status = StatusQueued
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	j := &Job{
+		run:    run,
+		store:  m.store,
+		audit:  m.audit,
+		outbox: m.outbox,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		record: record,
+	}
+
+	m.mu.Lock()
+	m.evict(time.Now())
+	m.jobs[record.ID] = j
+	m.order = append(m.order, record.ID)
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		j.execute()
+	}()
+
+	return j
+}
+
+func (m *Manager) Get(id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, exists := m.jobs[id]
+	if !exists {
+		return nil, errors.Wrap(ErrNotFound, id)
+	}
+	return j, nil
+}
+
+func (m *Manager) Cancel(id string) (*Job, error) {
+	j, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	j.Cancel()
+	return j, nil
+}
+
+// m.mu를 잡은 상태에서 호출, 보관 기간이 지났거나 개수를 넘은 끝난 작업을 오래된 순으로 제거 (실행 중인 작업은 유지)
+func (m *Manager) evict(now time.Time) {
+	finished := 0
+	for _, id := range m.order {
+		if m.jobs[id].finishedAt() != nil {
+			finished++
+		}
+	}
+
+	kept := m.order[:0]
+	for _, id := range m.order {
+		endTime := m.jobs[id].finishedAt()
+		if endTime != nil && (now.Sub(*endTime) > m.retention || finished > m.maxFinished) {
+			delete(m.jobs, id)
+			finished--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+// 실행 중인 모든 작업이 끝날 때까지 대기
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
@@ -0,0 +1,96 @@
+package job
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 단계별 처리 인원
+type Counts struct {
+	TotalUsers      int `json:"total_users"`
+	EligibleUsers   int `json:"eligible_users"`
+	UniqueUsers     int `json:"unique_users"`
+	EmailSuppressed int `json:"email_suppressed"`
+	SMSSuppressed   int `json:"sms_suppressed"`
+	SMSDeferred     int `json:"sms_deferred"`
+	SMSReleased     int `json:"sms_released"`
+	EmailTargets    int `json:"email_targets"`
+	SMSTargets      int `json:"sms_targets"`
+	EmailSuccess    int `json:"email_success"`
+	SMSSuccess      int `json:"sms_success"`
+}
+
+func countsFrom(result *pipeline.Result) Counts {
+	if result == nil {
+		return Counts{}
+	}
+
+	return Counts{
+		TotalUsers:      result.TotalUsers,
+		EligibleUsers:   result.EligibleUsers,
+		UniqueUsers:     result.UniqueUsers,
+		EmailSuppressed: result.EmailSuppressed,
+		SMSSuppressed:   result.SMSSuppressed,
+		SMSDeferred:     result.SMSDeferred,
+		SMSReleased:     result.SMSReleased,
+		EmailTargets:    result.EmailTargets,
+		SMSTargets:      result.SMSTargets,
+		EmailSuccess:    result.EmailSuccess,
+		SMSSuccess:      result.SMSSuccess,
+	}
+}
+
+// 저장소에 남기는 작업 이력
+type Record struct {
+	ID        string     `json:"id"`
+	Source    string     `json:"source"`
+	InputHash string     `json:"input_hash,omitempty"`
+	Status    Status     `json:"status"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Counts    Counts     `json:"counts"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func NewRecord(source, inputHash string) *Record {
+	return &Record{
+		ID:        newID(),
+		Source:    source,
+		InputHash: inputHash,
+		Status:    StatusRunning,
+		StartTime: time.Now().In(domain.KST),
+	}
+}
+
+// 실행 결과로 상태와 단계별 인원 기록 (cancelled는 취소로 끝난 경우)
+func (r *Record) Finish(result *pipeline.Result, err error, cancelled bool) {
+	endTime := time.Now().In(domain.KST)
+	r.EndTime = &endTime
+	r.Counts = countsFrom(result)
+
+	switch {
+	case err != nil && cancelled:
+		r.Status = StatusCancelled
+	case err != nil:
+		r.Status = StatusFailed
+	default:
+		r.Status = StatusCompleted
+	}
+
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// 실행 시각(KST)과 난수로 만든 작업 ID (여러 프로세스가 같은 저장소를 써도 겹치지 않도록)
+func newID() string {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		panic(err)
+	}
+	return time.Now().In(domain.KST).Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
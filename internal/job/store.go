@@ -0,0 +1,126 @@
+package job
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// 작업 이력을 기록하는 파일 기반 저장소 (JSON Lines)
+// 상태가 바뀔 때마다 한 줄씩 추가하며, 같은 ID는 마지막 줄이 최신 상태
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+	}
+}
+
+func (s *Store) Save(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.Wrap(err, "작업 이력 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "작업 이력 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close job store file")
+		}
+	}()
+
+	if err := json.NewEncoder(file).Encode(record); err != nil {
+		return errors.Wrap(err, "작업 이력 기록 실패")
+	}
+
+	return nil
+}
+
+// 작업별 최신 상태를 시작 시각 순으로 반환
+func (s *Store) List() ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Record, 0, len(records))
+	for _, record := range records {
+		list = append(list, record)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].StartTime.Equal(list[j].StartTime) {
+			return list[i].ID < list[j].ID
+		}
+		return list[i].StartTime.Before(list[j].StartTime)
+	})
+
+	return list, nil
+}
+
+func (s *Store) Get(id string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	record, exists := records[id]
+	if !exists {
+		return nil, errors.Wrap(ErrNotFound, id)
+	}
+	return record, nil
+}
+
+func (s *Store) load() (map[string]*Record, error) {
+	records := make(map[string]*Record)
+
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "작업 이력 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close job store file")
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, errors.Wrap(err, "작업 이력 항목 파싱 실패")
+		}
+		records[record.ID] = &record
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "작업 이력 파일 읽기 오류")
+	}
+
+	return records, nil
+}
@@ -0,0 +1,93 @@
+package message
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// 단문(SMS) 최대 길이 (EUC-KR 기준 바이트)
+	SMSMaxBytes = 80
+	// 장문(LMS) 최대 길이 (EUC-KR 기준 바이트)
+	LMSMaxBytes = 2000
+)
+
+type SMSType int
+
+const (
+	SMS SMSType = iota
+	LMS
+)
+
+func (st SMSType) String() string {
+	switch st {
+	case SMS:
+		return "SMS"
+	case LMS:
+		return "LMS"
+	default:
+		return "Unknown"
+	}
+}
+
+type SMSMessage struct {
+	Body string
+	Type SMSType
+}
+
+// 통신사 과금 기준인 EUC-KR 바이트 수 (ASCII 1바이트, 그 외 2바이트)
+func SMSByteLength(body string) int {
+	length := 0
+	for _, r := range body {
+		if r < utf8.RuneSelf {
+			length++
+		} else {
+			length += 2
+		}
+	}
+	return length
+}
+
+func ClassifySMS(body string) (SMSType, error) {
+	length := SMSByteLength(body)
+	switch {
+	case length <= SMSMaxBytes:
+		return SMS, nil
+	case length <= LMSMaxBytes:
+		return LMS, nil
+	default:
+		return LMS, fmt.Errorf("메시지 길이 초과: %d바이트 (최대 %d바이트)", length, LMSMaxBytes)
+	}
+}
+
+type EmailMessage struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+const mimeBoundary = "banksalad-notification-boundary"
+
+// 평문과 HTML 본문을 multipart/alternative 형식으로 구성
+func (em *EmailMessage) MIME() string {
+	var sb strings.Builder
+
+	// 헤더는 ASCII만 허용하므로 한글 제목은 RFC 2047로 인코딩 (ASCII 제목은 그대로)
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", em.Subject) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: multipart/alternative; boundary=" + mimeBoundary + "\r\n\r\n")
+
+	sb.WriteString("--" + mimeBoundary + "\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	sb.WriteString(em.Text + "\r\n")
+
+	sb.WriteString("--" + mimeBoundary + "\r\n")
+	sb.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	sb.WriteString(em.HTML + "\r\n")
+
+	sb.WriteString("--" + mimeBoundary + "--\r\n")
+
+	return sb.String()
+}
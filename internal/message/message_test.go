@@ -0,0 +1,250 @@
+package message
+
+import (
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+func TestClassifySMS(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		expectedType SMSType
+		expectError  bool
+	}{
+		{
+			name:         "영문 80바이트 - 단문",
+			body:         strings.Repeat("a", 80),
+			expectedType: SMS,
+		},
+		{
+			name:         "한글 40자(80바이트) - 단문",
+			body:         strings.Repeat("가", 40),
+			expectedType: SMS,
+		},
+		{
+			name:         "한글 41자(82바이트) - 장문",
+			body:         strings.Repeat("가", 41),
+			expectedType: LMS,
+		},
+		{
+			name:        "장문 최대 길이 초과",
+			body:        strings.Repeat("가", 1001),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 메시지 유형 분류
+			smsType, err := ClassifySMS(tc.body)
+
+			// Then: 결과 검증
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, smsType)
+		})
+	}
+}
+
+func TestMasking(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mask     func(string) string
+		input    string
+		expected string
+	}{
+		{
+			name:     "이름 마스킹",
+			mask:     MaskName,
+			input:    "Duser206226_26@example.fake",
+			expected: "Du************",
+		},
+		{
+			name:     "이메일 마스킹",
+			mask:     MaskEmail,
+			input:    "ab@example.fake",
+			expected: "**@example.fake",
+		},
+		{
+			name:     "전화번호 마스킹",
+			mask:     MaskPhoneNumber,
+			input:    "000-1815-2005",
+			expected: "000-****-2005",
+		},
+		{
+			name:     "형식이 다른 전화번호 마스킹",
+			mask:     MaskPhoneNumber,
+			input:    "01012345678",
+			expected: "010********",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 마스킹 실행
+			result := tc.mask(tc.input)
+
+			// Then: 결과 검증
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestRenderer_RenderSMS(t *testing.T) {
+	// Given: 기본 템플릿 렌더러와 사용자 준비
+	renderer := NewDefaultRenderer()
+	user, err := domain.NewUser("Duser206226_26@example.fake", "000-1815-2005", true)
+	require.NoError(t, err)
+
+	// When: SMS 메시지 생성
+	msg, err := renderer.RenderSMS(user)
+
+	// Then: 마스킹된 이름이 포함된 단문 메시지
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "Du************님")
+	assert.NotContains(t, msg.Body, "Duser206226_26")
+	assert.Equal(t, SMS, msg.Type)
+}
+
+func TestRenderer_RenderSMS_WithScore(t *testing.T) {
+	// Given: 점수 정보가 있는 사용자
+	renderer := NewDefaultRenderer()
+	user, err := domain.NewUser("Duser206226_26@example.fake", "000-1815-2005", true)
+	require.NoError(t, err)
+	user.Score, err = domain.NewCreditScore(780, 803)
+	require.NoError(t, err)
+
+	// When: SMS 메시지 생성
+	msg, err := renderer.RenderSMS(user)
+
+	// Then: 상승폭이 메시지에 포함
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "23점 올랐어요")
+}
+
+func TestRenderer_RenderEmail(t *testing.T) {
+	// Given: 고정된 시각을 사용하는 렌더러 준비
+	renderer := NewDefaultRenderer()
+	renderer.now = func() time.Time {
+		return time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC) // KST 기준 7월 1일
+	}
+	user, err := domain.NewUser("Duser206226_26@example.fake", "000-1815-2005", true)
+	require.NoError(t, err)
+
+	// When: 이메일 메시지 생성
+	msg, err := renderer.RenderEmail(user)
+
+	// Then: 평문과 HTML 본문 모두 KST 날짜 포함
+	require.NoError(t, err)
+	assert.Contains(t, msg.Text, "2025-07-01")
+	assert.Contains(t, msg.HTML, "<p>")
+	assert.Contains(t, msg.HTML, "2025-07-01")
+
+	content := msg.MIME()
+	assert.Contains(t, content, "Content-Type: text/plain")
+	assert.Contains(t, content, "Content-Type: text/html")
+}
+
+func TestEmailMessage_MIME_Subject(t *testing.T) {
+	testCases := []struct {
+		name    string
+		subject string
+	}{
+		{name: "한글 제목", subject: "[뱅크샐러드] 신용점수 상승 알림"},
+		{name: "ASCII 제목", subject: "Credit score update"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 제목이 있는 이메일 메시지
+			msg := &EmailMessage{Subject: tc.subject, Text: "본문", HTML: "<p>본문</p>"}
+
+			// When: MIME 메시지 구성
+			header, _, found := strings.Cut(msg.MIME(), "\r\n")
+			require.True(t, found)
+
+			// Then: 제목 헤더는 ASCII만 포함하고 디코딩하면 원래 제목
+			subject, ok := strings.CutPrefix(header, "Subject: ")
+			require.True(t, ok)
+			for _, r := range subject {
+				assert.Less(t, r, rune(0x80))
+			}
+			decoded, err := new(mime.WordDecoder).DecodeHeader(subject)
+			require.NoError(t, err)
+			assert.Equal(t, tc.subject, decoded)
+		})
+	}
+}
+
+func TestLoadRenderer(t *testing.T) {
+	t.Run("저장소 템플릿 로딩", func(t *testing.T) {
+		// When: 저장소에 포함된 템플릿 디렉토리 로딩
+		renderer, err := LoadRenderer(filepath.Join("..", "..", "files", "templates"))
+
+		// Then: 정상 로딩 및 렌더링
+		require.NoError(t, err)
+
+		user, err := domain.NewUser("user@example.com", "010-1234-5678", true)
+		require.NoError(t, err)
+
+		_, err = renderer.RenderSMS(user)
+		assert.NoError(t, err)
+	})
+
+	t.Run("템플릿 파일 누락", func(t *testing.T) {
+		// Given: SMS 템플릿만 있는 디렉토리
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, SMSTemplateFile), []byte("hello"), 0644))
+
+		// When: 템플릿 로딩
+		renderer, err := LoadRenderer(dir)
+
+		// Then: 에러 확인
+		assert.Error(t, err)
+		assert.Nil(t, renderer)
+	})
+
+	t.Run("HTML 템플릿 이스케이프", func(t *testing.T) {
+		// Given: 사용자 값을 그대로 출력하는 템플릿
+		dir := t.TempDir()
+		templates := map[string]string{
+			SMSTemplateFile:          "{{.MaskedName}}",
+			EmailSubjectTemplateFile: "subject",
+			EmailTextTemplateFile:    "{{.MaskedEmail}}",
+			EmailHTMLTemplateFile:    "<p>{{.MaskedEmail}}</p>",
+		}
+		for name, content := range templates {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+		}
+
+		renderer, err := LoadRenderer(dir)
+		require.NoError(t, err)
+
+		user, err := domain.NewUser("<b>@example.com", "010-1234-5678", true)
+		require.NoError(t, err)
+
+		// When: 이메일 생성
+		msg, err := renderer.RenderEmail(user)
+
+		// Then: HTML 본문만 이스케이프
+		require.NoError(t, err)
+		assert.Equal(t, "<b*@example.com", msg.Text)
+		assert.Equal(t, "<p>&lt;b*@example.com</p>", msg.HTML)
+	})
+}
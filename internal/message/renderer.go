@@ -0,0 +1,192 @@
+package message
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+const (
+	SMSTemplateFile          = "sms.tmpl"
+	EmailSubjectTemplateFile = "email_subject.tmpl"
+	EmailTextTemplateFile    = "email_text.tmpl"
+	EmailHTMLTemplateFile    = "email_html.tmpl"
+)
+
+const (
+	defaultSMSTemplate          = `[뱅크샐러드] {{.MaskedName}}님, {{if .ScoreDelta}}신용점수가 {{.ScoreDelta}}점 올랐어요!{{else}}신용점수가 올랐어요!{{end}}`
+	defaultEmailSubjectTemplate = `[뱅크샐러드] 신용점수 상승 알림`
+	defaultEmailTextTemplate    = `{{.MaskedName}}님, {{.Date}} 기준 신용점수가 {{if .ScoreDelta}}{{.ScoreDelta}}점 {{end}}상승했습니다.`
+	defaultEmailHTMLTemplate    = `<p>{{.MaskedName}}님, {{.Date}} 기준 신용점수가 {{if .ScoreDelta}}<strong>{{.ScoreDelta}}점</strong> {{end}}상승했습니다.</p>`
+)
+
+// 템플릿에서 사용할 수 있는 사용자별 변수
+type Data struct {
+	MaskedName    string
+	MaskedEmail   string
+	MaskedPhone   string
+	ScoreDelta    int
+	PreviousScore int
+	CurrentScore  int
+	Bureau        string
+	Date          string
+}
+
+type Renderer struct {
+	sms          *template.Template
+	emailSubject *template.Template
+	emailText    *template.Template
+	emailHTML    *htmltemplate.Template
+	location     *time.Location
+	now          func() time.Time
+}
+
+func NewDefaultRenderer() *Renderer {
+	renderer, err := newRenderer(defaultSMSTemplate, defaultEmailSubjectTemplate, defaultEmailTextTemplate, defaultEmailHTMLTemplate)
+	if err != nil {
+		panic(err)
+	}
+	return renderer
+}
+
+// 디렉토리에서 채널별 템플릿 파일을 읽어 생성
+func LoadRenderer(dir string) (*Renderer, error) {
+	files := []string{SMSTemplateFile, EmailSubjectTemplateFile, EmailTextTemplateFile, EmailHTMLTemplateFile}
+	contents := make([]string, 0, len(files))
+
+	for _, name := range files {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "템플릿 파일을 읽을 수 없습니다: %s", name)
+		}
+		contents = append(contents, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return newRenderer(contents[0], contents[1], contents[2], contents[3])
+}
+
+func newRenderer(smsText, subjectText, emailText, emailHTML string) (*Renderer, error) {
+	sms, err := template.New(SMSTemplateFile).Option("missingkey=error").Parse(smsText)
+	if err != nil {
+		return nil, errors.Wrap(err, "SMS 템플릿 파싱 실패")
+	}
+
+	subject, err := template.New(EmailSubjectTemplateFile).Option("missingkey=error").Parse(subjectText)
+	if err != nil {
+		return nil, errors.Wrap(err, "이메일 제목 템플릿 파싱 실패")
+	}
+
+	text, err := template.New(EmailTextTemplateFile).Option("missingkey=error").Parse(emailText)
+	if err != nil {
+		return nil, errors.Wrap(err, "이메일 본문 템플릿 파싱 실패")
+	}
+
+	html, err := htmltemplate.New(EmailHTMLTemplateFile).Option("missingkey=error").Parse(emailHTML)
+	if err != nil {
+		return nil, errors.Wrap(err, "이메일 HTML 템플릿 파싱 실패")
+	}
+
+	return &Renderer{
+		sms:          sms,
+		emailSubject: subject,
+		emailText:    text,
+		emailHTML:    html,
+		location:     domain.KST,
+		now:          time.Now,
+	}, nil
+}
+
+func (r *Renderer) RenderSMS(user *domain.User) (*SMSMessage, error) {
+	var buf bytes.Buffer
+	if err := r.sms.Execute(&buf, r.dataFor(user)); err != nil {
+		return nil, errors.Wrap(err, "SMS 템플릿 실행 실패")
+	}
+
+	body := buf.String()
+	smsType, err := ClassifySMS(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SMSMessage{
+		Body: body,
+		Type: smsType,
+	}, nil
+}
+
+func (r *Renderer) RenderEmail(user *domain.User) (*EmailMessage, error) {
+	data := r.dataFor(user)
+
+	var subject, text, html bytes.Buffer
+	if err := r.emailSubject.Execute(&subject, data); err != nil {
+		return nil, errors.Wrap(err, "이메일 제목 템플릿 실행 실패")
+	}
+	if err := r.emailText.Execute(&text, data); err != nil {
+		return nil, errors.Wrap(err, "이메일 본문 템플릿 실행 실패")
+	}
+	if err := r.emailHTML.Execute(&html, data); err != nil {
+		return nil, errors.Wrap(err, "이메일 HTML 템플릿 실행 실패")
+	}
+
+	return &EmailMessage{
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+func (r *Renderer) dataFor(user *domain.User) Data {
+	data := Data{
+		MaskedName:  MaskName(user.Email),
+		MaskedEmail: MaskEmail(user.Email),
+		MaskedPhone: MaskPhoneNumber(user.PhoneNumber),
+		ScoreDelta:  user.ScoreDelta(),
+		Bureau:      user.Bureau,
+		Date:        r.now().In(r.location).Format("2006-01-02"),
+	}
+
+	if user.HasScore() {
+		data.PreviousScore = user.Score.Previous
+		data.CurrentScore = user.Score.Current
+	}
+
+	return data
+}
+
+// 이메일 로컬 파트의 앞 2글자만 노출 (이름 정보가 없으므로 이메일로 대체)
+func MaskName(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	return maskTail([]rune(local), 2)
+}
+
+func MaskEmail(email string) string {
+	local, domainPart, found := strings.Cut(email, "@")
+	if !found {
+		return maskTail([]rune(email), 2)
+	}
+	return maskTail([]rune(local), 2) + "@" + domainPart
+}
+
+// 전화번호 가운데 자리 마스킹 (000-1815-2005 → 000-****-2005)
+func MaskPhoneNumber(phoneNumber string) string {
+	parts := strings.Split(phoneNumber, "-")
+	if len(parts) != 3 {
+		return maskTail([]rune(phoneNumber), 3)
+	}
+	return parts[0] + "-" + strings.Repeat("*", len(parts[1])) + "-" + parts[2]
+}
+
+func maskTail(runes []rune, visible int) string {
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:visible]) + strings.Repeat("*", len(runes)-visible)
+}
@@ -0,0 +1,390 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 전송 클라이언트가 출력 디렉토리에 이어서 기록하는 파일 (clients 패키지와 같은 이름)
+const (
+	EmailFile = "notified_emails.txt"
+	SMSFile   = "notified_phone_numbers.txt"
+
+	ManifestFile = "manifest.json"
+	runsDir      = "runs"
+	archiveDir   = "archive"
+)
+
+var ErrNoRuns = errors.New("실행별 출력이 없습니다")
+
+// 클라이언트를 고치지 않고 실행마다 출력 파일을 분리
+// 클라이언트가 기록하는 출력 파일은 그대로 두고, 실행 전 파일 끝 위치(Mark) 이후에 추가된 줄만 runs/<실행 ID>/ 로 복사
+// 이전 프로세스가 남긴 출력 파일은 프로세스를 시작할 때(클라이언트가 파일을 열기 전) archive/<시각>/ 으로 옮김
+type Manager struct {
+	dir string
+	now func() time.Time
+}
+
+func NewManager(dir string) *Manager {
+	return &Manager{
+		dir: dir,
+		now: func() time.Time { return time.Now().In(domain.KST) },
+	}
+}
+
+// 실행 하나의 출력 파일 목록
+type Manifest struct {
+	RunID     string     `json:"run_id,omitempty"` // 보관한 이전 출력은 비어 있음
+	Source    string     `json:"source,omitempty"` // 입력 파일
+	InputHash string     `json:"input_hash,omitempty"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   time.Time  `json:"end_time"`
+	Config    *RunConfig `json:"config,omitempty"` // 대상 판단에 사용한 설정 (보관한 이전 출력은 없음)
+	Files     []File     `json:"files"`
+
+	Dir string `json:"-"`
+}
+
+// 실행의 대상 판단에 영향을 주는 설정 (대사에서 지금 설정과 같은지 확인)
+// 규칙과 수신 거부 목록은 파일 내용의 SHA-256 (지정하지 않았으면 빈 값)
+type RunConfig struct {
+	RulesHash       string `json:"rules_hash,omitempty"`
+	MinScoreDelta   int    `json:"min_score_delta,omitempty"`
+	ScoreThreshold  int    `json:"score_threshold,omitempty"`
+	SuppressionHash string `json:"suppression_hash,omitempty"`
+	DedupKey        string `json:"dedup_key"`
+	MergePolicy     string `json:"merge_policy"`
+	SMSWindow       string `json:"sms_window,omitempty"`
+}
+
+// other와 값이 다른 설정 이름 (같으면 빈 목록)
+func (c RunConfig) Diff(other RunConfig) []string {
+	var names []string
+	for _, field := range []struct {
+		name        string
+		this, other any
+	}{
+		{"rules", c.RulesHash, other.RulesHash},
+		{"min-score-delta", c.MinScoreDelta, other.MinScoreDelta},
+		{"score-threshold", c.ScoreThreshold, other.ScoreThreshold},
+		{"suppression", c.SuppressionHash, other.SuppressionHash},
+		{"dedup-key", c.DedupKey, other.DedupKey},
+		{"merge-policy", c.MergePolicy, other.MergePolicy},
+		{"sms-window", c.SMSWindow, other.SMSWindow},
+	} {
+		if field.this != field.other {
+			names = append(names, field.name)
+		}
+	}
+	return names
+}
+
+type File struct {
+	Name    string `json:"name"`
+	Channel string `json:"channel"`
+	Offset  int64  `json:"offset"` // 출력 파일에서 이 실행의 기록이 시작하는 위치 (바이트)
+	Lines   int    `json:"lines"`
+	Bytes   int64  `json:"bytes"`
+	SHA256  string `json:"sha256"`
+}
+
+// 매니페스트 기준 파일 경로 (없는 채널은 빈 문자열)
+func (m *Manifest) Path(channel domain.NotificationChannel) string {
+	for _, file := range m.Files {
+		if file.Channel == channel.String() {
+			return filepath.Join(m.Dir, file.Name)
+		}
+	}
+	return ""
+}
+
+// 실행을 시작할 때의 출력 파일 끝 위치 (파일 이름 → 바이트, 없는 파일은 0)
+type Mark map[string]int64
+
+// 실행 전에 호출하여 출력 파일의 현재 끝 위치를 기록 (이전 실행이 남긴 줄은 다음 실행에 포함되지 않음)
+func (m *Manager) Mark() (Mark, error) {
+	mark := make(Mark)
+	for _, name := range []string{EmailFile, SMSFile} {
+		info, err := os.Stat(filepath.Join(m.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "출력 파일 확인 실패")
+		}
+		mark[name] = info.Size()
+	}
+	return mark, nil
+}
+
+// mark 이후에 출력 파일에 추가된 줄을 runs/<실행 ID>/ 로 복사하고 매니페스트 기록 (보낸 알림이 없어도 매니페스트는 남김)
+// 출력 파일은 옮기거나 지우지 않으므로 클라이언트가 계속 이어서 기록
+func (m *Manager) Collect(manifest Manifest, mark Mark) (*Manifest, error) {
+	if manifest.RunID == "" || filepath.Base(manifest.RunID) != manifest.RunID {
+		return nil, errors.Errorf("실행 ID가 올바르지 않습니다: %q", manifest.RunID)
+	}
+
+	dir := filepath.Join(m.dir, runsDir, manifest.RunID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "실행별 출력 디렉토리 생성 실패")
+	}
+
+	manifest.Dir = dir
+	if manifest.EndTime.IsZero() {
+		manifest.EndTime = m.now()
+	}
+
+	manifest.Files = make([]File, 0, 2)
+	for _, name := range []string{EmailFile, SMSFile} {
+		file, copied, err := m.copySince(name, mark[name], dir)
+		if err != nil {
+			return nil, err
+		}
+		if !copied {
+			continue
+		}
+		file.Name = name
+		file.Channel = channelOf(name).String()
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "매니페스트 생성 실패")
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644); err != nil {
+		return nil, errors.Wrap(err, "매니페스트 기록 실패")
+	}
+	return &manifest, nil
+}
+
+// 이전 프로세스가 남긴 출력 파일을 archive/<시각>/ 으로 옮기고 매니페스트 기록 (남은 내용이 없으면 nil)
+// 클라이언트가 열어 둔 파일을 옮기면 옮긴 파일에 계속 기록하므로 전송 클라이언트를 만들기 전에 프로세스마다 한 번만 호출
+func (m *Manager) Archive() (*Manifest, error) {
+	var names []string
+	for _, name := range []string{EmailFile, SMSFile} {
+		info, err := os.Stat(filepath.Join(m.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "출력 파일 확인 실패")
+		}
+		if info.Size() > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	dir, err := m.uniqueDir(filepath.Join(m.dir, archiveDir), m.now().Format("20060102-150405"))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{EndTime: m.now(), Dir: dir, Files: make([]File, 0, len(names))}
+	for _, name := range names {
+		target := filepath.Join(dir, name)
+		if err := os.Rename(filepath.Join(m.dir, name), target); err != nil {
+			return nil, errors.Wrapf(err, "%s 이동 실패", name)
+		}
+		file, err := describe(target)
+		if err != nil {
+			return nil, err
+		}
+		file.Name = name
+		file.Channel = channelOf(name).String()
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "매니페스트 생성 실패")
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644); err != nil {
+		return nil, errors.Wrap(err, "매니페스트 기록 실패")
+	}
+	return manifest, nil
+}
+
+// 같은 초에 여러 번 보관해도 덮어쓰지 않도록 이름 뒤에 번호를 붙임
+func (m *Manager) uniqueDir(parent, name string) (string, error) {
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", errors.Wrap(err, "보관 디렉토리 생성 실패")
+	}
+
+	dir := filepath.Join(parent, name)
+	for i := 2; ; i++ {
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", errors.Wrap(err, "보관 디렉토리 생성 실패")
+		}
+		dir = filepath.Join(parent, name+"-"+strconv.Itoa(i))
+	}
+}
+
+// 가장 최근 실행의 매니페스트 (실행 ID가 KST 시각으로 시작하므로 이름순 마지막)
+func (m *Manager) Latest() (*Manifest, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, runsDir))
+	if os.IsNotExist(err) {
+		return nil, ErrNoRuns
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "실행별 출력 디렉토리를 읽을 수 없습니다")
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, ErrNoRuns
+	}
+
+	sort.Strings(names)
+	return LoadManifest(filepath.Join(m.dir, runsDir, names[len(names)-1]))
+}
+
+// 실행 ID 또는 디렉토리 경로로 매니페스트 조회
+func (m *Manager) Find(runIDOrDir string) (*Manifest, error) {
+	if info, err := os.Stat(runIDOrDir); err == nil && info.IsDir() {
+		return LoadManifest(runIDOrDir)
+	}
+	return LoadManifest(filepath.Join(m.dir, runsDir, runIDOrDir))
+}
+
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "매니페스트를 읽을 수 없습니다")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "%s 매니페스트 파싱 실패", dir)
+	}
+	manifest.Dir = dir
+	return &manifest, nil
+}
+
+// 출력 파일의 offset부터 현재 끝까지를 dir 아래 같은 이름으로 복사 (출력 파일이 없으면 false)
+func (m *Manager) copySince(name string, offset int64, dir string) (File, bool, error) {
+	source, err := os.Open(filepath.Join(m.dir, name))
+	if os.IsNotExist(err) {
+		return File{}, false, nil
+	}
+	if err != nil {
+		return File{}, false, errors.Wrap(err, "출력 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := source.Close(); err != nil {
+			log.WithError(err).Error("failed to close output file")
+		}
+	}()
+
+	info, err := source.Stat()
+	if err != nil {
+		return File{}, false, errors.Wrap(err, "출력 파일 확인 실패")
+	}
+	// 실행 중에 출력 파일이 줄었으면(다른 곳에서 비움) 처음부터 복사
+	if offset > info.Size() {
+		log.WithField("file", name).Warn("출력 파일이 실행 중에 줄어 처음부터 복사합니다")
+		offset = 0
+	}
+	if _, err := source.Seek(offset, io.SeekStart); err != nil {
+		return File{}, false, errors.Wrap(err, "출력 파일 읽기 오류")
+	}
+
+	target := filepath.Join(dir, name)
+	if err := writeCopy(target, io.LimitReader(source, info.Size()-offset)); err != nil {
+		return File{}, false, errors.Wrapf(err, "%s 복사 실패", name)
+	}
+
+	file, err := describe(target)
+	if err != nil {
+		return File{}, false, err
+	}
+	file.Offset = offset
+	return file, true, nil
+}
+
+func writeCopy(path string, r io.Reader) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close run output file")
+		}
+	}()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func describe(path string) (File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return File{}, errors.Wrap(err, "출력 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close output file")
+		}
+	}()
+
+	hash := sha256.New()
+	counter := &lineCounter{}
+	size, err := io.Copy(io.MultiWriter(hash, counter), bufio.NewReader(file))
+	if err != nil {
+		return File{}, errors.Wrap(err, "출력 파일 읽기 오류")
+	}
+
+	return File{
+		Lines:  counter.lines,
+		Bytes:  size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func channelOf(name string) domain.NotificationChannel {
+	if name == SMSFile {
+		return domain.SMSChannel
+	}
+	return domain.EmailChannel
+}
+
+type lineCounter struct {
+	lines int
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	c.lines += bytes.Count(p, []byte{'\n'})
+	return len(p), nil
+}
@@ -0,0 +1,187 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+func TestManager_Archive(t *testing.T) {
+	// Given: 이전 프로세스가 남긴 출력 파일 (SMS 파일은 비어 있음)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, EmailFile), "a@example.com\nb@example.com\n")
+	writeFile(t, filepath.Join(dir, SMSFile), "")
+	manager := newTestManager(dir, time.Date(2025, 7, 1, 9, 0, 0, 0, domain.KST))
+
+	// When: 두 번 보관
+	archived, err := manager.Archive()
+	require.NoError(t, err)
+	again, err := manager.Archive()
+	require.NoError(t, err)
+
+	// Then: 내용이 있는 파일만 시각 이름의 보관 디렉토리로 옮기고 남은 내용이 없으면 보관하지 않음
+	require.NotNil(t, archived)
+	assert.Nil(t, again)
+	assert.Equal(t, filepath.Join(dir, "archive", "20250701-090000"), archived.Dir)
+	assert.NoFileExists(t, filepath.Join(dir, EmailFile))
+	require.Len(t, archived.Files, 1)
+	assert.Equal(t, File{
+		Name:    EmailFile,
+		Channel: "email",
+		Lines:   2,
+		Bytes:   28,
+		SHA256:  archived.Files[0].SHA256,
+	}, archived.Files[0])
+
+	loaded, err := LoadManifest(archived.Dir)
+	require.NoError(t, err)
+	assert.Equal(t, archived.Files, loaded.Files)
+
+	// When: 같은 시각에 다시 남은 출력 파일을 보관
+	writeFile(t, filepath.Join(dir, SMSFile), "010-0000-0001\n")
+	second, err := manager.Archive()
+
+	// Then: 덮어쓰지 않고 번호를 붙임
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "archive", "20250701-090000-2"), second.Dir)
+}
+
+func TestManager_Collect(t *testing.T) {
+	// Given: 이전 실행이 남긴 줄이 있는 출력 파일에 이번 실행이 이어서 기록
+	dir := t.TempDir()
+	emailPath := filepath.Join(dir, EmailFile)
+	smsPath := filepath.Join(dir, SMSFile)
+	writeFile(t, emailPath, "old@example.com\n")
+	manager := newTestManager(dir, time.Date(2025, 7, 1, 9, 0, 0, 0, domain.KST))
+	startTime := time.Date(2025, 7, 1, 8, 59, 0, 0, domain.KST)
+
+	mark, err := manager.Mark()
+	require.NoError(t, err)
+	appendFile(t, emailPath, "a@example.com\n")
+	appendFile(t, smsPath, "010-0000-0001\n010-0000-0002\n")
+
+	// When: 실행 ID로 정리
+	manifest, err := manager.Collect(Manifest{RunID: "20250701-085900-abcdef", Source: "data.txt", StartTime: &startTime}, mark)
+	require.NoError(t, err)
+
+	// Then: 이번 실행의 줄만 실행별 디렉토리로 복사하고 출력 파일은 그대로 둠
+	assert.Equal(t, filepath.Join(dir, "runs", "20250701-085900-abcdef"), manifest.Dir)
+	assert.Equal(t, filepath.Join(manifest.Dir, SMSFile), manifest.Path(domain.SMSChannel))
+	require.Len(t, manifest.Files, 2)
+	assert.Equal(t, File{
+		Name:    EmailFile,
+		Channel: "email",
+		Offset:  16,
+		Lines:   1,
+		Bytes:   14,
+		SHA256:  manifest.Files[0].SHA256,
+	}, manifest.Files[0])
+	assert.Len(t, manifest.Files[0].SHA256, 64)
+	assert.Equal(t, 2, manifest.Files[1].Lines)
+
+	copied, err := os.ReadFile(manifest.Path(domain.EmailChannel))
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com\n", string(copied))
+	original, err := os.ReadFile(emailPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com\na@example.com\n", string(original))
+
+	latest, err := manager.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, "data.txt", latest.Source)
+	assert.Equal(t, manifest.Dir, latest.Dir)
+
+	found, err := manager.Find("20250701-085900-abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, found.Files)
+}
+
+func TestManager_Collect_Truncated(t *testing.T) {
+	// Given: 실행 중에 다른 곳에서 비운 출력 파일
+	dir := t.TempDir()
+	path := filepath.Join(dir, SMSFile)
+	writeFile(t, path, "010-0000-0001\n010-0000-0002\n")
+	manager := NewManager(dir)
+	mark, err := manager.Mark()
+	require.NoError(t, err)
+	writeFile(t, path, "010-0000-0003\n")
+
+	// When: 정리
+	manifest, err := manager.Collect(Manifest{RunID: "20250701-090000-000001"}, mark)
+
+	// Then: 처음부터 복사
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, int64(0), manifest.Files[0].Offset)
+	assert.Equal(t, 1, manifest.Files[0].Lines)
+}
+
+func TestManager_Collect_NoOutput(t *testing.T) {
+	// Given: 출력 파일이 없는 디렉토리
+	dir := t.TempDir()
+	manager := NewManager(dir)
+	mark, err := manager.Mark()
+	require.NoError(t, err)
+
+	// When: 정리
+	manifest, err := manager.Collect(Manifest{RunID: "20250701-090000-000000"}, mark)
+
+	// Then: 빈 매니페스트만 기록
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Files)
+	assert.Equal(t, "", manifest.Path(domain.EmailChannel))
+	assert.FileExists(t, filepath.Join(manifest.Dir, ManifestFile))
+
+	// When & Then: 잘못된 실행 ID
+	_, err = manager.Collect(Manifest{RunID: "../escape"}, mark)
+	assert.Error(t, err)
+}
+
+func TestRunConfig_Diff(t *testing.T) {
+	// Given: 실행의 설정
+	run := RunConfig{RulesHash: "aaa", SuppressionHash: "bbb", DedupKey: "channel", MergePolicy: "first-wins", SMSWindow: "08:00-21:00"}
+
+	// When & Then: 같은 설정
+	assert.Empty(t, run.Diff(run))
+
+	// When & Then: 규칙 파일, 병합 정책, 허용 시간대가 다른 설정
+	current := run
+	current.RulesHash = "ccc"
+	current.MergePolicy = "last-wins"
+	current.SMSWindow = ""
+	assert.Equal(t, []string{"rules", "merge-policy", "sms-window"}, run.Diff(current))
+}
+
+func TestManager_Latest_NoRuns(t *testing.T) {
+	// Given & When: 실행별 출력이 없는 디렉토리
+	_, err := NewManager(t.TempDir()).Latest()
+
+	// Then
+	assert.ErrorIs(t, err, ErrNoRuns)
+}
+
+func newTestManager(dir string, now time.Time) *Manager {
+	manager := NewManager(dir)
+	manager.now = func() time.Time { return now }
+	return manager
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZip  Compression = "zip"
+)
+
+// 확장자로 압축 형식 판단
+func DetectCompression(filePath string) Compression {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".gz", ".gzip":
+		return CompressionGzip
+	case ".zip":
+		return CompressionZip
+	default:
+		return CompressionNone
+	}
+}
+
+// gzip 확장자를 제거한 원본 파일 이름 (data.csv.gz -> data.csv)
+func trimCompressionExt(filePath string) string {
+	if DetectCompression(filePath) != CompressionGzip {
+		return filePath
+	}
+	return strings.TrimSuffix(filePath, filepath.Ext(filePath))
+}
+
+// gzip 파일을 디스크에 풀지 않고 스트림으로 파싱
+func (fp *FileParser) parseGzip(ctx context.Context, file *os.File, emit func(*domain.User) error) error {
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrap(err, "gzip 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := gzipReader.Close(); err != nil {
+			log.WithError(err).Error("failed to close gzip reader")
+		}
+	}()
+
+	name := trimCompressionExt(fp.filePath)
+	parser, err := fp.parserFor(name)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(parser.ParseStream(ctx, gzipReader, emit), filepath.Base(name))
+}
+
+// zip 파일의 항목을 저장된 순서대로 하나씩 스트림으로 파싱 (항목마다 확장자로 형식 판단)
+func (fp *FileParser) parseZip(ctx context.Context, file *os.File, emit func(*domain.User) error) error {
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "파일 정보를 읽을 수 없습니다")
+	}
+
+	zipReader, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return errors.Wrap(err, "zip 파일을 열 수 없습니다")
+	}
+
+	for _, member := range zipReader.File {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !isDataMember(member) {
+			continue
+		}
+
+		if err := fp.parseZipMember(fp.collectRejects(ctx, member.Name), member, emit); err != nil {
+			return errors.Wrap(err, member.Name)
+		}
+	}
+
+	return nil
+}
+
+func (fp *FileParser) parseZipMember(ctx context.Context, member *zip.File, emit func(*domain.User) error) error {
+	parser, err := fp.parserFor(member.Name)
+	if err != nil {
+		return err
+	}
+
+	reader, err := member.Open()
+	if err != nil {
+		return errors.Wrap(err, "zip 항목을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.WithError(err).Error("failed to close zip member")
+		}
+	}()
+
+	return parser.ParseStream(ctx, reader, emit)
+}
+
+// 디렉토리, 숨김 파일, macOS 메타데이터(__MACOSX) 항목 제외
+func isDataMember(member *zip.File) bool {
+	if member.FileInfo().IsDir() {
+		return false
+	}
+
+	for _, part := range strings.Split(path.Clean(member.Name), "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return false
+		}
+	}
+	return true
+}
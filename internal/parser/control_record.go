@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrIntegrity = errors.New("파일 무결성 검증 실패")
+
+// 헤더/트레일러 레코드 필드
+const (
+	FieldBatchDate   Field = "batch_date"
+	FieldRecordCount Field = "record_count"
+	FieldChecksum    Field = "checksum"
+)
+
+// 헤더/트레일러 체크섬 알고리즘
+const (
+	ChecksumCRC32  = "crc32"
+	ChecksumSHA256 = "sha256"
+)
+
+// 헤더/트레일러 레코드 (Marker로 시작하는 라인, 컬럼 위치는 데이터 레코드와 같은 바이트 단위)
+type ControlRecord struct {
+	Marker  string        `json:"marker"`
+	Columns []FixedColumn `json:"columns"`
+}
+
+func (cr *ControlRecord) matches(line []byte) bool {
+	return bytes.HasPrefix(line, []byte(cr.Marker))
+}
+
+func (cr *ControlRecord) value(line []byte, field Field) (string, bool) {
+	for _, column := range cr.Columns {
+		if column.Field != field {
+			continue
+		}
+		if column.Start+column.Width > len(line) {
+			return "", true
+		}
+		return strings.TrimSpace(string(line[column.Start : column.Start+column.Width])), true
+	}
+	return "", false
+}
+
+func (cr *ControlRecord) validate(name string, allowed ...Field) error {
+	if cr.Marker == "" {
+		return errors.Errorf("%s 레코드 구분자(marker)가 없습니다", name)
+	}
+
+	for _, column := range cr.Columns {
+		if column.Start < 0 || column.Width <= 0 {
+			return errors.Errorf("%s 컬럼 위치 오류: %s (start %d, width %d)", name, column.Field, column.Start, column.Width)
+		}
+		if !containsField(allowed, column.Field) {
+			return errors.Errorf("%s 레코드에 사용할 수 없는 컬럼: %s", name, column.Field)
+		}
+	}
+	return nil
+}
+
+func containsField(fields []Field, field Field) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// 파일 하나의 헤더/트레일러 검증 상태 (데이터 레코드 수와 체크섬을 누적)
+type controlValidator struct {
+	layout      *FixedWidthLayout
+	checksum    hash.Hash
+	headerSeen  bool
+	trailerSeen bool
+	records     int
+}
+
+func newControlValidator(layout *FixedWidthLayout) *controlValidator {
+	cv := &controlValidator{layout: layout}
+	if layout.Trailer != nil {
+		if layout.Checksum == ChecksumSHA256 {
+			cv.checksum = sha256.New()
+		} else {
+			cv.checksum = crc32.NewIEEE()
+		}
+	}
+	return cv
+}
+
+// 라인이 헤더/트레일러이면 검증 후 true, 데이터 레코드이면 집계 후 false
+func (cv *controlValidator) control(line []byte, lineNumber int) (bool, error) {
+	if cv.trailerSeen {
+		return false, errors.Wrapf(ErrIntegrity, "%d번째 라인: 트레일러 레코드 뒤에 데이터가 있습니다", lineNumber)
+	}
+
+	header := cv.layout.Header
+	if header != nil && !cv.headerSeen {
+		if !header.matches(line) {
+			return false, errors.Wrapf(ErrIntegrity, "%d번째 라인: 헤더 레코드가 없습니다", lineNumber)
+		}
+		cv.headerSeen = true
+		return true, cv.checkHeader(line)
+	}
+
+	trailer := cv.layout.Trailer
+	if trailer != nil && trailer.matches(line) {
+		cv.trailerSeen = true
+		return true, cv.checkTrailer(line)
+	}
+
+	cv.records++
+	if cv.checksum != nil {
+		cv.checksum.Write(line)
+		cv.checksum.Write([]byte{'\n'})
+	}
+	return false, nil
+}
+
+func (cv *controlValidator) checkHeader(line []byte) error {
+	batchDate, exists := cv.layout.Header.value(line, FieldBatchDate)
+	if !exists {
+		return nil
+	}
+
+	if _, err := time.Parse("20060102", batchDate); err != nil {
+		return errors.Wrapf(ErrIntegrity, "헤더 기준일자 형식 오류: %s", batchDate)
+	}
+	log.WithField("batch_date", batchDate).Debug("header record")
+	return nil
+}
+
+func (cv *controlValidator) checkTrailer(line []byte) error {
+	countText, exists := cv.layout.Trailer.value(line, FieldRecordCount)
+	if exists {
+		count, err := strconv.Atoi(countText)
+		if err != nil {
+			return errors.Wrapf(ErrIntegrity, "트레일러 레코드 수 형식 오류: %s", countText)
+		}
+		if count != cv.records {
+			return errors.Wrapf(ErrIntegrity, "레코드 수 불일치 (트레일러 %d, 실제 %d)", count, cv.records)
+		}
+	}
+
+	expected, exists := cv.layout.Trailer.value(line, FieldChecksum)
+	if exists {
+		actual := cv.sum()
+		if !strings.EqualFold(expected, actual) {
+			return errors.Wrapf(ErrIntegrity, "체크섬 불일치 (트레일러 %s, 실제 %s)", expected, actual)
+		}
+	}
+	return nil
+}
+
+func (cv *controlValidator) sum() string {
+	if crc, ok := cv.checksum.(hash.Hash32); ok {
+		return fmt.Sprintf("%08x", crc.Sum32())
+	}
+	return hex.EncodeToString(cv.checksum.Sum(nil))
+}
+
+// 파일 끝에서 선언된 헤더/트레일러가 모두 있었는지 확인 (트레일러가 없으면 잘린 파일)
+func (cv *controlValidator) finish() error {
+	if cv.layout.Header != nil && !cv.headerSeen {
+		return errors.Wrap(ErrIntegrity, "헤더 레코드가 없습니다")
+	}
+	if cv.layout.Trailer != nil && !cv.trailerSeen {
+		return errors.Wrap(ErrIntegrity, "트레일러 레코드가 없습니다 (파일이 잘렸을 수 있음)")
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package parser
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 사용자 필드 이름 (컬럼 설정, JSONL 키에 공통 사용)
+type Field string
+
+const (
+	FieldEmail         Field = "email"
+	FieldPhoneNumber   Field = "phone_number"
+	FieldCreditUp      Field = "credit_up"
+	FieldPreviousScore Field = "previous_score"
+	FieldCurrentScore  Field = "current_score"
+	FieldBureau        Field = "bureau"
+)
+
+var defaultColumns = []Field{
+	FieldEmail,
+	FieldPhoneNumber,
+	FieldCreditUp,
+	FieldPreviousScore,
+	FieldCurrentScore,
+	FieldBureau,
+}
+
+// CSV/TSV 컬럼 구성
+type ColumnConfig struct {
+	Header  bool             `json:"header"`  // 첫 행이 헤더인지 여부
+	Mapping map[Field]string `json:"mapping"` // 필드별 헤더 이름 (없으면 필드 이름과 같은 헤더 사용)
+	Columns []Field          `json:"columns"` // 헤더가 없을 때 컬럼 순서 (없으면 기본 순서)
+}
+
+// 헤더 행이 있고 헤더 이름이 필드 이름과 같은 구성
+func DefaultColumnConfig() *ColumnConfig {
+	return &ColumnConfig{
+		Header: true,
+	}
+}
+
+func LoadColumnConfig(path string) (*ColumnConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "컬럼 설정 파일을 읽을 수 없습니다")
+	}
+
+	var cfg ColumnConfig
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, errors.Wrap(err, "컬럼 설정 파일 형식 오류")
+	}
+
+	return &cfg, nil
+}
+
+func (cc *ColumnConfig) headerName(field Field) string {
+	if name, exists := cc.Mapping[field]; exists {
+		return name
+	}
+	return string(field)
+}
+
+// 쉼표(CSV) 또는 탭(TSV)으로 구분된 입력
+type DelimitedParser struct {
+	delimiter    rune
+	columns      *ColumnConfig
+	maxLineBytes int
+}
+
+func NewDelimitedParser(delimiter rune, columns *ColumnConfig) *DelimitedParser {
+	if columns == nil {
+		columns = DefaultColumnConfig()
+	}
+
+	return &DelimitedParser{
+		delimiter:    delimiter,
+		columns:      columns,
+		maxLineBytes: DefaultMaxLineBytes,
+	}
+}
+
+func (dp *DelimitedParser) ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	return collectUsers(ctx, reader, dp)
+}
+
+func (dp *DelimitedParser) ParseStream(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = dp.delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	indexes, err := dp.columnIndexes(csvReader)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		offset := csvReader.InputOffset()
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "파일 읽기 오류")
+		}
+
+		lineNumber, _ := csvReader.FieldPos(0)
+
+		// 따옴표 안의 줄바꿈을 포함한 레코드 하나의 길이
+		if size := csvReader.InputOffset() - offset; size > int64(dp.maxLineBytes) {
+			reportReject(ctx, Reject{
+				Line:   lineNumber,
+				Offset: offset,
+				Size:   size,
+				Reason: fmt.Sprintf("라인 길이 초과 (최대 %d바이트)", dp.maxLineBytes),
+			})
+			continue
+		}
+
+		user, err := newUserFromFields(func(field Field) string {
+			index, exists := indexes[field]
+			if !exists || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		})
+		if err != nil {
+			return &LineError{Line: lineNumber, Err: err}
+		}
+
+		if err := emit(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 필드별 컬럼 위치 (헤더가 있으면 헤더 행에서 찾음)
+func (dp *DelimitedParser) columnIndexes(csvReader *csv.Reader) (map[Field]int, error) {
+	indexes := make(map[Field]int)
+
+	if !dp.columns.Header {
+		columns := dp.columns.Columns
+		if len(columns) == 0 {
+			columns = defaultColumns
+		}
+		for i, field := range columns {
+			indexes[field] = i
+		}
+		return indexes, nil
+	}
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("헤더 행이 없습니다")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "헤더 행 읽기 오류")
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		// 엑셀 등에서 저장한 UTF-8 BOM 제거
+		name = strings.TrimPrefix(name, "\ufeff")
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, field := range defaultColumns {
+		if index, exists := positions[strings.ToLower(dp.columns.headerName(field))]; exists {
+			indexes[field] = index
+		}
+	}
+
+	for _, field := range []Field{FieldEmail, FieldPhoneNumber} {
+		if _, exists := indexes[field]; !exists {
+			return nil, errors.Errorf("필수 컬럼이 없습니다: %s", dp.columns.headerName(field))
+		}
+	}
+
+	return indexes, nil
+}
+
+// 필드 값으로 사용자 생성 (credit_up이 비어 있으면 점수 변화로 판단)
+func newUserFromFields(value func(Field) string) (*domain.User, error) {
+	previousText := value(FieldPreviousScore)
+	currentText := value(FieldCurrentScore)
+	if (previousText == "") != (currentText == "") {
+		return nil, errors.New("점수 필드가 부족합니다: 이전 점수와 현재 점수 모두 필요")
+	}
+
+	user, err := domain.NewUser(value(FieldEmail), value(FieldPhoneNumber), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "사용자 객체 생성 실패")
+	}
+
+	if previousText != "" {
+		if err := setScore(user, previousText, currentText); err != nil {
+			return nil, err
+		}
+	}
+	user.Bureau = value(FieldBureau)
+
+	creditUpText := value(FieldCreditUp)
+	if creditUpText == "" {
+		if !user.HasScore() {
+			return nil, errors.New("credit_up 또는 점수 필드가 필요합니다")
+		}
+		user.CreditUp = user.ScoreDelta() > 0
+		return user, nil
+	}
+
+	creditUp, err := parseCreditUp(creditUpText)
+	if err != nil {
+		return nil, err
+	}
+	user.CreditUp = creditUp
+
+	return user, nil
+}
+
+func parseCreditUp(text string) (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(text)) {
+	case "Y", "TRUE", "1":
+		return true, nil
+	case "N", "FALSE", "0":
+		return false, nil
+	default:
+		return false, errors.Errorf("credit_up 형식 오류: %s (Y/N)", text)
+	}
+}
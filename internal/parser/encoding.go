@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/transform"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+type Encoding string
+
+const (
+	EncodingAuto  Encoding = "auto"
+	EncodingUTF8  Encoding = "utf-8"
+	EncodingEUCKR Encoding = "euc-kr" // CP949(확장 완성형) 포함
+)
+
+// 인코딩 자동 판단에 사용하는 파일 앞부분 크기
+const detectSampleSize = 64 * 1024
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+func ParseEncoding(name string) (Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return EncodingAuto, nil
+	case "utf-8", "utf8":
+		return EncodingUTF8, nil
+	case "euc-kr", "euckr", "cp949", "ms949", "uhc", "windows-949":
+		return EncodingEUCKR, nil
+	default:
+		return "", errors.Errorf("지원하지 않는 인코딩: %s (auto, utf-8, euc-kr, cp949)", name)
+	}
+}
+
+// 올바른 UTF-8이면 UTF-8, 아니면 EUC-KR(CP949)로 판단
+func DetectEncoding(sample []byte) Encoding {
+	sample = bytes.TrimPrefix(sample, utf8BOM)
+
+	// 잘린 샘플의 마지막 문자가 불완전할 수 있으므로 최대 3바이트까지 줄여서 확인
+	for end := len(sample); end >= 0 && end > len(sample)-utf8.UTFMax; end-- {
+		if utf8.Valid(sample[:end]) {
+			return EncodingUTF8
+		}
+	}
+	return EncodingEUCKR
+}
+
+// 바이너리 입력을 거르고 인코딩을 확정한 뒤 UTF-8 BOM을 건너뛴 원본 바이트 reader 반환
+func prepareReader(reader io.Reader, encoding Encoding) (*bufio.Reader, Encoding, error) {
+	bufferedReader := bufio.NewReaderSize(reader, detectSampleSize)
+
+	sample, err := bufferedReader.Peek(detectSampleSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, "", errors.Wrap(err, "파일 읽기 오류")
+	}
+
+	// 손상된 파일을 끝까지 읽지 않도록 앞부분에서 미리 확인
+	if looksBinary(sample) {
+		return nil, "", ErrBinaryInput
+	}
+
+	if encoding == "" || encoding == EncodingAuto {
+		encoding = DetectEncoding(sample)
+	}
+
+	if encoding == EncodingUTF8 {
+		if prefix, _ := bufferedReader.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+			if _, err := bufferedReader.Discard(len(utf8BOM)); err != nil {
+				return nil, "", errors.Wrap(err, "파일 읽기 오류")
+			}
+		}
+	}
+
+	return bufferedReader, encoding, nil
+}
+
+// 원본 인코딩의 입력을 UTF-8로 변환하는 reader
+func decodeReader(reader io.Reader, encoding Encoding) (io.Reader, error) {
+	bufferedReader, encoding, err := prepareReader(reader, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	if encoding == EncodingEUCKR {
+		return transform.NewReader(bufferedReader, korean.EUCKR.NewDecoder()), nil
+	}
+	return bufferedReader, nil
+}
+
+// 원본 인코딩의 바이트 조각을 UTF-8 문자열로 변환 (멀티바이트 문자가 잘렸으면 에러)
+func decodeBytes(content []byte, encoding Encoding) (string, error) {
+	if encoding != EncodingEUCKR {
+		if !utf8.Valid(content) {
+			return "", errors.New("올바르지 않은 UTF-8 문자 (컬럼 경계가 문자를 나누었을 수 있음)")
+		}
+		return string(content), nil
+	}
+
+	decoded, err := korean.EUCKR.NewDecoder().Bytes(content)
+	if err != nil {
+		return "", errors.Wrap(err, "EUC-KR 변환 실패")
+	}
+	if bytes.ContainsRune(decoded, utf8.RuneError) {
+		return "", errors.New("올바르지 않은 EUC-KR 문자 (컬럼 경계가 문자를 나누었을 수 있음)")
+	}
+	return string(decoded), nil
+}
+
+// 입력을 UTF-8로 변환한 뒤 형식별 파서에 전달
+type decodingParser struct {
+	encoding Encoding
+	parser   Parser
+}
+
+func (dp *decodingParser) ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	return collectUsers(ctx, reader, dp)
+}
+
+func (dp *decodingParser) ParseStream(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error {
+	decoded, err := decodeReader(reader, dp.encoding)
+	if err != nil {
+		return err
+	}
+	return dp.parser.ParseStream(ctx, decoded, emit)
+}
@@ -1,9 +1,11 @@
 package parser
 
 import (
-	"bufio"
 	"context"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -12,21 +14,171 @@ import (
 	"banksalad-backend-task/internal/domain"
 )
 
+// 입력 형식별 파서가 구현하는 인터페이스
+type Parser interface {
+	ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error)
+	// 레코드를 읽는 대로 emit에 전달 (emit이 에러를 반환하면 그 에러로 중단)
+	ParseStream(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error
+}
+
+// ParseStream으로 읽은 레코드를 모두 모아 반환
+func collectUsers(ctx context.Context, reader io.Reader, parser Parser) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, 8000)
+	err := parser.ParseStream(ctx, reader, func(user *domain.User) error {
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// 보관하는 거부 라인 수 (넘는 라인은 개수만 셈)
+const maxRejectSamples = 100
+
+type Format string
+
+const (
+	FormatFixedWidth Format = "fixed"
+	FormatCSV        Format = "csv"
+	FormatTSV        Format = "tsv"
+	FormatJSONL      Format = "jsonl"
+)
+
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case FormatFixedWidth:
+		return FormatFixedWidth, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatTSV:
+		return FormatTSV, nil
+	case FormatJSONL:
+		return FormatJSONL, nil
+	default:
+		return "", errors.Errorf("지원하지 않는 입력 형식: %s (fixed, csv, tsv, jsonl)", name)
+	}
+}
+
+// 확장자로 입력 형식 판단 (알 수 없으면 기존 공백 구분 형식, data.csv.gz는 csv)
+func DetectFormat(path string) Format {
+	switch strings.ToLower(filepath.Ext(trimCompressionExt(path))) {
+	case ".csv":
+		return FormatCSV
+	case ".tsv":
+		return FormatTSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return FormatFixedWidth
+	}
+}
+
+// 형식별 파서 선택 사항 (모두 생략 가능)
+type Options struct {
+	Encoding     Encoding          // 비어 있으면 자동 판단
+	Columns      *ColumnConfig     // CSV/TSV 컬럼 구성
+	Layout       *FixedWidthLayout // 고정 폭 컬럼 위치 (없으면 공백 구분)
+	MaxLineBytes int               // 넘는 라인은 거부 라인으로 건너뜀 (0이면 DefaultMaxLineBytes)
+}
+
+// 형식에 맞는 파서 생성, 입력은 UTF-8로 변환하여 전달
+func New(format Format, opts Options) (Parser, error) {
+	maxLineBytes := opts.MaxLineBytes
+	if maxLineBytes <= 0 {
+		maxLineBytes = DefaultMaxLineBytes
+	}
+
+	var parser Parser
+	switch format {
+	case FormatFixedWidth:
+		// 컬럼 위치는 원본 인코딩의 바이트 기준이므로 변환 전에 자름
+		if opts.Layout != nil {
+			layoutParser := NewFixedWidthLayoutParser(opts.Layout, opts.Encoding)
+			layoutParser.maxLineBytes = maxLineBytes
+			return layoutParser, nil
+		}
+		fixedParser := NewFixedWidthParser()
+		fixedParser.maxLineBytes = maxLineBytes
+		parser = fixedParser
+	case FormatCSV, FormatTSV:
+		delimiter := ','
+		if format == FormatTSV {
+			delimiter = '\t'
+		}
+		delimitedParser := NewDelimitedParser(delimiter, opts.Columns)
+		delimitedParser.maxLineBytes = maxLineBytes
+		parser = delimitedParser
+	case FormatJSONL:
+		jsonlParser := NewJSONLParser()
+		jsonlParser.maxLineBytes = maxLineBytes
+		parser = jsonlParser
+	default:
+		return nil, errors.Errorf("지원하지 않는 입력 형식: %s", format)
+	}
+
+	return &decodingParser{
+		encoding: opts.Encoding,
+		parser:   parser,
+	}, nil
+}
+
+// 파일 경로와 형식별 파서를 묶어 파일 단위로 파싱 (.gz, .zip 압축 파일 포함)
 type FileParser struct {
-	filePath string
+	filePath      string
+	parser        Parser
+	newParser     func(name string) (Parser, error)
+	rejects       []Reject
+	rejectCount   int
+	workers       int
+	minChunkBytes int64
 }
 
+// 확장자로 형식을 자동 판단
 func NewFileParser(filePath string) *FileParser {
+	return NewFileParserWithFactory(filePath, func(name string) (Parser, error) {
+		return New(DetectFormat(name), Options{})
+	})
+}
+
+// 형식을 직접 지정하는 생성자
+func NewFileParserWithParser(filePath string, parser Parser) *FileParser {
 	return &FileParser{
 		filePath: filePath,
+		parser:   parser,
+	}
+}
+
+// 파일(압축 파일이면 압축을 푼 항목) 이름으로 파서를 만드는 생성자
+func NewFileParserWithFactory(filePath string, newParser func(name string) (Parser, error)) *FileParser {
+	return &FileParser{
+		filePath:  filePath,
+		newParser: newParser,
 	}
 }
 
 func (fp *FileParser) ParseUsers(ctx context.Context) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, 8000)
+	err := fp.ParseStream(ctx, func(user *domain.User) error {
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// 파일 전체를 메모리에 올리지 않고 레코드를 읽는 대로 파일 순서대로 emit에 전달
+func (fp *FileParser) ParseStream(ctx context.Context, emit func(*domain.User) error) error {
+	fp.rejects = nil
+	fp.rejectCount = 0
+
 	// Given: 파일 열기
 	file, err := os.Open(fp.filePath)
 	if err != nil {
-		return nil, errors.Wrap(err, "파일을 열 수 없습니다")
+		return errors.Wrap(err, "파일을 열 수 없습니다")
 	}
 
 	defer func() {
@@ -35,60 +187,97 @@ func (fp *FileParser) ParseUsers(ctx context.Context) ([]*domain.User, error) {
 		}
 	}()
 
-	users := make([]*domain.User, 0, 8000)
-	scanner := bufio.NewScanner(file)
-	lineNumber := 0
-
-	// When: 라인별로 파싱 실행
-	for scanner.Scan() {
-		// 컨텍스트 취소 확인
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
+	ctx = fp.collectRejects(ctx, "")
 
-		lineNumber++
-		line := scanner.Text()
+	switch DetectCompression(fp.filePath) {
+	case CompressionGzip:
+		return fp.parseGzip(ctx, file, emit)
+	case CompressionZip:
+		return fp.parseZip(ctx, file, emit)
+	}
 
-		// 빈 라인 스킵
-		if len(strings.TrimSpace(line)) == 0 {
-			continue
-		}
+	parser, err := fp.parserFor(fp.filePath)
+	if err != nil {
+		return err
+	}
 
-		user, err := fp.parseLine(line)
+	if fp.workers > 1 {
+		info, err := file.Stat()
 		if err != nil {
-			return nil, errors.Wrapf(err, "%d번째 라인 파싱 오류", lineNumber)
+			return errors.Wrap(err, "파일 정보를 읽을 수 없습니다")
 		}
 
-		users = append(users, user)
+		if info.Size() >= 2*fp.chunkSize(info.Size()) {
+			parsed, err := fp.parseParallel(ctx, file, info.Size(), parser, emit)
+			if parsed || err != nil {
+				return err
+			}
+		}
 	}
 
-	// Then: 스캔 에러 확인
-	if err := scanner.Err(); err != nil {
-		return nil, errors.Wrap(err, "파일 읽기 오류")
+	return parser.ParseStream(ctx, file, emit)
+}
+
+// 작업자 수가 2 이상이면 큰 파일을 줄바꿈 단위 구간으로 나누어 병렬 파싱
+// (압축 파일, CSV/TSV, 헤더/트레일러가 있는 고정 폭 형식은 순차 파싱)
+func (fp *FileParser) SetWorkers(workers int) {
+	fp.workers = workers
+}
+
+// 파일과 같은 형식의 데이터를 읽어 파싱 (예: API로 전달된 레코드)
+func (fp *FileParser) ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	parser, err := fp.parserFor(fp.filePath)
+	if err != nil {
+		return nil, err
 	}
+	return parser.ParseReader(ctx, reader)
+}
 
-	return users, nil
+// 마지막 파싱에서 길이 초과 등으로 건너뛴 라인 (앞에서부터 최대 100개)
+func (fp *FileParser) Rejects() []Reject {
+	return fp.rejects
+}
+
+// 마지막 파싱에서 건너뛴 전체 라인 수
+func (fp *FileParser) RejectCount() int {
+	return fp.rejectCount
 }
 
-func (fp *FileParser) parseLine(line string) (*domain.User, error) {
-	// 공백을 기준으로 필드 분리
-	fields := strings.Fields(line)
-	if len(fields) < 3 {
-		return nil, errors.New("필드가 부족합니다: 최소 3개 필요")
+func (fp *FileParser) collectRejects(ctx context.Context, source string) context.Context {
+	return withRejectHandler(ctx, func(reject Reject) {
+		fp.rejectCount++
+		if len(fp.rejects) >= maxRejectSamples {
+			return
+		}
+		reject.Source = source
+		fp.rejects = append(fp.rejects, reject)
+	})
+}
+
+func (fp *FileParser) parserFor(name string) (Parser, error) {
+	if fp.parser != nil {
+		return fp.parser, nil
 	}
+	return fp.newParser(name)
+}
 
-	email := fields[0]
-	phoneNumber := fields[1]
-	creditUpStr := fields[2]
+// 이전/현재 점수 문자열로 신용점수 설정
+func setScore(user *domain.User, previousText, currentText string) error {
+	previous, err := strconv.Atoi(strings.TrimSpace(previousText))
+	if err != nil {
+		return errors.Wrapf(err, "이전 점수 형식 오류: %s", previousText)
+	}
 
-	creditUp := creditUpStr == "Y"
+	current, err := strconv.Atoi(strings.TrimSpace(currentText))
+	if err != nil {
+		return errors.Wrapf(err, "현재 점수 형식 오류: %s", currentText)
+	}
 
-	user, err := domain.NewUser(email, phoneNumber, creditUp)
+	score, err := domain.NewCreditScore(previous, current)
 	if err != nil {
-		return nil, errors.Wrap(err, "사용자 객체 생성 실패")
+		return errors.Wrap(err, "신용점수 생성 실패")
 	}
+	user.Score = score
 
-	return user, nil
+	return nil
 }
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 고정 폭 컬럼 하나 (위치와 폭은 원본 인코딩 기준 바이트 단위)
+type FixedColumn struct {
+	Field Field `json:"field"`
+	Start int   `json:"start"`
+	Width int   `json:"width"`
+}
+
+// 컬럼 위치로 자르는 고정 폭 형식 (EUC-KR 파일은 한글 한 글자가 2바이트)
+type FixedWidthLayout struct {
+	Columns  []FixedColumn  `json:"columns"`
+	Header   *ControlRecord `json:"header,omitempty"`   // 첫 라인 (기준일자)
+	Trailer  *ControlRecord `json:"trailer,omitempty"`  // 마지막 라인 (레코드 수, 체크섬)
+	Checksum string         `json:"checksum,omitempty"` // 트레일러 체크섬 알고리즘 (crc32(기본), sha256)
+}
+
+func LoadFixedWidthLayout(path string) (*FixedWidthLayout, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "고정 폭 레이아웃 파일을 읽을 수 없습니다")
+	}
+
+	var layout FixedWidthLayout
+	if err := json.Unmarshal(content, &layout); err != nil {
+		return nil, errors.Wrap(err, "고정 폭 레이아웃 파일 형식 오류")
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+func (l *FixedWidthLayout) Validate() error {
+	fields := make(map[Field]struct{}, len(l.Columns))
+	for _, column := range l.Columns {
+		if column.Start < 0 || column.Width <= 0 {
+			return errors.Errorf("컬럼 위치 오류: %s (start %d, width %d)", column.Field, column.Start, column.Width)
+		}
+		fields[column.Field] = struct{}{}
+	}
+
+	for _, field := range []Field{FieldEmail, FieldPhoneNumber} {
+		if _, exists := fields[field]; !exists {
+			return errors.Errorf("필수 컬럼이 없습니다: %s", field)
+		}
+	}
+
+	if l.Header != nil {
+		if err := l.Header.validate("헤더", FieldBatchDate); err != nil {
+			return err
+		}
+	}
+	if l.Trailer != nil {
+		if err := l.Trailer.validate("트레일러", FieldRecordCount, FieldChecksum); err != nil {
+			return err
+		}
+	}
+
+	switch l.Checksum {
+	case "", ChecksumCRC32, ChecksumSHA256:
+	default:
+		return errors.Errorf("지원하지 않는 체크섬 알고리즘: %s (crc32, sha256)", l.Checksum)
+	}
+	return nil
+}
+
+// 고정 폭 입력 형식
+// 레이아웃이 없으면 공백으로 구분된 기존 형식 (이메일 전화번호 Y/N [이전점수 현재점수 [신용평가사]])
+type FixedWidthParser struct {
+	layout       *FixedWidthLayout
+	encoding     Encoding
+	maxLineBytes int
+}
+
+func NewFixedWidthParser() *FixedWidthParser {
+	return &FixedWidthParser{
+		maxLineBytes: DefaultMaxLineBytes,
+	}
+}
+
+// 컬럼 위치를 지정하는 생성자 (원본 바이트를 자른 뒤 컬럼별로 UTF-8 변환)
+func NewFixedWidthLayoutParser(layout *FixedWidthLayout, encoding Encoding) *FixedWidthParser {
+	return &FixedWidthParser{
+		layout:       layout,
+		encoding:     encoding,
+		maxLineBytes: DefaultMaxLineBytes,
+	}
+}
+
+func (fp *FixedWidthParser) ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	return collectUsers(ctx, reader, fp)
+}
+
+func (fp *FixedWidthParser) ParseStream(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error {
+	if fp.layout != nil {
+		return fp.parseColumns(ctx, reader, emit)
+	}
+
+	lines := newLineReader(reader, fp.maxLineBytes)
+
+	// When: 라인별로 파싱 실행
+	for {
+		// 컨텍스트 취소 확인
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if line.tooLong {
+			lines.reject(ctx, line)
+			continue
+		}
+
+		// 빈 라인 스킵
+		text := string(line.bytes)
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		user, err := fp.parseLine(text)
+		if err != nil {
+			return &LineError{Line: line.number, Err: err}
+		}
+
+		if err := emit(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fp *FixedWidthParser) parseLine(line string) (*domain.User, error) {
+	// 공백을 기준으로 필드 분리
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return nil, errors.New("필드가 부족합니다: 최소 3개 필요")
+	}
+
+	email := fields[0]
+	phoneNumber := fields[1]
+	creditUpStr := fields[2]
+
+	creditUp := creditUpStr == "Y"
+
+	user, err := domain.NewUser(email, phoneNumber, creditUp)
+	if err != nil {
+		return nil, errors.Wrap(err, "사용자 객체 생성 실패")
+	}
+
+	// 선택 필드: 이전 점수, 현재 점수, 신용평가사
+	if err := fp.parseScoreFields(user, fields[3:]); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (fp *FixedWidthParser) parseScoreFields(user *domain.User, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if len(fields) == 1 {
+		return errors.New("점수 필드가 부족합니다: 이전 점수와 현재 점수 모두 필요")
+	}
+
+	if err := setScore(user, fields[0], fields[1]); err != nil {
+		return err
+	}
+
+	if len(fields) > 2 {
+		user.Bureau = fields[2]
+	}
+
+	return nil
+}
+
+func (fp *FixedWidthParser) parseColumns(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error {
+	rawReader, encoding, err := prepareReader(reader, fp.encoding)
+	if err != nil {
+		return err
+	}
+
+	lines := newLineReader(rawReader, fp.maxLineBytes)
+	validator := newControlValidator(fp.layout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if line.tooLong {
+			lines.reject(ctx, line)
+			continue
+		}
+
+		if len(bytes.TrimSpace(line.bytes)) == 0 {
+			continue
+		}
+
+		// 헤더/트레일러는 사용자 레코드에서 제외
+		isControl, err := validator.control(line.bytes, line.number)
+		if err != nil {
+			return err
+		}
+		if isControl {
+			continue
+		}
+
+		user, err := fp.parseColumnLine(line.bytes, encoding)
+		if err != nil {
+			return &LineError{Line: line.number, Err: err}
+		}
+
+		if err := emit(user); err != nil {
+			return err
+		}
+	}
+
+	return validator.finish()
+}
+
+func (fp *FixedWidthParser) parseColumnLine(line []byte, encoding Encoding) (*domain.User, error) {
+	values := make(map[Field]string, len(fp.layout.Columns))
+	for _, column := range fp.layout.Columns {
+		if column.Start >= len(line) {
+			continue
+		}
+
+		end := column.Start + column.Width
+		if end > len(line) {
+			end = len(line)
+		}
+
+		value, err := decodeBytes(line[column.Start:end], encoding)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s 컬럼 (%d-%d 바이트)", column.Field, column.Start, end)
+		}
+		values[column.Field] = strings.TrimSpace(value)
+	}
+
+	return newUserFromFields(func(field Field) string {
+		return values[field]
+	})
+}
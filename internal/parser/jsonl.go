@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 한 줄에 JSON 객체 하나 (키는 Field 이름, 예: {"email": "...", "phone_number": "...", "credit_up": true})
+type JSONLParser struct {
+	maxLineBytes int
+}
+
+func NewJSONLParser() *JSONLParser {
+	return &JSONLParser{
+		maxLineBytes: DefaultMaxLineBytes,
+	}
+}
+
+func (jp *JSONLParser) ParseReader(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	return collectUsers(ctx, reader, jp)
+}
+
+func (jp *JSONLParser) ParseStream(ctx context.Context, reader io.Reader, emit func(*domain.User) error) error {
+	lines := newLineReader(reader, jp.maxLineBytes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if line.tooLong {
+			lines.reject(ctx, line)
+			continue
+		}
+
+		if len(bytes.TrimSpace(line.bytes)) == 0 {
+			continue
+		}
+
+		user, err := jp.parseLine(line.bytes)
+		if err != nil {
+			return &LineError{Line: line.number, Err: err}
+		}
+
+		if err := emit(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (jp *JSONLParser) parseLine(line []byte) (*domain.User, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, errors.Wrap(err, "JSON 형식 오류")
+	}
+
+	// 문자열, 숫자, 불리언 값을 CSV와 같은 문자열 값으로 변환
+	return newUserFromFields(func(field Field) string {
+		raw, exists := object[string(field)]
+		if !exists {
+			return ""
+		}
+
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			return strings.TrimSpace(text)
+		}
+
+		value := strings.TrimSpace(string(raw))
+		if value == "null" {
+			return ""
+		}
+		return value
+	})
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// 라인 하나의 기본 최대 길이 (bufio.Scanner 기본값 64KB보다 넉넉하게)
+const DefaultMaxLineBytes = 1024 * 1024
+
+var ErrBinaryInput = errors.New("텍스트 파일이 아닙니다 (바이너리 또는 손상된 입력)")
+
+// 파싱하지 않고 건너뛴 라인 (위치는 파서가 읽은 입력 기준 바이트 오프셋)
+type Reject struct {
+	Source string `json:"source,omitempty"` // zip 항목 이름 등
+	Line   int    `json:"line"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason"`
+}
+
+func (r Reject) String() string {
+	location := fmt.Sprintf("%d번째 라인 (오프셋 %d, %d바이트)", r.Line, r.Offset, r.Size)
+	if r.Source != "" {
+		location = r.Source + " " + location
+	}
+	return location + ": " + r.Reason
+}
+
+// 라인 번호가 있는 파싱 오류 (병렬 파싱 시 구간 기준 라인 번호를 파일 기준으로 보정)
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("%d번째 라인 파싱 오류: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+type rejectHandlerKey struct{}
+
+// FileParser가 형식별 파서의 거부 라인을 모으기 위해 컨텍스트에 등록
+func withRejectHandler(ctx context.Context, handler func(Reject)) context.Context {
+	return context.WithValue(ctx, rejectHandlerKey{}, handler)
+}
+
+func reportReject(ctx context.Context, reject Reject) {
+	if handler, ok := ctx.Value(rejectHandlerKey{}).(func(Reject)); ok {
+		handler(reject)
+		return
+	}
+	log.WithField("reject", reject.String()).Warn("line rejected")
+}
+
+// bufio.Scanner 대신 사용하는 라인 reader
+// 최대 길이를 넘는 라인은 메모리에 쌓지 않고 끝까지 건너뛴 뒤 tooLong으로 반환
+type lineReader struct {
+	reader   *bufio.Reader
+	maxBytes int
+	buffer   []byte
+	number   int
+	offset   int64
+}
+
+type rawLine struct {
+	number  int
+	offset  int64
+	size    int64
+	bytes   []byte // 줄 끝 \r\n 제외, 다음 next 호출 전까지만 유효
+	tooLong bool
+}
+
+func newLineReader(reader io.Reader, maxBytes int) *lineReader {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxLineBytes
+	}
+
+	bufferedReader, ok := reader.(*bufio.Reader)
+	if !ok {
+		bufferedReader = bufio.NewReader(reader)
+	}
+
+	return &lineReader{
+		reader:   bufferedReader,
+		maxBytes: maxBytes,
+	}
+}
+
+func (lr *lineReader) next() (*rawLine, error) {
+	line := &rawLine{number: lr.number + 1, offset: lr.offset}
+	lr.buffer = lr.buffer[:0]
+
+	for {
+		chunk, err := lr.reader.ReadSlice('\n')
+		line.size += int64(len(chunk))
+
+		if !line.tooLong {
+			lr.buffer = append(lr.buffer, chunk...)
+			if len(bytes.TrimRight(lr.buffer, "\r\n")) > lr.maxBytes {
+				line.tooLong = true
+				lr.buffer = lr.buffer[:0]
+			}
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF && line.size == 0 {
+			return nil, io.EOF
+		}
+		if err != nil && err != io.EOF {
+			return nil, errors.Wrap(err, "파일 읽기 오류")
+		}
+		break
+	}
+
+	lr.number++
+	lr.offset += line.size
+	line.bytes = bytes.TrimRight(lr.buffer, "\r\n")
+	return line, nil
+}
+
+func (lr *lineReader) reject(ctx context.Context, line *rawLine) {
+	reportReject(ctx, Reject{
+		Line:   line.number,
+		Offset: line.offset,
+		Size:   line.size,
+		Reason: fmt.Sprintf("라인 길이 초과 (최대 %d바이트)", lr.maxBytes),
+	})
+}
+
+// 파일 앞부분에 NUL 문자가 있거나 제어 문자 비율이 높으면 텍스트가 아닌 입력으로 판단
+func looksBinary(sample []byte) bool {
+	if len(sample) == 0 {
+		return false
+	}
+
+	controls := 0
+	for _, b := range sample {
+		switch {
+		case b == 0x00:
+			return true
+		case b == '\t' || b == '\n' || b == '\r' || b == '\f':
+		case b < 0x20 || b == 0x7F:
+			controls++
+		}
+	}
+	return controls*20 > len(sample)
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 병렬 파싱 구간 하나의 최소 크기 (작은 파일은 순차 파싱)
+const defaultMinChunkBytes = 4 * 1024 * 1024
+
+// 병렬 파싱 구간 하나의 최대 크기 (구간마다 파싱한 레코드를 전달할 때까지 보관)
+const maxChunkBytes = 16 * 1024 * 1024
+
+// 라인 단위로 독립적인 형식 (줄바꿈 위치로 나눈 구간을 따로 파싱 가능)
+type chunkSplitter interface {
+	// 파일 앞부분으로 인코딩을 확정한 구간용 파서, 나눌 수 없는 형식이면 nil
+	chunkParser(head []byte) Parser
+}
+
+func (dp *decodingParser) chunkParser(head []byte) Parser {
+	// CSV/TSV는 헤더 행과 따옴표 안의 줄바꿈 때문에 나눌 수 없음
+	switch dp.parser.(type) {
+	case *FixedWidthParser, *JSONLParser:
+	default:
+		return nil
+	}
+
+	return &decodingParser{
+		encoding: resolveEncoding(dp.encoding, head),
+		parser:   dp.parser,
+	}
+}
+
+func (fp *FixedWidthParser) chunkParser(head []byte) Parser {
+	// 헤더/트레일러 검증은 파일 전체의 순서가 필요
+	if fp.layout == nil || fp.layout.Header != nil || fp.layout.Trailer != nil {
+		return nil
+	}
+
+	return &FixedWidthParser{
+		layout:       fp.layout,
+		encoding:     resolveEncoding(fp.encoding, head),
+		maxLineBytes: fp.maxLineBytes,
+	}
+}
+
+func resolveEncoding(encoding Encoding, head []byte) Encoding {
+	if encoding == "" || encoding == EncodingAuto {
+		return DetectEncoding(head)
+	}
+	return encoding
+}
+
+// 파일의 한 구간 [start, end), end는 줄바꿈 다음 위치
+type chunk struct {
+	start int64
+	end   int64
+}
+
+type chunkResult struct {
+	users   []*domain.User
+	rejects []Reject
+	lines   int
+	err     error
+}
+
+// 구간별 파싱 결과를 원래 순서대로 전달하여 순차 파싱과 같은 결과 유지 (중복 제거의 "먼저 나온 사용자 우선" 유지)
+// 파싱을 마치고 전달을 기다리는 구간은 작업자 수의 두 배까지만 두어 파일 크기와 관계없이 메모리 사용량을 제한
+func (fp *FileParser) parseParallel(ctx context.Context, file *os.File, size int64, parser Parser, emit func(*domain.User) error) (bool, error) {
+	head := make([]byte, detectSampleSize)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return false, errors.Wrap(err, "파일 읽기 오류")
+	}
+
+	splitter, ok := parser.(chunkSplitter)
+	if !ok {
+		return false, nil
+	}
+	chunkParser := splitter.chunkParser(head[:n])
+	if chunkParser == nil {
+		return false, nil
+	}
+
+	chunks, err := splitChunks(file, size, fp.chunkSize(size))
+	if err != nil {
+		return false, err
+	}
+
+	// 전달을 마치거나 중간에 그만두면 새 구간은 시작하지 않음
+	workerCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	results := make([]chan chunkResult, len(chunks))
+	for i := range results {
+		results[i] = make(chan chunkResult, 1)
+	}
+	slots := make(chan struct{}, fp.workers*2)
+	indexes := make(chan int)
+
+	for i := 0; i < fp.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] <- parseChunk(workerCtx, file, chunks[index], chunkParser)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(indexes)
+		for i := range chunks {
+			select {
+			case slots <- struct{}{}:
+			case <-workerCtx.Done():
+				return
+			}
+			select {
+			case indexes <- i:
+			case <-workerCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// 구간 기준 라인 번호, 오프셋을 파일 기준으로 보정하여 전달
+	lines := 0
+	for i := range chunks {
+		var result chunkResult
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+		<-slots
+
+		for _, reject := range result.rejects {
+			reject.Line += lines
+			reject.Offset += chunks[i].start
+			reportReject(ctx, reject)
+		}
+
+		if result.err != nil {
+			var lineErr *LineError
+			if errors.As(result.err, &lineErr) {
+				lineErr.Line += lines
+			}
+			return true, result.err
+		}
+
+		for _, user := range result.users {
+			if err := emit(user); err != nil {
+				return true, err
+			}
+		}
+		lines += result.lines
+	}
+
+	return true, nil
+}
+
+func (fp *FileParser) chunkSize(size int64) int64 {
+	minChunkBytes := fp.minChunkBytes
+	if minChunkBytes <= 0 {
+		minChunkBytes = defaultMinChunkBytes
+	}
+
+	// 작업자보다 구간을 여러 개 만들어 구간별 처리 시간 차이를 줄이고, 구간 하나의 결과가 너무 커지지 않도록 제한
+	chunkSize := size / int64(fp.workers*4)
+	if chunkSize > maxChunkBytes {
+		chunkSize = maxChunkBytes
+	}
+	if chunkSize < minChunkBytes {
+		chunkSize = minChunkBytes
+	}
+	return chunkSize
+}
+
+// 구간 경계를 다음 줄바꿈 뒤로 맞춤
+func splitChunks(file *os.File, size int64, chunkSize int64) ([]chunk, error) {
+	chunks := make([]chunk, 0, size/chunkSize+1)
+	buffer := make([]byte, 64*1024)
+
+	start := int64(0)
+	for start < size {
+		end := start + chunkSize
+		for end < size {
+			n, err := file.ReadAt(buffer, end)
+			if err != nil && err != io.EOF {
+				return nil, errors.Wrap(err, "파일 읽기 오류")
+			}
+			if index := bytes.IndexByte(buffer[:n], '\n'); index >= 0 {
+				end += int64(index) + 1
+				break
+			}
+			end += int64(n)
+		}
+		if end > size {
+			end = size
+		}
+
+		chunks = append(chunks, chunk{start: start, end: end})
+		start = end
+	}
+
+	return chunks, nil
+}
+
+func parseChunk(ctx context.Context, file *os.File, c chunk, parser Parser) chunkResult {
+	var result chunkResult
+	ctx = withRejectHandler(ctx, func(reject Reject) {
+		result.rejects = append(result.rejects, reject)
+	})
+
+	reader := &lineCountingReader{reader: io.NewSectionReader(file, c.start, c.end-c.start)}
+	result.users, result.err = parser.ParseReader(ctx, reader)
+	result.lines = reader.lines
+	return result
+}
+
+// 읽은 바이트의 줄바꿈 수를 세는 reader (구간의 라인 수)
+type lineCountingReader struct {
+	reader io.Reader
+	lines  int
+}
+
+func (r *lineCountingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.lines += bytes.Count(p[:n], []byte{'\n'})
+	return n, err
+}
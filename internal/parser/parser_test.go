@@ -1,18 +1,30 @@
 package parser
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"fmt"
+	"hash/crc32"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/korean"
+
+	"banksalad-backend-task/internal/domain"
 )
 
-func TestFileParser_parseLine(t *testing.T) {
+func TestFixedWidthParser_parseLine(t *testing.T) {
 	// Given: 파서 인스턴스 생성
-	parser := NewFileParser("")
+	parser := NewFixedWidthParser()
 
 	testCases := []struct {
 		name             string
@@ -142,6 +154,25 @@ Duser468598_84@example.fake                        000-1311-1060   Y`
 	assert.Len(t, users, 3)
 }
 
+func TestFileParser_ParseReader(t *testing.T) {
+	// Given: 파일과 같은 형식의 레코드
+	records := strings.Join([]string{
+		"Duser780641_29@example.fake 000-0420-2932 Y 780 803 KCB",
+		"Duser206226_26@example.fake 000-1815-2005 N",
+	}, "\n")
+
+	parser := NewFileParser("")
+
+	// When: 파일 없이 레코드 파싱
+	users, err := parser.ParseReader(context.Background(), strings.NewReader(records))
+
+	// Then: 파일 파싱과 같은 결과
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, 23, users[0].ScoreDelta())
+	assert.False(t, users[1].CreditUp)
+}
+
 func TestFileParser_ParseUsers_WithContext(t *testing.T) {
 	// Given: 큰 테스트 파일 생성
 	testData := strings.Repeat("Duser780641_29@example.fake                000-0420-2932   Y\n", 1000)
@@ -206,3 +237,801 @@ Duser206226_26@example.fake               000-1815-2005   N`
 	assert.Error(t, err)
 	assert.Nil(t, users)
 }
+
+func TestFixedWidthParser_parseLine_WithScores(t *testing.T) {
+	// Given: 파서 인스턴스 생성
+	parser := NewFixedWidthParser()
+
+	testCases := []struct {
+		name             string
+		line             string
+		expectError      bool
+		expectedPrevious int
+		expectedCurrent  int
+		expectedBureau   string
+	}{
+		{
+			name:             "점수와 신용평가사 포함",
+			line:             "Duser780641_29@example.fake   000-0420-2932   Y   780   803   NICE",
+			expectedPrevious: 780,
+			expectedCurrent:  803,
+			expectedBureau:   "NICE",
+		},
+		{
+			name:             "신용평가사 생략",
+			line:             "Duser780641_29@example.fake   000-0420-2932   Y   780   803",
+			expectedPrevious: 780,
+			expectedCurrent:  803,
+		},
+		{
+			name:        "현재 점수 누락",
+			line:        "Duser780641_29@example.fake   000-0420-2932   Y   780",
+			expectError: true,
+		},
+		{
+			name:        "숫자가 아닌 점수",
+			line:        "Duser780641_29@example.fake   000-0420-2932   Y   abc   803",
+			expectError: true,
+		},
+		{
+			name:        "범위를 벗어난 점수",
+			line:        "Duser780641_29@example.fake   000-0420-2932   Y   780   1200",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 라인 파싱 실행
+			user, err := parser.parseLine(tc.line)
+
+			// Then: 결과 검증
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, user.HasScore())
+			assert.Equal(t, tc.expectedPrevious, user.Score.Previous)
+			assert.Equal(t, tc.expectedCurrent, user.Score.Current)
+			assert.Equal(t, tc.expectedBureau, user.Bureau)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected Format
+	}{
+		{path: "files/input/data.txt", expected: FormatFixedWidth},
+		{path: "files/input/20250701.CSV", expected: FormatCSV},
+		{path: "files/input/20250701.tsv", expected: FormatTSV},
+		{path: "files/input/20250701.jsonl", expected: FormatJSONL},
+		{path: "files/input/20250701.ndjson", expected: FormatJSONL},
+		{path: "files/input/20250701", expected: FormatFixedWidth},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			// When & Then: 확장자로 형식 판단
+			assert.Equal(t, tc.expected, DetectFormat(tc.path))
+		})
+	}
+}
+
+func TestDelimitedParser_ParseReader(t *testing.T) {
+	testCases := []struct {
+		name        string
+		delimiter   rune
+		columns     *ColumnConfig
+		data        string
+		expectError bool
+		expected    []*domain.User
+	}{
+		{
+			name:      "CSV 기본 헤더 (순서 무관, BOM 포함)",
+			delimiter: ',',
+			data: "\ufeffphone_number,email,credit_up,previous_score,current_score,bureau\n" +
+				"000-0420-2932,user1@example.fake,Y,780,803,KCB\n" +
+				"000-1815-2005,user2@example.fake,N,,,\n",
+			expected: []*domain.User{
+				{Email: "user1@example.fake", PhoneNumber: "000-0420-2932", CreditUp: true, Score: &domain.CreditScore{Previous: 780, Current: 803}, Bureau: "KCB"},
+				{Email: "user2@example.fake", PhoneNumber: "000-1815-2005", CreditUp: false},
+			},
+		},
+		{
+			name:      "CSV 헤더 이름 매핑, credit_up 없이 점수 변화로 판단",
+			delimiter: ',',
+			columns: &ColumnConfig{
+				Header: true,
+				Mapping: map[Field]string{
+					FieldEmail:         "EMAIL_ADDR",
+					FieldPhoneNumber:   "MOBILE_NO",
+					FieldPreviousScore: "PREV_SCORE",
+					FieldCurrentScore:  "CURR_SCORE",
+				},
+			},
+			data: "EMAIL_ADDR,MOBILE_NO,PREV_SCORE,CURR_SCORE\n" +
+				"user1@example.fake,000-0420-2932,780,803\n" +
+				"user2@example.fake,000-1815-2005,803,780\n",
+			expected: []*domain.User{
+				{Email: "user1@example.fake", PhoneNumber: "000-0420-2932", CreditUp: true, Score: &domain.CreditScore{Previous: 780, Current: 803}},
+				{Email: "user2@example.fake", PhoneNumber: "000-1815-2005", CreditUp: false, Score: &domain.CreditScore{Previous: 803, Current: 780}},
+			},
+		},
+		{
+			name:      "TSV 헤더 없음, 컬럼 순서 지정",
+			delimiter: '\t',
+			columns: &ColumnConfig{
+				Columns: []Field{FieldCreditUp, FieldEmail, FieldPhoneNumber},
+			},
+			data: "Y\tuser1@example.fake\t000-0420-2932\n",
+			expected: []*domain.User{
+				{Email: "user1@example.fake", PhoneNumber: "000-0420-2932", CreditUp: true},
+			},
+		},
+		{
+			name:        "필수 컬럼 누락",
+			delimiter:   ',',
+			data:        "email,credit_up\nuser1@example.fake,Y\n",
+			expectError: true,
+		},
+		{
+			name:        "credit_up 형식 오류",
+			delimiter:   ',',
+			data:        "email,phone_number,credit_up\nuser1@example.fake,000-0420-2932,maybe\n",
+			expectError: true,
+		},
+		{
+			name:        "credit_up과 점수 모두 없음",
+			delimiter:   ',',
+			data:        "email,phone_number\nuser1@example.fake,000-0420-2932\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 구분자와 컬럼 구성으로 파서 생성
+			parser := NewDelimitedParser(tc.delimiter, tc.columns)
+
+			// When: 파싱 실행
+			users, err := parser.ParseReader(context.Background(), strings.NewReader(tc.data))
+
+			// Then: 결과 검증
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, users)
+		})
+	}
+}
+
+func TestJSONLParser_ParseReader(t *testing.T) {
+	// Given: 불리언, 문자열, 숫자 값이 섞인 JSONL
+	data := `{"email": "user1@example.fake", "phone_number": "000-0420-2932", "credit_up": true, "previous_score": 780, "current_score": 803, "bureau": "NICE"}
+
+{"email": "user2@example.fake", "phone_number": "000-1815-2005", "credit_up": "N", "bureau": null}
+`
+
+	// When: 파싱 실행
+	users, err := NewJSONLParser().ParseReader(context.Background(), strings.NewReader(data))
+
+	// Then: 빈 라인을 건너뛰고 모든 필드 변환
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.User{
+		{Email: "user1@example.fake", PhoneNumber: "000-0420-2932", CreditUp: true, Score: &domain.CreditScore{Previous: 780, Current: 803}, Bureau: "NICE"},
+		{Email: "user2@example.fake", PhoneNumber: "000-1815-2005", CreditUp: false},
+	}, users)
+
+	// When & Then: JSON 형식 오류는 라인 번호와 함께 에러
+	_, err = NewJSONLParser().ParseReader(context.Background(), strings.NewReader(`{"email": `))
+	assert.ErrorContains(t, err, "1번째 라인")
+}
+
+func TestFileParser_ParseUsers_DetectsFormat(t *testing.T) {
+	// Given: CSV 확장자 파일
+	path := filepath.Join(t.TempDir(), "20250701.csv")
+	require.NoError(t, os.WriteFile(path, []byte("email,phone_number,credit_up\nuser1@example.fake,000-0420-2932,Y\n"), 0644))
+
+	// When: 형식 지정 없이 파싱
+	users, err := NewFileParser(path).ParseUsers(context.Background())
+
+	// Then: CSV로 파싱
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "000-0420-2932", users[0].PhoneNumber)
+}
+
+func TestDetectEncoding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sample   []byte
+		expected Encoding
+	}{
+		{name: "ASCII", sample: []byte("user@example.fake 000-0420-2932 Y"), expected: EncodingUTF8},
+		{name: "UTF-8 한글", sample: []byte("나이스"), expected: EncodingUTF8},
+		{name: "UTF-8 BOM", sample: append([]byte{0xEF, 0xBB, 0xBF}, []byte("email")...), expected: EncodingUTF8},
+		{name: "샘플 끝에서 잘린 UTF-8 문자", sample: []byte("나이스")[:8], expected: EncodingUTF8},
+		{name: "EUC-KR 한글", sample: encodeEUCKR(t, "나이스"), expected: EncodingEUCKR},
+		{name: "빈 입력", sample: nil, expected: EncodingUTF8},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When & Then: 인코딩 판단
+			assert.Equal(t, tc.expected, DetectEncoding(tc.sample))
+		})
+	}
+}
+
+func TestNew_TranscodesEUCKR(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   Format
+		encoding Encoding
+		data     string
+	}{
+		{
+			name:     "공백 구분 형식, 자동 판단",
+			format:   FormatFixedWidth,
+			encoding: EncodingAuto,
+			data:     "user1@example.fake 000-0420-2932 Y 780 803 나이스\n",
+		},
+		{
+			name:     "CSV, CP949 지정",
+			format:   FormatCSV,
+			encoding: EncodingEUCKR,
+			data:     "email,phone_number,credit_up,previous_score,current_score,bureau\nuser1@example.fake,000-0420-2932,Y,780,803,나이스\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: EUC-KR로 인코딩된 입력
+			parser, err := New(tc.format, Options{Encoding: tc.encoding})
+			require.NoError(t, err)
+
+			// When: 파싱 실행
+			users, err := parser.ParseReader(context.Background(), bytes.NewReader(encodeEUCKR(t, tc.data)))
+
+			// Then: UTF-8로 변환된 값
+			require.NoError(t, err)
+			require.Len(t, users, 1)
+			assert.Equal(t, "나이스", users[0].Bureau)
+			assert.Equal(t, 23, users[0].ScoreDelta())
+		})
+	}
+}
+
+func TestFixedWidthLayoutParser_ParseReader(t *testing.T) {
+	// Given: 바이트 위치로 정의한 레이아웃 (신용평가사 뒤에 점수 컬럼)
+	layout := &FixedWidthLayout{
+		Columns: []FixedColumn{
+			{Field: FieldEmail, Start: 0, Width: 20},
+			{Field: FieldPhoneNumber, Start: 20, Width: 13},
+			{Field: FieldBureau, Start: 33, Width: 8},
+			{Field: FieldPreviousScore, Start: 41, Width: 4},
+			{Field: FieldCurrentScore, Start: 45, Width: 4},
+		},
+	}
+	require.NoError(t, layout.Validate())
+
+	// EUC-KR에서 "나이스"는 6바이트이므로 8바이트 컬럼에 공백 2개
+	line := "user1@example.fake  000-0420-2932" + "나이스  " + " 780" + " 803"
+	data := encodeEUCKR(t, line+"\r\n")
+
+	// When: EUC-KR 레이아웃 파싱
+	users, err := NewFixedWidthLayoutParser(layout, EncodingEUCKR).ParseReader(context.Background(), bytes.NewReader(data))
+
+	// Then: 바이트 위치 기준으로 잘라 컬럼별 변환
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user1@example.fake", users[0].Email)
+	assert.Equal(t, "000-0420-2932", users[0].PhoneNumber)
+	assert.Equal(t, "나이스", users[0].Bureau)
+	assert.Equal(t, 780, users[0].Score.Previous)
+	assert.Equal(t, 803, users[0].Score.Current)
+	assert.True(t, users[0].CreditUp, "credit_up 컬럼이 없으면 점수 변화로 판단")
+
+	// When & Then: 컬럼 경계가 한글 한 글자를 나누면 에러
+	layout.Columns[2].Width = 5
+	_, err = NewFixedWidthLayoutParser(layout, EncodingEUCKR).ParseReader(context.Background(), bytes.NewReader(data))
+	assert.ErrorContains(t, err, "bureau 컬럼")
+}
+
+func TestFixedWidthLayout_Validate(t *testing.T) {
+	// Given & When & Then: 필수 컬럼 누락, 잘못된 폭
+	assert.Error(t, (&FixedWidthLayout{Columns: []FixedColumn{{Field: FieldEmail, Width: 10}}}).Validate())
+	assert.Error(t, (&FixedWidthLayout{Columns: []FixedColumn{
+		{Field: FieldEmail, Width: 10},
+		{Field: FieldPhoneNumber, Start: 10, Width: 0},
+	}}).Validate())
+}
+
+func encodeEUCKR(t *testing.T, text string) []byte {
+	t.Helper()
+	encoded, err := korean.EUCKR.NewEncoder().Bytes([]byte(text))
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestFileParser_ParseUsers_Gzip(t *testing.T) {
+	// Given: gzip으로 압축한 CSV 파일
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	_, err := gzipWriter.Write([]byte("email,phone_number,credit_up\nuser1@example.fake,000-0420-2932,Y\nuser2@example.fake,000-0420-2933,N\n"))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	path := filepath.Join(t.TempDir(), "20250701.csv.gz")
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), 0644))
+
+	// When: 형식 지정 없이 파싱
+	users, err := NewFileParser(path).ParseUsers(context.Background())
+
+	// Then: 압축을 풀어 CSV로 파싱
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user2@example.fake", users[1].Email)
+	assert.Equal(t, FormatCSV, DetectFormat(path))
+}
+
+func TestFileParser_ParseUsers_Zip(t *testing.T) {
+	// Given: 형식이 다른 항목 두 개와 제외 대상 항목이 있는 zip 파일
+	path := writeZip(t, map[string]string{
+		"a.txt":            "user1@example.fake 000-0420-2932 Y\n",
+		"b.csv":            "email,phone_number,credit_up\nuser2@example.fake,000-0420-2933,Y\n",
+		"__MACOSX/._a.txt": "binary",
+	}, []string{"a.txt", "b.csv", "__MACOSX/._a.txt"})
+
+	// When: 파싱 실행
+	users, err := NewFileParser(path).ParseUsers(context.Background())
+
+	// Then: 항목 순서대로 각 형식으로 파싱
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user1@example.fake", users[0].Email)
+	assert.Equal(t, "user2@example.fake", users[1].Email)
+}
+
+func TestFileParser_ParseUsers_ZipMemberError(t *testing.T) {
+	// Given: 두 번째 항목의 2번째 라인이 잘못된 zip 파일
+	path := writeZip(t, map[string]string{
+		"a.txt": "user1@example.fake 000-0420-2932 Y\n",
+		"b.txt": "user2@example.fake 000-0420-2933 Y\ninvalid\n",
+	}, []string{"a.txt", "b.txt"})
+
+	// When: 파싱 실행
+	_, err := NewFileParser(path).ParseUsers(context.Background())
+
+	// Then: 항목 이름과 라인 번호가 에러에 포함
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "b.txt: 2번째 라인 파싱 오류")
+}
+
+func writeZip(t *testing.T, contents map[string]string, order []string) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for _, name := range order {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(contents[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	path := filepath.Join(t.TempDir(), "20250701.zip")
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), 0644))
+	return path
+}
+
+func TestFixedWidthLayoutParser_ControlRecords(t *testing.T) {
+	layout := &FixedWidthLayout{
+		Columns: []FixedColumn{
+			{Field: FieldEmail, Start: 1, Width: 20},
+			{Field: FieldPhoneNumber, Start: 21, Width: 13},
+			{Field: FieldCreditUp, Start: 34, Width: 1},
+		},
+		Header: &ControlRecord{
+			Marker:  "H",
+			Columns: []FixedColumn{{Field: FieldBatchDate, Start: 1, Width: 8}},
+		},
+		Trailer: &ControlRecord{
+			Marker: "T",
+			Columns: []FixedColumn{
+				{Field: FieldRecordCount, Start: 1, Width: 6},
+				{Field: FieldChecksum, Start: 7, Width: 8},
+			},
+		},
+	}
+	require.NoError(t, layout.Validate())
+
+	records := []string{
+		"Duser1@example.fake  000-0420-2932Y",
+		"Duser2@example.fake  000-0420-2933N",
+	}
+	checksum := crc32.ChecksumIEEE([]byte(records[0] + "\n" + records[1] + "\n"))
+	header := "H20250701"
+	trailer := fmt.Sprintf("T%06d%08x", len(records), checksum)
+
+	testCases := []struct {
+		name        string
+		lines       []string
+		expectedErr string
+	}{
+		{
+			name:  "정상 파일",
+			lines: []string{header, records[0], records[1], trailer},
+		},
+		{
+			name:        "트레일러 없음 (잘린 파일)",
+			lines:       []string{header, records[0], records[1]},
+			expectedErr: "트레일러 레코드가 없습니다",
+		},
+		{
+			name:        "레코드 누락",
+			lines:       []string{header, records[0], trailer},
+			expectedErr: "레코드 수 불일치 (트레일러 2, 실제 1)",
+		},
+		{
+			name:        "레코드 변조",
+			lines:       []string{header, records[0], strings.Replace(records[1], "N", "Y", 1), trailer},
+			expectedErr: "체크섬 불일치",
+		},
+		{
+			name:        "헤더 없음",
+			lines:       []string{records[0], records[1], trailer},
+			expectedErr: "1번째 라인: 헤더 레코드가 없습니다",
+		},
+		{
+			name:        "트레일러 뒤 데이터",
+			lines:       []string{header, records[0], records[1], trailer, records[1]},
+			expectedErr: "5번째 라인: 트레일러 레코드 뒤에 데이터가 있습니다",
+		},
+		{
+			name:        "기준일자 형식 오류",
+			lines:       []string{"H2025-07-", records[0], records[1], trailer},
+			expectedErr: "헤더 기준일자 형식 오류",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 헤더/트레일러가 있는 고정 폭 파일
+			data := strings.Join(tc.lines, "\r\n") + "\r\n"
+
+			// When: 레이아웃 파싱
+			users, err := NewFixedWidthLayoutParser(layout, EncodingUTF8).ParseReader(context.Background(), strings.NewReader(data))
+
+			// Then: 헤더/트레일러 제외한 사용자 또는 무결성 에러
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrIntegrity)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, users, 2)
+			assert.Equal(t, "user1@example.fake", users[0].Email)
+			assert.False(t, users[1].CreditUp)
+		})
+	}
+}
+
+func TestFileParser_ParseUsers_RejectsLongLines(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format Format
+		lines  []string
+	}{
+		{
+			name:   "공백 구분 형식",
+			format: FormatFixedWidth,
+			lines:  []string{"user1@example.fake 000-0420-2932 Y", "user2@example.fake 000-0420-2933 Y"},
+		},
+		{
+			name:   "CSV",
+			format: FormatCSV,
+			lines:  []string{"email,phone_number,credit_up", "user1@example.fake,000-0420-2932,Y", "user2@example.fake,000-0420-2933,Y"},
+		},
+		{
+			name:   "JSONL",
+			format: FormatJSONL,
+			lines: []string{
+				`{"email": "user1@example.fake", "phone_number": "000-0420-2932", "credit_up": "Y"}`,
+				`{"email": "user2@example.fake", "phone_number": "000-0420-2933", "credit_up": "Y"}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 마지막 사용자 앞에 최대 길이를 넘는 라인이 있는 파일
+			longLine := strings.Repeat("x", 300*1024)
+			lines := append(append([]string{}, tc.lines[:len(tc.lines)-1]...), longLine, tc.lines[len(tc.lines)-1])
+			content := strings.Join(lines, "\n") + "\n"
+
+			path := filepath.Join(t.TempDir(), "input")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+			parser, err := New(tc.format, Options{MaxLineBytes: 128 * 1024})
+			require.NoError(t, err)
+			fileParser := NewFileParserWithParser(path, parser)
+
+			// When: 파싱 실행
+			users, err := fileParser.ParseUsers(context.Background())
+
+			// Then: 긴 라인만 건너뛰고 위치를 기록
+			require.NoError(t, err)
+			require.Len(t, users, 2)
+			assert.Equal(t, "user2@example.fake", users[1].Email)
+
+			rejects := fileParser.Rejects()
+			require.Len(t, rejects, 1)
+			assert.Equal(t, len(lines)-1, rejects[0].Line)
+			assert.Equal(t, int64(strings.Index(content, longLine)), rejects[0].Offset)
+			assert.GreaterOrEqual(t, rejects[0].Size, int64(len(longLine)))
+			assert.Contains(t, rejects[0].Reason, "라인 길이 초과")
+		})
+	}
+}
+
+func TestFileParser_ParseUsers_ZipRejectSource(t *testing.T) {
+	// Given: 두 번째 항목에 긴 라인이 있는 zip 파일
+	path := writeZip(t, map[string]string{
+		"a.txt": "user1@example.fake 000-0420-2932 Y\n",
+		"b.txt": "user2@example.fake 000-0420-2933 Y\n" + strings.Repeat("x", 100) + "\n",
+	}, []string{"a.txt", "b.txt"})
+
+	parser, err := New(FormatFixedWidth, Options{MaxLineBytes: 64})
+	require.NoError(t, err)
+	fileParser := NewFileParserWithFactory(path, func(string) (Parser, error) { return parser, nil })
+
+	// When: 파싱 실행
+	users, err := fileParser.ParseUsers(context.Background())
+
+	// Then: 거부 라인에 항목 이름과 항목 안의 위치 기록
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	require.Len(t, fileParser.Rejects(), 1)
+	assert.Equal(t, Reject{Source: "b.txt", Line: 2, Offset: 35, Size: 101, Reason: "라인 길이 초과 (최대 64바이트)"}, fileParser.Rejects()[0])
+}
+
+func TestNew_RejectsBinaryInput(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "NUL 문자", content: []byte("user1@example.fake\x00\x00\x00 000-0420-2932 Y\n")},
+		{name: "제어 문자", content: bytes.Repeat([]byte{0x01, 0x02, 0x03, 'a'}, 100)},
+		{name: "UTF-16", content: []byte{0xFF, 0xFE, 'e', 0x00, 'm', 0x00}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, format := range []Format{FormatFixedWidth, FormatCSV, FormatJSONL} {
+				// Given: 텍스트가 아닌 입력
+				parser, err := New(format, Options{})
+				require.NoError(t, err)
+
+				// When: 파싱 실행
+				_, err = parser.ParseReader(context.Background(), bytes.NewReader(tc.content))
+
+				// Then: 라인을 읽기 전에 거부
+				assert.ErrorIs(t, err, ErrBinaryInput, "format %s", format)
+			}
+		})
+	}
+}
+
+func TestFileParser_ParseUsers_Parallel(t *testing.T) {
+	layout := &FixedWidthLayout{
+		Columns: []FixedColumn{
+			{Field: FieldEmail, Start: 0, Width: 24},
+			{Field: FieldPhoneNumber, Start: 24, Width: 13},
+			{Field: FieldCreditUp, Start: 37, Width: 1},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		format Format
+		opts   Options
+		line   func(i int) string
+	}{
+		{
+			name:   "공백 구분 형식",
+			format: FormatFixedWidth,
+			line: func(i int) string {
+				return fmt.Sprintf("user%d@example.fake 000-0420-%04d Y", i%700, i%10000)
+			},
+		},
+		{
+			name:   "JSONL",
+			format: FormatJSONL,
+			line: func(i int) string {
+				return fmt.Sprintf(`{"email": "user%d@example.fake", "phone_number": "000-0420-%04d", "credit_up": "Y"}`, i%700, i%10000)
+			},
+		},
+		{
+			name:   "고정 폭 레이아웃",
+			format: FormatFixedWidth,
+			opts:   Options{Layout: layout},
+			line: func(i int) string {
+				return fmt.Sprintf("%-24s000-0420-%04dY", fmt.Sprintf("user%d@example.fake", i%700), i%10000)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 중복 사용자와 긴 라인이 섞인 파일
+			lines := make([]string, 0, 2000)
+			for i := 0; i < 2000; i++ {
+				if i%500 == 250 {
+					lines = append(lines, strings.Repeat("x", 300))
+					continue
+				}
+				lines = append(lines, tc.line(i))
+			}
+			path := filepath.Join(t.TempDir(), "input")
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+
+			tc.opts.MaxLineBytes = 200
+			parser, err := New(tc.format, tc.opts)
+			require.NoError(t, err)
+
+			sequential := NewFileParserWithParser(path, parser)
+			expected, err := sequential.ParseUsers(context.Background())
+			require.NoError(t, err)
+
+			// When: 작은 구간으로 나누어 병렬 파싱
+			parallel := NewFileParserWithParser(path, parser)
+			parallel.SetWorkers(4)
+			parallel.minChunkBytes = 1024
+			users, err := parallel.ParseUsers(context.Background())
+
+			// Then: 순차 파싱과 같은 순서, 같은 거부 라인
+			require.NoError(t, err)
+			require.Len(t, users, len(expected))
+			for i := range expected {
+				assert.Equal(t, expected[i].Email, users[i].Email)
+				assert.Equal(t, expected[i].PhoneNumber, users[i].PhoneNumber)
+			}
+			assert.Equal(t, sequential.Rejects(), parallel.Rejects())
+			require.Len(t, parallel.Rejects(), 4)
+			assert.Equal(t, 1751, parallel.Rejects()[3].Line)
+		})
+	}
+}
+
+func TestFileParser_ParseUsers_ParallelError(t *testing.T) {
+	// Given: 뒤쪽 구간 두 곳에 잘못된 라인이 있는 파일
+	lines := make([]string, 0, 2000)
+	for i := 1; i <= 2000; i++ {
+		if i == 1234 || i == 1900 {
+			lines = append(lines, "invalid")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("user%d@example.fake 000-0420-%04d Y", i, i))
+	}
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+
+	fileParser := NewFileParser(path)
+	fileParser.SetWorkers(4)
+	fileParser.minChunkBytes = 1024
+
+	// When: 병렬 파싱
+	_, err := fileParser.ParseUsers(context.Background())
+
+	// Then: 파일 기준 첫 번째 오류 라인 번호
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1234번째 라인 파싱 오류")
+}
+
+func TestFileParser_ParseStream_StopsOnEmitError(t *testing.T) {
+	// Given: 여러 구간으로 나누어 병렬 파싱하는 파일
+	lines := make([]string, 0, 2000)
+	for i := 1; i <= 2000; i++ {
+		lines = append(lines, fmt.Sprintf("user%d@example.fake 000-0420-%04d Y", i, i))
+	}
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+
+	fileParser := NewFileParser(path)
+	fileParser.SetWorkers(4)
+	fileParser.minChunkBytes = 1024
+	stop := errors.New("stop")
+
+	// When: 1500번째 레코드에서 에러 반환
+	emails := make([]string, 0, 1500)
+	err := fileParser.ParseStream(context.Background(), func(user *domain.User) error {
+		emails = append(emails, user.Email)
+		if len(emails) == 1500 {
+			return stop
+		}
+		return nil
+	})
+
+	// Then: 파일 순서대로 전달하다가 그 에러로 중단
+	assert.ErrorIs(t, err, stop)
+	require.Len(t, emails, 1500)
+	assert.Equal(t, "user1@example.fake", emails[0])
+	assert.Equal(t, "user1500@example.fake", emails[1499])
+}
+
+func TestFileParser_ParseUsers_RejectSamples(t *testing.T) {
+	// Given: 긴 라인이 보관 개수보다 많은 파일
+	lines := make([]string, 0, 300)
+	for i := 0; i < 150; i++ {
+		lines = append(lines, strings.Repeat("x", 100), fmt.Sprintf("user%d@example.fake 000-0420-%04d Y", i, i))
+	}
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+
+	parser, err := New(FormatFixedWidth, Options{MaxLineBytes: 64})
+	require.NoError(t, err)
+	fileParser := NewFileParserWithParser(path, parser)
+
+	// When: 파싱 실행
+	users, err := fileParser.ParseUsers(context.Background())
+
+	// Then: 앞쪽 거부 라인만 보관하고 전체 개수는 따로 셈
+	require.NoError(t, err)
+	assert.Len(t, users, 150)
+	assert.Len(t, fileParser.Rejects(), maxRejectSamples)
+	assert.Equal(t, 1, fileParser.Rejects()[0].Line)
+	assert.Equal(t, 150, fileParser.RejectCount())
+}
+
+func BenchmarkFileParser_ParseUsers(b *testing.B) {
+	// 약 40MB 공백 구분 형식 파일
+	path := filepath.Join(b.TempDir(), "large.txt")
+	file, err := os.Create(path)
+	require.NoError(b, err)
+	writer := bufio.NewWriter(file)
+	for i := 0; i < 800000; i++ {
+		fmt.Fprintf(writer, "user%d@example.fake 000-%04d-%04d Y %d %d 나이스\n", i, i/10000, i%10000, 600+i%100, 650+i%100)
+	}
+	require.NoError(b, writer.Flush())
+	require.NoError(b, file.Close())
+
+	benchmarks := []struct {
+		name    string
+		workers int
+	}{
+		{name: "sequential", workers: 1},
+		{name: "parallel", workers: max(runtime.NumCPU(), 2)},
+	}
+
+	for _, bm := range benchmarks {
+		workers := bm.workers
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fileParser := NewFileParser(path)
+				fileParser.SetWorkers(workers)
+				if _, err := fileParser.ParseUsers(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
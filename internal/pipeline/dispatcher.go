@@ -0,0 +1,369 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/service"
+)
+
+// 2차 확인에서 레코드마다 전송 여부를 정하고 배치 단위로 전송
+// 메모리에는 전송 중인 배치와 중복 키, 대기열에서 꺼낸 사용자만 남김
+type dispatcher struct {
+	p        *Pipeline
+	ctx      context.Context
+	result   *Result
+	merger   *processor.Merger
+	channels *processor.ChannelMerger // 채널 기준 중복 제거 (ByChannel, 아니면 nil)
+	hooks    Hooks
+	notifier *service.NotificationManager
+
+	batch []*Record
+	index int // 2차에서 확인한 입력 레코드 수
+
+	claim        *service.DeferredClaim
+	releasedDone []*domain.User          // 대기열에서 지워도 되는 사용자
+	releasedLeft map[string]*domain.User // 보내지 못한 대기열 사용자 (정규화한 전화번호, 이번 실행에서 같은 번호로 보내면 지움)
+	releasedSent map[string]struct{}     // 대기열 사용자에게 보낸 전화번호 (이번 실행의 같은 번호는 중복)
+}
+
+func (p *Pipeline) newDispatcher(ctx context.Context, result *Result, merger *processor.Merger, channels *processor.ChannelMerger, hooks Hooks) *dispatcher {
+	return &dispatcher{
+		p:            p,
+		ctx:          ctx,
+		result:       result,
+		merger:       merger,
+		channels:     channels,
+		hooks:        hooks,
+		releasedLeft: make(map[string]*domain.User),
+		releasedSent: make(map[string]struct{}),
+	}
+}
+
+// 처음 보낼 때 알림 관리자 생성 (보낼 대상이 없으면 출력 파일도 열지 않음)
+func (d *dispatcher) notificationManager() *service.NotificationManager {
+	if d.notifier == nil {
+		d.notifier = d.p.newNotifier()
+		d.notifier.SetObserver(func(user *domain.User, channel domain.NotificationChannel, err error) {
+			record := d.result.recordSend(user, channel, err)
+			d.hooks.OnSend.Notify(record, channel, err)
+		})
+		d.notifier.SetStartObserver(d.result.recordStart)
+	}
+	return d.notifier
+}
+
+// 대기열 정리 후 전송 자원 정리
+func (d *dispatcher) close() {
+	if d.claim != nil {
+		// 전송 결과가 정해진 뒤 대기열 정리 (실패, 중단된 사용자는 다음 실행에서 다시 꺼냄)
+		if err := d.claim.Commit(d.releasedDone); err != nil {
+			log.WithError(err).Error("SMS 대기열 정리 실패")
+		}
+	}
+
+	if d.notifier != nil {
+		if err := d.notifier.Close(); err != nil {
+			log.WithError(err).Error("failed to close notification manager")
+		}
+	}
+}
+
+// 대기열에서 전송 시각이 된 사용자를 꺼내 입력 레코드보다 먼저 전송
+// 보관한 뒤 규칙이나 수신 거부 목록이 바뀌었을 수 있으므로 다시 적용
+func (d *dispatcher) release() error {
+	if d.p.smsScheduler == nil {
+		return nil
+	}
+
+	claim, err := d.p.smsScheduler.Release()
+	if err != nil {
+		return errors.Wrap(err, "SMS 전송 시간대 처리 실패")
+	}
+	if claim == nil {
+		return nil
+	}
+	d.claim = claim
+	d.result.SMSReleased = len(claim.Users)
+
+	for _, user := range claim.Users {
+		record := &Record{
+			Index:  -1,
+			User:   user,
+			Target: user,
+			Report: UserReport{
+				Email:       user.Email,
+				PhoneNumber: user.PhoneNumber,
+				Eligible:    true,
+				Released:    true,
+				EmailStatus: StatusNone,
+			},
+		}
+
+		switch decision := d.p.creditProcessor.EvaluateChannel(user, domain.SMSChannel); {
+		case !decision.Eligible:
+			record.Report.SMSStatus = StatusExcluded
+			record.Report.ExcludedBy = domain.SMSChannel.String() + ":" + decision.Rule
+			d.result.addExclusion(user, domain.SMSChannel.String(), decision.Rule)
+		case d.p.suppression.IsSuppressed(user, domain.SMSChannel):
+			record.Report.SMSStatus = StatusSuppressed
+			d.result.SMSSuppressed++
+		default:
+			record.Report.SMSStatus = StatusPending
+		}
+
+		if err := d.append(record); err != nil {
+			return err
+		}
+	}
+	return d.flush()
+}
+
+// 입력 레코드 하나의 전송 여부를 정하고 배치에 추가
+func (d *dispatcher) add(user *domain.User) error {
+	record := &Record{
+		Index:  d.index,
+		User:   user,
+		Target: user,
+		Report: UserReport{
+			Email:       user.Email,
+			PhoneNumber: user.PhoneNumber,
+		},
+	}
+	d.index++
+	report := &record.Report
+
+	decision := d.p.creditProcessor.Evaluate(user)
+	merge, err := d.decideMerge(user, decision.Eligible)
+	if err != nil {
+		return errors.Wrap(err, "중복 레코드 병합 중 오류")
+	}
+	report.Eligible = decision.Eligible
+	if !decision.Eligible {
+		report.ExcludedBy = decision.Rule
+		report.EmailStatus = StatusExcluded
+		report.SMSStatus = StatusExcluded
+		if d.channels != nil {
+			if _, _, err := d.channels.Decide(user, false, false); err != nil {
+				return errors.Wrap(err, "채널 중복 병합 중 오류")
+			}
+		}
+		return d.append(record)
+	}
+
+	// 병합 결과 (대표 연락처로 바꾼 복사본은 원래 레코드의 결과로 기록, union-phones로 SMS만 보내는 레코드는 이메일만 중복)
+	record.Target = merge.Target
+	email, sms := merge.Kept, merge.SMS
+	if !email {
+		report.EmailStatus = StatusDuplicate
+		report.DuplicateKey = merge.Key
+	}
+	if !sms {
+		report.SMSStatus = StatusDuplicate
+		report.DuplicateKey = merge.Key
+	}
+
+	// 채널별 규칙
+	email = email && d.allowChannel(record, domain.EmailChannel)
+	sms = sms && d.allowChannel(record, domain.SMSChannel)
+
+	// 수신 거부 (채널 중복 제거 전에 적용하여 수신 거부한 레코드가 같은 주소의 다른 레코드를 가리지 않음)
+	if email && d.p.suppression.IsSuppressed(record.Target, domain.EmailChannel) {
+		report.EmailStatus = StatusSuppressed
+		d.result.EmailSuppressed++
+		email = false
+	}
+	if sms && d.p.suppression.IsSuppressed(record.Target, domain.SMSChannel) {
+		report.SMSStatus = StatusSuppressed
+		d.result.SMSSuppressed++
+		sms = false
+	}
+
+	// 채널 주소가 같은 레코드 중 병합 정책이 고르지 않은 레코드는 그 채널에서만 중복
+	if d.channels != nil {
+		emailKept, smsKept, err := d.channels.Decide(record.Target, email, sms)
+		if err != nil {
+			return errors.Wrap(err, "채널 중복 병합 중 오류")
+		}
+		if email && !emailKept {
+			report.EmailStatus = StatusDuplicate
+			addDuplicateKey(report, domain.EmailChannel, record.Target.Email)
+			d.result.EmailDuplicates++
+			email = false
+		}
+		if sms && !smsKept {
+			report.SMSStatus = StatusDuplicate
+			addDuplicateKey(report, domain.SMSChannel, record.Target.PhoneNumber)
+			d.result.SMSDuplicates++
+			sms = false
+		}
+	}
+
+	// 대기열에서 꺼내 같은 번호로 이미 보낸 SMS
+	if sms {
+		if _, sent := d.releasedSent[domain.NormalizePhoneNumber(record.Target.PhoneNumber)]; sent {
+			report.SMSStatus = StatusDuplicate
+			addDuplicateKey(report, domain.SMSChannel, record.Target.PhoneNumber)
+			d.result.SMSDuplicates++
+			sms = false
+		}
+	}
+
+	// 중단된 이전 실행에서 이미 시도한 전송 (중복 키는 위에서 표시하여 뒤 레코드의 판단이 같음)
+	if email && d.attempted(record, domain.EmailChannel) {
+		report.EmailStatus = StatusSkipped
+		email = false
+	}
+	if sms && d.attempted(record, domain.SMSChannel) {
+		report.SMSStatus = StatusSkipped
+		sms = false
+	}
+
+	if email {
+		report.EmailStatus = StatusPending
+	}
+	if sms {
+		report.SMSStatus = StatusPending
+	}
+	return d.append(record)
+}
+
+// 레코드 단위 병합 (ByChannel은 채널별 병합이 정하므로 대상 레코드를 모두 남김)
+func (d *dispatcher) decideMerge(user *domain.User, eligible bool) (processor.MergeDecision, error) {
+	if d.channels != nil {
+		return processor.MergeDecision{Kept: eligible, SMS: eligible, Target: user}, nil
+	}
+	return d.merger.Decide(user, eligible)
+}
+
+func (d *dispatcher) attempted(record *Record, channel domain.NotificationChannel) bool {
+	if d.hooks.Attempted == nil || !d.hooks.Attempted(record.Index, channel) {
+		return false
+	}
+	d.result.SkippedSends++
+	return true
+}
+
+// 채널에서만 중복인 주소를 "채널:주소"로 기록 (두 채널 모두 중복이면 "|"로 연결)
+func addDuplicateKey(report *UserReport, channel domain.NotificationChannel, address string) {
+	key := channel.String() + ":" + address
+	if report.DuplicateKey != "" {
+		key = report.DuplicateKey + "|" + key
+	}
+	report.DuplicateKey = key
+}
+
+// 채널 규칙을 통과하면 true, 아니면 제외로 표시
+func (d *dispatcher) allowChannel(record *Record, channel domain.NotificationChannel) bool {
+	decision := d.p.creditProcessor.EvaluateChannel(record.Target, channel)
+	if decision.Eligible {
+		return true
+	}
+
+	record.Report.ExcludedBy = channel.String() + ":" + decision.Rule
+	if channel == domain.EmailChannel {
+		record.Report.EmailStatus = StatusExcluded
+	} else {
+		record.Report.SMSStatus = StatusExcluded
+	}
+	d.result.addExclusion(record.Target, channel.String(), decision.Rule)
+	return false
+}
+
+func (d *dispatcher) append(record *Record) error {
+	d.batch = append(d.batch, record)
+	if len(d.batch) < d.p.batchSize {
+		return nil
+	}
+	return d.flush()
+}
+
+// 배치를 전송하고 결과를 훅으로 넘김 (취소되었으면 보내지 않고 미전송으로 기록)
+func (d *dispatcher) flush() error {
+	records := d.batch
+	d.batch = nil
+	if len(records) == 0 {
+		return nil
+	}
+
+	emailUsers, smsUsers, err := d.schedule(records)
+	d.result.begin(records)
+	if err == nil && d.ctx.Err() == nil && len(emailUsers)+len(smsUsers) > 0 {
+		// 취소 에러는 run에서 확인 (남은 레코드도 미전송으로 기록)
+		if _, _, sendErr := d.notificationManager().SendChannelNotifications(d.ctx, emailUsers, smsUsers); sendErr != nil && d.ctx.Err() == nil {
+			err = errors.Wrap(sendErr, "알림 전송 중 오류")
+		}
+	}
+	finished := d.result.finish(records)
+
+	d.settleReleased(records)
+	d.hooks.records(finished)
+	return err
+}
+
+// SMS 허용 시간대 확인 (이메일과 대기열에서 꺼낸 SMS는 즉시 전송)
+func (d *dispatcher) schedule(records []*Record) ([]*domain.User, []*domain.User, error) {
+	emailUsers := make([]*domain.User, 0, len(records))
+	smsUsers := make([]*domain.User, 0, len(records))
+	released := make([]*domain.User, 0)
+	for _, record := range records {
+		if record.Report.EmailStatus == StatusPending {
+			emailUsers = append(emailUsers, record.Target)
+		}
+		if record.Report.SMSStatus != StatusPending {
+			continue
+		}
+		if record.Report.Released {
+			released = append(released, record.Target)
+		} else {
+			smsUsers = append(smsUsers, record.Target)
+		}
+	}
+
+	if d.p.smsScheduler != nil && len(smsUsers) > 0 {
+		sendNow, deferred, err := d.p.smsScheduler.Schedule(smsUsers)
+		if err != nil {
+			return emailUsers, nil, errors.Wrap(err, "SMS 전송 시간대 처리 실패")
+		}
+		d.result.markDeferred(records, deferred)
+		smsUsers = sendNow
+	}
+
+	d.result.EmailTargets += len(emailUsers)
+	d.result.SMSTargets += len(released) + len(smsUsers)
+	return emailUsers, append(released, smsUsers...), nil
+}
+
+// 대기열에서 꺼낸 사용자 중 지워도 되는 사용자 확인 (전송 성공, 규칙 제외, 수신 거부, 같은 번호로 이번 실행에서 전송 성공)
+func (d *dispatcher) settleReleased(records []*Record) {
+	if d.claim == nil {
+		return
+	}
+
+	for _, record := range records {
+		phone := domain.NormalizePhoneNumber(record.Target.PhoneNumber)
+		status := record.Report.SMSStatus
+
+		if !record.Report.Released {
+			if user, left := d.releasedLeft[phone]; left && status == StatusSent {
+				d.releasedDone = append(d.releasedDone, user)
+				delete(d.releasedLeft, phone)
+			}
+			continue
+		}
+
+		switch status {
+		case StatusSent:
+			d.releasedSent[phone] = struct{}{}
+			d.releasedDone = append(d.releasedDone, record.User)
+		case StatusExcluded, StatusSuppressed:
+			d.releasedDone = append(d.releasedDone, record.User)
+		default:
+			d.releasedLeft[phone] = record.User
+		}
+	}
+}
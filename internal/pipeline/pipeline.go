@@ -0,0 +1,344 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/parser"
+	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/service"
+	"banksalad-backend-task/internal/suppression"
+)
+
+type Stage string
+
+const (
+	StageParsing       Stage = "parsing"
+	StageFiltering     Stage = "filtering"
+	StageDeduplicating Stage = "deduplicating"
+	StageSending       Stage = "sending"
+)
+
+// 작업마다 새 NotificationManager를 생성하는 함수 (클라이언트, 속도 제한기는 공유 가능)
+type NotifierFactory func() *service.NotificationManager
+
+// 입력 파일을 파싱하지 못해 처리한 사용자가 없는 경우 (errors.As로 확인)
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("데이터 파일 파싱 중 오류: %v", e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// 입력 파일(압축 파일이면 압축 안의 항목) 이름에 맞는 파서를 선택하는 함수
+type ParserFactory func(path string) (parser.Parser, error)
+
+type Config struct {
+	NewParser         ParserFactory // nil이면 확장자로 형식 판단
+	ParseWorkers      int           // 2 이상이면 큰 파일을 구간별로 병렬 파싱
+	CreditProcessor   *processor.CreditProcessor
+	DuplicateStrategy domain.DuplicateStrategy
+	Dedup             processor.DedupConfig // 중복 제거 키 저장소 (기본 메모리)
+	MergePolicy       processor.MergePolicy // 중복 레코드 병합 정책 (기본 first-wins)
+	Suppression       *suppression.List
+	SMSScheduler      *service.SMSScheduler // nil이면 허용 시간대 미적용
+	NewNotifier       NotifierFactory
+	BatchSize         int // 한 번에 전송하는 레코드 수 (0이면 1000)
+}
+
+// 단계 완료 시점과 사용자별 전송 결과를 전달받는 훅 (모두 선택)
+type Hooks struct {
+	OnStageDone func(stage Stage, result *Result)
+	OnSend      service.SendObserver
+	// 전송 결과가 정해진 레코드를 배치마다 입력 순서대로 전달 (감사 기록, 아웃박스 기록 등)
+	// 파이프라인은 전달한 레코드를 보관하지 않으므로 입력 크기와 관계없이 메모리 사용량이 일정
+	OnRecords func(records []Record)
+	// 이전 실행에서 이미 시도한 전송이면 true (중단된 입력 파일을 다시 처리할 때 Index 기준으로 건너뜀)
+	Attempted func(index int, channel domain.NotificationChannel) bool
+}
+
+const defaultBatchSize = 1000
+
+// 파싱부터 알림 전송까지의 처리 흐름
+type Pipeline struct {
+	newParser         ParserFactory
+	parseWorkers      int
+	creditProcessor   *processor.CreditProcessor
+	duplicateStrategy domain.DuplicateStrategy
+	dedup             processor.DedupConfig
+	mergePolicy       processor.MergePolicy
+	suppression       *suppression.List
+	smsScheduler      *service.SMSScheduler
+	newNotifier       NotifierFactory
+	batchSize         int
+}
+
+func New(cfg Config) *Pipeline {
+	creditProcessor := cfg.CreditProcessor
+	if creditProcessor == nil {
+		creditProcessor = processor.NewCreditProcessor()
+	}
+
+	suppressionList := cfg.Suppression
+	if suppressionList == nil {
+		suppressionList = suppression.NewList(nil)
+	}
+
+	newNotifier := cfg.NewNotifier
+	if newNotifier == nil {
+		newNotifier = service.NewNotificationManager
+	}
+
+	newParser := cfg.NewParser
+	if newParser == nil {
+		newParser = func(path string) (parser.Parser, error) {
+			return parser.New(parser.DetectFormat(path), parser.Options{})
+		}
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return &Pipeline{
+		newParser:         newParser,
+		parseWorkers:      cfg.ParseWorkers,
+		creditProcessor:   creditProcessor,
+		duplicateStrategy: cfg.DuplicateStrategy,
+		dedup:             cfg.Dedup,
+		mergePolicy:       cfg.MergePolicy,
+		suppression:       suppressionList,
+		smsScheduler:      cfg.SMSScheduler,
+		newNotifier:       newNotifier,
+		batchSize:         batchSize,
+	}
+}
+
+// 입력 레코드를 처음부터 순서대로 전달하는 함수 (같은 입력을 두 번 읽음)
+type source func(ctx context.Context, emit func(*domain.User) error) error
+
+// 파일을 두 번 읽어 처리 (1차로 파일 전체의 형식과 중복 키를 확인한 뒤 2차로 다시 읽으며 전송)
+// 형식 오류가 있으면 아무에게도 보내지 않고, 레코드를 메모리에 모아 두지 않음
+func (p *Pipeline) RunFile(ctx context.Context, path string, hooks Hooks) (*Result, error) {
+	// 압축 파일은 항목 이름으로 형식 판단
+	fileParser := parser.NewFileParserWithFactory(path, p.newParser)
+	fileParser.SetWorkers(p.parseWorkers)
+	read := func(ctx context.Context, emit func(*domain.User) error) error {
+		return fileParser.ParseStream(ctx, emit)
+	}
+
+	return p.run(ctx, read, hooks, func(result *Result, err error) error {
+		if err != nil {
+			return &ParseError{Path: path, Err: err}
+		}
+		result.Rejects = fileParser.Rejects()
+		result.RejectedLines = fileParser.RejectCount()
+		return nil
+	})
+}
+
+// 파일이 아닌 요청 본문 등의 레코드를 입력 파일과 같은 형식 설정으로 파싱 (이름이 없으므로 형식을 지정하지 않았으면 고정 폭)
+func (p *Pipeline) ParseRecords(ctx context.Context, reader io.Reader) ([]*domain.User, error) {
+	recordParser, err := p.newParser("")
+	if err != nil {
+		return nil, err
+	}
+	return recordParser.ParseReader(ctx, reader)
+}
+
+// 이미 읽은 레코드 처리 (취소되어도 남은 레코드를 끝까지 확인하여 보내지 못한 전송으로 기록)
+func (p *Pipeline) Run(ctx context.Context, users []*domain.User, hooks Hooks) (*Result, error) {
+	read := func(ctx context.Context, emit func(*domain.User) error) error {
+		for _, user := range users {
+			if err := emit(user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return p.run(ctx, read, hooks, func(result *Result, err error) error {
+		return err
+	})
+}
+
+// 채널 기준 중복 제거(ByChannel)면 채널별 병합 생성 (아니면 nil, 반환한 함수로 키 저장소 정리)
+// 새 입력 없이 SMS 대기열에서 전송 시각이 된 사용자만 보냄 (감시 모드에서 새 파일이 없을 때 허용 시간대가 열린 경우 등)
+// 허용 시간대 밖이거나 꺼낼 사용자가 없으면 결과가 nil
+func (p *Pipeline) Release(ctx context.Context, hooks Hooks) (*Result, error) {
+	if p.smsScheduler == nil {
+		return nil, nil
+	}
+
+	due, err := p.smsScheduler.Due()
+	if err != nil {
+		return nil, errors.Wrap(err, "SMS 전송 시간대 처리 실패")
+	}
+	if due == 0 {
+		return nil, nil
+	}
+	return p.Run(ctx, nil, hooks)
+}
+
+func (p *Pipeline) newChannelMerger() (*processor.ChannelMerger, func(), error) {
+	if p.duplicateStrategy != domain.ByChannel {
+		return nil, func() {}, nil
+	}
+
+	var keySets []processor.KeySet
+	closeAll := func() {
+		for _, keys := range keySets {
+			if err := keys.Close(); err != nil {
+				log.WithError(err).Error("failed to close dedup key set")
+			}
+		}
+	}
+	for range 2 {
+		keys, err := processor.NewKeySet(p.dedup)
+		if err != nil {
+			closeAll()
+			return nil, nil, errors.Wrap(err, "중복 제거 저장소 생성 실패")
+		}
+		keySets = append(keySets, keys)
+	}
+	return processor.NewChannelMerger(p.mergePolicy, keySets[0], keySets[1]), closeAll, nil
+}
+
+// 채널별 병합 대상 여부 (공통 규칙, 채널 규칙, 수신 거부를 모두 통과, 2차 확인의 판단과 같음)
+func (p *Pipeline) channelEligible(user *domain.User, eligible bool) (bool, bool) {
+	if !eligible {
+		return false, false
+	}
+	email := p.creditProcessor.EvaluateChannel(user, domain.EmailChannel).Eligible &&
+		!p.suppression.IsSuppressed(user, domain.EmailChannel)
+	sms := p.creditProcessor.EvaluateChannel(user, domain.SMSChannel).Eligible &&
+		!p.suppression.IsSuppressed(user, domain.SMSChannel)
+	return email, sms
+}
+
+// parsed는 1차 확인이 끝나면 호출 (파싱 에러를 변환하거나 파싱 결과를 기록)
+func (p *Pipeline) run(ctx context.Context, read source, hooks Hooks, parsed func(result *Result, err error) error) (*Result, error) {
+	result := newResult(time.Now().In(domain.KST))
+	defer func() {
+		result.EndTime = time.Now().In(domain.KST)
+	}()
+
+	keys, err := processor.NewKeySet(p.dedup)
+	if err != nil {
+		return result, errors.Wrap(err, "중복 제거 저장소 생성 실패")
+	}
+	defer func() {
+		if err := keys.Close(); err != nil {
+			log.WithError(err).Error("failed to close dedup key set")
+		}
+	}()
+
+	// 1차: 파싱, 신용점수 상승 사용자 판단, 중복 키 확인 (레코드는 보관하지 않고 개수와 중복 키만 남김)
+	// 병합 전에 판단하여 대상 레코드끼리만 병합
+	merger := processor.NewMerger(p.duplicateStrategy, p.mergePolicy, keys)
+	channels, closeChannels, err := p.newChannelMerger()
+	if err != nil {
+		return result, err
+	}
+	defer closeChannels()
+
+	var observeErr error
+	err = read(ctx, func(user *domain.User) error {
+		result.TotalUsers++
+		decision := p.creditProcessor.Evaluate(user)
+		if decision.Eligible {
+			result.EligibleUsers++
+		} else {
+			result.addExclusion(user, "", decision.Rule)
+		}
+
+		if err := merger.Observe(user, decision.Eligible); err != nil {
+			observeErr = errors.Wrap(err, "중복 레코드 확인 중 오류")
+			return observeErr
+		}
+		if channels != nil {
+			email, sms := p.channelEligible(user, decision.Eligible)
+			if err := channels.Observe(user, email, sms); err != nil {
+				observeErr = errors.Wrap(err, "채널 중복 확인 중 오류")
+				return observeErr
+			}
+		}
+		return nil
+	})
+	if observeErr != nil {
+		return result, observeErr
+	}
+	if err := parsed(result, err); err != nil {
+		return nil, err
+	}
+
+	merger.Plan()
+	result.UniqueUsers = merger.Unique()
+	hooks.stageDone(StageParsing, result)
+	hooks.stageDone(StageFiltering, result)
+	hooks.stageDone(StageDeduplicating, result)
+
+	// 2차: 레코드마다 병합, 채널 규칙, 수신 거부, 채널 중복 제거를 적용하고 배치로 전송
+	d := p.newDispatcher(ctx, result, merger, channels, hooks)
+	defer d.close()
+
+	if err := d.release(); err != nil {
+		return result, err
+	}
+
+	var dispatchErr error
+	err = read(ctx, func(user *domain.User) error {
+		if err := d.add(user); err != nil {
+			dispatchErr = err
+			return err
+		}
+		return nil
+	})
+	if flushErr := d.flush(); dispatchErr == nil {
+		dispatchErr = flushErr
+	}
+
+	result.Conflicts = merger.Conflicts()
+	if channels != nil {
+		result.Conflicts = channels.Conflicts()
+	}
+	result.Clusters = merger.Clusters()
+	hooks.stageDone(StageSending, result)
+
+	switch {
+	case dispatchErr != nil:
+		return result, dispatchErr
+	case ctx.Err() != nil:
+		return result, errors.Wrap(ctx.Err(), "알림 전송 중 오류")
+	case err != nil:
+		return result, errors.Wrap(err, "입력을 다시 읽는 중 오류")
+	case d.index != result.TotalUsers:
+		return result, errors.Errorf("입력 레코드 수가 1차 확인(%d건)과 다릅니다 (%d건)", result.TotalUsers, d.index)
+	}
+	return result, nil
+}
+
+func (h Hooks) stageDone(stage Stage, result *Result) {
+	if h.OnStageDone != nil {
+		h.OnStageDone(stage, result)
+	}
+}
+
+func (h Hooks) records(records []Record) {
+	if h.OnRecords != nil {
+		h.OnRecords(records)
+	}
+}
@@ -0,0 +1,729 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/service"
+	"banksalad-backend-task/internal/suppression"
+)
+
+type mockClient struct {
+	mu      sync.Mutex
+	sent    []string
+	failFor string
+}
+
+func (m *mockClient) Send(to string, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if to == m.failFor {
+		return errors.New("전송 실패")
+	}
+	m.sent = append(m.sent, to)
+	return nil
+}
+
+func TestPipeline_Run(t *testing.T) {
+	// Given: 제외, 중복, 수신 거부, 전송 실패 사용자가 섞인 입력
+	users := []*domain.User{
+		createUser(t, "sent@example.com", "010-0000-0001", true),
+		createUser(t, "down@example.com", "010-0000-0002", false),
+		createUser(t, "sent@example.com", "010-0000-0003", true),
+		createUser(t, "optout@example.com", "010-0000-0004", true),
+		createUser(t, "fail@example.com", "010-0000-0005", true),
+	}
+
+	emailClient := &mockClient{failFor: "fail@example.com"}
+	smsClient := &mockClient{}
+
+	p := New(Config{
+		DuplicateStrategy: domain.ByEmail,
+		Suppression: suppression.NewList([]suppression.Entry{
+			{Contact: "optout@example.com", Scope: suppression.ScopeEmail},
+		}),
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	var stages []Stage
+	var mu sync.Mutex
+	sendCount := 0
+	hooks := Hooks{
+		OnStageDone: func(stage Stage, result *Result) {
+			stages = append(stages, stage)
+		},
+		OnSend: func(user *domain.User, channel domain.NotificationChannel, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			sendCount++
+		},
+	}
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, hooks)
+
+	// Then: 단계별 통계와 사용자별 결과 검증
+	require.NoError(t, err)
+	assert.Equal(t, []Stage{StageParsing, StageFiltering, StageDeduplicating, StageSending}, stages)
+	assert.Equal(t, 5, result.TotalUsers)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, []string{"phone_number"}, result.Conflicts[0].Fields)
+	assert.Equal(t, 4, result.EligibleUsers)
+	assert.Equal(t, 3, result.UniqueUsers)
+	assert.Equal(t, 1, result.EmailSuppressed)
+	assert.Equal(t, 1, result.EmailSuccess)
+	assert.Equal(t, 3, result.SMSSuccess)
+	assert.Equal(t, 5, sendCount)
+
+	require.Len(t, records, 5)
+
+	assert.Equal(t, StatusSent, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[0].Report.SMSStatus)
+
+	assert.Equal(t, StatusExcluded, records[1].Report.EmailStatus)
+	assert.Equal(t, "credit_up", records[1].Report.ExcludedBy)
+
+	assert.Equal(t, StatusDuplicate, records[2].Report.EmailStatus)
+	assert.Equal(t, StatusDuplicate, records[2].Report.SMSStatus)
+
+	assert.Equal(t, StatusSuppressed, records[3].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[3].Report.SMSStatus)
+
+	assert.Equal(t, StatusFailed, records[4].Report.EmailStatus)
+	assert.Equal(t, "전송 실패", records[4].Report.EmailError)
+	assert.Equal(t, StatusSent, records[4].Report.SMSStatus)
+}
+
+func TestPipeline_Run_ByChannel(t *testing.T) {
+	// Given: 이메일이나 전화번호만 같은 레코드와 완전히 같은 레코드
+	users := []*domain.User{
+		createUser(t, "a@example.com", "010-0000-0001", true),
+		createUser(t, "a@example.com", "010-0000-0002", true),
+		createUser(t, "b@example.com", "010-0000-0001", true),
+		createUser(t, "a@example.com", "010-0000-0001", true),
+	}
+
+	emailClient := &mockClient{}
+	smsClient := &mockClient{}
+	p := New(Config{
+		DuplicateStrategy: domain.ByChannel,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 주소마다 채널별로 한 번씩만 전송하고 다른 전화번호는 빠뜨리지 않음
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.EligibleUsers)
+	assert.Equal(t, 3, result.UniqueUsers)
+	assert.Equal(t, 2, result.EmailDuplicates)
+	assert.Equal(t, 2, result.SMSDuplicates)
+	assert.ElementsMatch(t, []string{"a@example.com", "b@example.com"}, emailClient.sent)
+	assert.ElementsMatch(t, []string{"010-0000-0001", "010-0000-0002"}, smsClient.sent)
+
+	assert.Equal(t, StatusSent, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[0].Report.SMSStatus)
+	assert.Equal(t, StatusDuplicate, records[1].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[1].Report.SMSStatus)
+	assert.Equal(t, "email:a@example.com", records[1].Report.DuplicateKey)
+	assert.Equal(t, StatusSent, records[2].Report.EmailStatus)
+	assert.Equal(t, StatusDuplicate, records[2].Report.SMSStatus)
+	assert.Equal(t, "sms:010-0000-0001", records[2].Report.DuplicateKey)
+	assert.Equal(t, StatusDuplicate, records[3].Report.EmailStatus)
+	assert.Equal(t, StatusDuplicate, records[3].Report.SMSStatus)
+	assert.Equal(t, "email:a@example.com|sms:010-0000-0001", records[3].Report.DuplicateKey)
+	for _, record := range records {
+		assert.True(t, record.Report.Eligible)
+	}
+}
+
+func TestPipeline_Run_ByChannelMergePolicy(t *testing.T) {
+	// Given: 이메일이 같고 전화번호가 다른 레코드, 채널 기준 중복 제거와 last-wins 병합
+	users := []*domain.User{
+		createUser(t, "a@example.com", "010-0000-0001", true),
+		createUser(t, "a@example.com", "010-0000-0002", true),
+	}
+
+	emailClient := &mockClient{}
+	smsClient := &mockClient{}
+	p := New(Config{
+		DuplicateStrategy: domain.ByChannel,
+		MergePolicy:       processor.MergeLastWins,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 이메일은 마지막 레코드로 한 번 보내고 전화번호마다 SMS 전송
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, emailClient.sent)
+	assert.ElementsMatch(t, []string{"010-0000-0001", "010-0000-0002"}, smsClient.sent)
+	assert.Equal(t, StatusDuplicate, records[0].Report.EmailStatus)
+	assert.Equal(t, "email:a@example.com", records[0].Report.DuplicateKey)
+	assert.Equal(t, StatusSent, records[1].Report.EmailStatus)
+	assert.Equal(t, 1, result.EmailDuplicates)
+
+	// Then: 같은 이메일의 다른 전화번호를 충돌로 보고
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, "email:a@example.com", result.Conflicts[0].Key)
+	assert.Equal(t, []string{"phone_number"}, result.Conflicts[0].Fields)
+	assert.Equal(t, 1, result.Conflicts[0].Kept)
+}
+
+func TestPipeline_Run_ByChannelSuppressedFirst(t *testing.T) {
+	// Given: 전화번호를 함께 쓰는 두 레코드 중 먼저 나온 레코드의 이메일이 전체 채널 수신 거부
+	users := []*domain.User{
+		createUser(t, "optout@example.com", "010-0000-0001", true),
+		createUser(t, "other@example.com", "010-0000-0001", true),
+	}
+
+	smsClient := &mockClient{}
+	p := New(Config{
+		DuplicateStrategy: domain.ByChannel,
+		Suppression: suppression.NewList([]suppression.Entry{
+			{Contact: "optout@example.com", Scope: suppression.ScopeAll},
+		}),
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 수신 거부를 먼저 적용하여 뒤 레코드가 그 번호로 SMS를 받음
+	require.NoError(t, err)
+	assert.Equal(t, []string{"010-0000-0001"}, smsClient.sent)
+	assert.Equal(t, 1, result.SMSSuppressed)
+	assert.Equal(t, 0, result.SMSDuplicates)
+
+	assert.Equal(t, StatusSuppressed, records[0].Report.SMSStatus)
+	assert.Equal(t, StatusSent, records[1].Report.SMSStatus)
+}
+
+func TestPipeline_Run_MergeEligibleFirst(t *testing.T) {
+	// Given: 같은 이메일의 처음 레코드는 하락(N), 뒤 레코드는 상승(Y), 번호 하나는 SMS 수신 거부
+	users := []*domain.User{
+		createUser(t, "dup@example.com", "010-0000-0001", false),
+		createUser(t, "dup@example.com", "010-0000-0002", true),
+		createUser(t, "dup@example.com", "010-0000-0003", true),
+		createUser(t, "dup@example.com", "010-0000-0004", true),
+	}
+
+	emailClient := &mockClient{}
+	smsClient := &mockClient{failFor: "010-0000-0004"}
+	p := New(Config{
+		DuplicateStrategy: domain.ByEmail,
+		MergePolicy:       processor.MergeUnionPhones,
+		Suppression: suppression.NewList([]suppression.Entry{
+			{Contact: "01000000003", Scope: suppression.ScopeSMS},
+		}),
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 대상 레코드끼리만 병합하여 상승 레코드로 전송
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.EligibleUsers)
+	assert.Equal(t, 1, result.UniqueUsers)
+	assert.Equal(t, []string{"dup@example.com"}, emailClient.sent)
+	assert.Equal(t, []string{"010-0000-0002"}, smsClient.sent)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, 1, result.Conflicts[0].Kept)
+
+	// Then: 전화번호마다 수신 거부와 전송 결과를 따로 기록하고 입력 레코드는 바꾸지 않음
+	assert.Equal(t, StatusExcluded, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[1].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[1].Report.SMSStatus)
+	assert.Equal(t, StatusDuplicate, records[2].Report.EmailStatus)
+	assert.Equal(t, StatusSuppressed, records[2].Report.SMSStatus)
+	assert.Equal(t, StatusDuplicate, records[3].Report.EmailStatus)
+	assert.Equal(t, StatusFailed, records[3].Report.SMSStatus)
+	assert.Equal(t, "010-0000-0002", users[1].PhoneNumber)
+}
+
+func TestPipeline_Run_Cancelled(t *testing.T) {
+	// Given: 이미 취소된 컨텍스트
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := New(Config{
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(&mockClient{}),
+			)
+		},
+	})
+	users := []*domain.User{createUser(t, "user@example.com", "010-0000-0001", true)}
+
+	// When: 처리 실행
+	result, records, err := runRecords(ctx, p, users, Hooks{})
+
+	// Then: 에러와 함께 전송하지 못한 사용자 표시
+	assert.Error(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, StatusNotAttempted, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusNotAttempted, records[0].Report.SMSStatus)
+
+	entries := OutboxEntries("run-1", records)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "email", entries[0].Channel)
+	assert.Equal(t, "sms", entries[1].Channel)
+	assert.Equal(t, "run-1", entries[1].RunID)
+	assert.Equal(t, service.OutboxInterrupted, entries[1].Reason)
+}
+
+// 첫 번째 전송을 release가 닫힐 때까지 붙잡는 클라이언트
+type blockingClient struct {
+	mockClient
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingClient) Send(to string, message string) error {
+	b.once.Do(func() {
+		close(b.started)
+		<-b.release
+	})
+	return b.mockClient.Send(to, message)
+}
+
+func TestPipeline_Run_PendingUnknown(t *testing.T) {
+	// Given: 첫 번째 SMS 전송이 끝나지 않는 클라이언트
+	smsClient := &blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	p := New(Config{
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+	users := []*domain.User{
+		createUser(t, "first@example.com", "010-0000-0001", true),
+		createUser(t, "second@example.com", "010-0000-0002", true),
+	}
+
+	var result *Result
+	var mu sync.Mutex
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Run(context.Background(), users, Hooks{
+			OnStageDone: func(stage Stage, current *Result) {
+				mu.Lock()
+				result = current
+				mu.Unlock()
+			},
+		})
+		done <- err
+	}()
+
+	// When: 전송 중에 강제 종료 시점의 배치 확인
+	<-smsClient.started
+	mu.Lock()
+	pending := result.Pending()
+	mu.Unlock()
+	close(smsClient.release)
+	require.NoError(t, <-done)
+
+	// Then: 클라이언트를 호출한 전송은 결과를 모름, 시작하지 않은 전송은 미전송
+	require.Len(t, pending, 2)
+	assert.Equal(t, StatusUnknown, pending[0].Report.SMSStatus)
+	assert.Equal(t, StatusNotAttempted, pending[1].Report.SMSStatus)
+
+	entries := OutboxEntries("run-1", pending)
+	reasons := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Channel == "sms" {
+			reasons[entry.User.PhoneNumber] = entry.Reason
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"010-0000-0001": service.OutboxUnknown,
+		"010-0000-0002": service.OutboxInterrupted,
+	}, reasons)
+}
+
+func TestPipeline_Run_CircuitOpen(t *testing.T) {
+	// Given: 첫 번째 SMS 전송이 실패하면 열리는 회로 차단기
+	users := []*domain.User{
+		createUser(t, "first@example.com", "010-0000-0001", true),
+		createUser(t, "second@example.com", "010-0000-0002", true),
+		createUser(t, "third@example.com", "010-0000-0003", true),
+	}
+	smsClient := service.NewBreakerSender(&mockClient{failFor: "010-0000-0001"}, service.NewCircuitBreaker("sms", service.BreakerConfig{
+		Window:      time.Minute,
+		MinRequests: 1,
+		FailureRate: 0.5,
+		OpenTimeout: time.Minute,
+	}))
+
+	p := New(Config{
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 실패한 사용자 뒤로는 보관 상태로 표시하고 아웃박스 항목으로 변환
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, records[0].Report.SMSStatus)
+	assert.Equal(t, StatusParked, records[1].Report.SMSStatus)
+	assert.Equal(t, StatusParked, records[2].Report.SMSStatus)
+	assert.Equal(t, StatusSent, records[2].Report.EmailStatus)
+	assert.Equal(t, 3, result.EmailSuccess)
+	assert.Equal(t, 0, result.SMSSuccess)
+
+	entries := OutboxEntries("run-1", records)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "sms", entries[0].Channel)
+	assert.Equal(t, users[1].Email, entries[0].User.Email)
+	assert.Equal(t, service.OutboxCircuitOpen, entries[0].Reason)
+}
+
+func TestPipeline_Run_ReleasedSMS(t *testing.T) {
+	// Given: 지금이 허용 시간대인 정책과 전송 시각이 지난 대기열 (1명은 보관 후 수신 거부, 1명은 이번 입력과 번호가 같음)
+	now := time.Now().In(domain.KST)
+	window := now.Add(-time.Hour).Format("15:04") + "-" + now.Add(2*time.Hour).Format("15:04")
+	policy, err := service.NewQuietHoursPolicy(window, domain.KST)
+	require.NoError(t, err)
+
+	queuePath := filepath.Join(t.TempDir(), "deferred_sms.jsonl")
+	queue := service.NewDeferredQueue(queuePath)
+	released := []*domain.User{
+		createUser(t, "optout@example.com", "010-0000-0001", true),
+		createUser(t, "fail@example.com", "010-0000-0002", true),
+		createUser(t, "again@example.com", "010-0000-0003", true),
+	}
+	require.NoError(t, queue.Enqueue(released, now.Add(-time.Minute)))
+
+	smsClient := &mockClient{failFor: "010-0000-0002"}
+	p := New(Config{
+		Suppression: suppression.NewList([]suppression.Entry{
+			{Contact: "01000000001", Scope: suppression.ScopeSMS},
+		}),
+		SMSScheduler: service.NewSMSScheduler(policy, queue),
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+	users := []*domain.User{createUser(t, "new@example.com", "010-0000-0003", true)}
+
+	// When: 처리 실행
+	result, records, err := runRecords(context.Background(), p, users, Hooks{})
+
+	// Then: 꺼낸 사용자를 입력보다 먼저 보내고 수신 거부를 다시 적용, 같은 번호의 이번 입력은 중복
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalUsers)
+	assert.Equal(t, 3, result.SMSReleased)
+	assert.Equal(t, 1, result.SMSSuppressed)
+	assert.Equal(t, []string{"010-0000-0003"}, smsClient.sent)
+
+	require.Len(t, records, 4)
+	assert.True(t, records[0].Report.Released)
+	assert.Equal(t, -1, records[0].Index)
+	assert.Equal(t, StatusNone, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusSuppressed, records[0].Report.SMSStatus)
+	assert.Equal(t, StatusFailed, records[1].Report.SMSStatus)
+	assert.Equal(t, StatusSent, records[2].Report.SMSStatus)
+	assert.Equal(t, 0, records[3].Index)
+	assert.Equal(t, StatusSent, records[3].Report.EmailStatus)
+	assert.Equal(t, StatusDuplicate, records[3].Report.SMSStatus)
+	assert.Equal(t, "sms:010-0000-0003", records[3].Report.DuplicateKey)
+
+	// Then: 보내지 못한 사용자만 대기열에 남고 아웃박스로는 옮기지 않음
+	assert.Empty(t, OutboxEntries("run-1", records))
+	remaining, err := service.NewDeferredQueue(queuePath).Claim(now)
+	require.NoError(t, err)
+	require.Len(t, remaining.Users, 1)
+	assert.Equal(t, "fail@example.com", remaining.Users[0].Email)
+}
+
+func TestPipeline_Release(t *testing.T) {
+	// Given: 지금이 허용 시간대인 정책과 전송 시각이 지난 사용자, 아직 전송 시각이 아닌 사용자가 있는 대기열
+	now := time.Now().In(domain.KST)
+	window := now.Add(-time.Hour).Format("15:04") + "-" + now.Add(2*time.Hour).Format("15:04")
+	policy, err := service.NewQuietHoursPolicy(window, domain.KST)
+	require.NoError(t, err)
+
+	queue := service.NewDeferredQueue(filepath.Join(t.TempDir(), "deferred_sms.jsonl"))
+	require.NoError(t, queue.Enqueue([]*domain.User{createUser(t, "due@example.com", "010-0000-0001", true)}, now.Add(-time.Minute)))
+	require.NoError(t, queue.Enqueue([]*domain.User{createUser(t, "later@example.com", "010-0000-0002", true)}, now.Add(time.Hour)))
+
+	smsClient := &mockClient{}
+	p := New(Config{
+		SMSScheduler: service.NewSMSScheduler(policy, queue),
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+
+	// When: 새 입력 없이 대기열만 처리
+	var records []Record
+	result, err := p.Release(context.Background(), Hooks{
+		OnRecords: func(batch []Record) {
+			records = append(records, batch...)
+		},
+	})
+
+	// Then: 전송 시각이 된 사용자만 보내고 대기열에서 지움
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 0, result.TotalUsers)
+	assert.Equal(t, 1, result.SMSReleased)
+	assert.Equal(t, []string{"010-0000-0001"}, smsClient.sent)
+	require.Len(t, records, 1)
+	assert.Equal(t, StatusSent, records[0].Report.SMSStatus)
+	remaining, err := queue.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 1, remaining)
+
+	// When: 꺼낼 사용자가 없을 때 다시 처리
+	result, err = p.Release(context.Background(), Hooks{})
+
+	// Then: 아무것도 하지 않음
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, smsClient.sent, 1)
+}
+
+func TestPipeline_Run_Batches(t *testing.T) {
+	// Given: 배치 크기보다 많은 입력
+	users := make([]*domain.User, 0, 5)
+	for i := 1; i <= 5; i++ {
+		users = append(users, createUser(t, fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("010-0000-000%d", i), true))
+	}
+
+	p := New(Config{
+		BatchSize: 2,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithClient(&mockClient{failFor: "010-0000-0004"}),
+			)
+		},
+	})
+
+	// When: 처리 실행
+	var sizes, indexes []int
+	result, err := p.Run(context.Background(), users, Hooks{
+		OnRecords: func(records []Record) {
+			sizes = append(sizes, len(records))
+			for _, record := range records {
+				indexes = append(indexes, record.Index)
+			}
+		},
+	})
+
+	// Then: 배치마다 입력 순서대로 전달하고 채널별 인원은 결과에 누적
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indexes)
+	assert.Equal(t, 5, result.EmailSuccess)
+	assert.Equal(t, 4, result.SMSSuccess)
+	assert.Equal(t, 1, result.SMSFailed)
+
+	email, sms := result.Counts()
+	assert.Equal(t, ChannelCounts{Sent: 5}, email)
+	assert.Equal(t, ChannelCounts{Sent: 4, Failed: 1}, sms)
+}
+
+func TestPipeline_Run_Attempted(t *testing.T) {
+	// Given: 이전 실행에서 첫 레코드의 두 채널과 둘째 레코드의 이메일을 이미 시도한 입력
+	users := []*domain.User{
+		createUser(t, "a@example.com", "010-0000-0001", true),
+		createUser(t, "b@example.com", "010-0000-0002", true),
+		createUser(t, "a@example.com", "010-0000-0003", true),
+	}
+	emailClient := &mockClient{}
+	smsClient := &mockClient{}
+	p := New(Config{
+		DuplicateStrategy: domain.ByChannel,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(smsClient),
+			)
+		},
+	})
+	attempted := map[int][]domain.NotificationChannel{
+		0: {domain.EmailChannel, domain.SMSChannel},
+		1: {domain.EmailChannel},
+	}
+
+	// When: 같은 입력을 다시 처리
+	result, records, err := runRecords(context.Background(), p, users, Hooks{
+		Attempted: func(index int, channel domain.NotificationChannel) bool {
+			for _, c := range attempted[index] {
+				if c == channel {
+					return true
+				}
+			}
+			return false
+		},
+	})
+
+	// Then: 시도한 전송은 건너뛰고, 건너뛴 레코드의 주소도 중복 판단에 쓰임
+	require.NoError(t, err)
+	assert.Empty(t, emailClient.sent)
+	assert.Equal(t, []string{"010-0000-0002", "010-0000-0003"}, smsClient.sent)
+	assert.Equal(t, 3, result.SkippedSends)
+	assert.Equal(t, StatusSkipped, records[0].Report.EmailStatus)
+	assert.Equal(t, StatusSkipped, records[0].Report.SMSStatus)
+	assert.Equal(t, StatusSkipped, records[1].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[1].Report.SMSStatus)
+	assert.Equal(t, StatusDuplicate, records[2].Report.EmailStatus)
+	assert.Equal(t, StatusSent, records[2].Report.SMSStatus)
+}
+
+func TestPipeline_RunFile(t *testing.T) {
+	// Given: 같은 이메일이 배치 경계를 넘어 다시 나오는 입력 파일
+	path := filepath.Join(t.TempDir(), "input.txt")
+	content := strings.Join([]string{
+		"a@example.com 010-0000-0001 Y",
+		"b@example.com 010-0000-0002 N",
+		"c@example.com 010-0000-0003 Y",
+		"a@example.com 010-0000-0004 Y",
+	}, "\n") + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	emailClient := &mockClient{}
+	p := New(Config{
+		BatchSize: 2,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(&mockClient{}),
+			)
+		},
+	})
+
+	// When: 파일 처리
+	var records []Record
+	result, err := p.RunFile(context.Background(), path, Hooks{
+		OnRecords: func(batch []Record) {
+			records = append(records, batch...)
+		},
+	})
+
+	// Then: 파일을 두 번 읽어 뒤 배치의 중복도 찾음
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.TotalUsers)
+	assert.Equal(t, 2, result.UniqueUsers)
+	assert.Equal(t, []string{"a@example.com", "c@example.com"}, emailClient.sent)
+	require.Len(t, records, 4)
+	assert.Equal(t, StatusExcluded, records[1].Report.EmailStatus)
+	assert.Equal(t, StatusDuplicate, records[3].Report.EmailStatus)
+	require.Len(t, result.Exclusions, 1)
+	assert.Equal(t, ExclusionCount{Rule: "credit_up", Count: 1}, result.Exclusions[0])
+}
+
+func TestPipeline_RunFile_InvalidLineSendsNothing(t *testing.T) {
+	// Given: 마지막 라인의 형식이 잘못된 입력 파일
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte("a@example.com 010-0000-0001 Y\ninvalid\n"), 0644))
+
+	emailClient := &mockClient{}
+	p := New(Config{
+		BatchSize: 1,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(emailClient),
+				service.NewSMSServiceWithClient(&mockClient{}),
+			)
+		},
+	})
+
+	// When: 파일 처리
+	result, err := p.RunFile(context.Background(), path, Hooks{})
+
+	// Then: 1차 확인에서 파싱 에러로 끝나 앞 레코드에도 보내지 않음
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Nil(t, result)
+	assert.Empty(t, emailClient.sent)
+}
+
+func TestPipeline_RunFile_NotFound(t *testing.T) {
+	// Given: 존재하지 않는 입력 파일
+	p := New(Config{})
+
+	// When: 처리 실행
+	result, err := p.RunFile(context.Background(), "없는파일.txt", Hooks{})
+
+	// Then: 파싱 에러
+	var parseErr *ParseError
+	require.ErrorAs(t, errors.Wrap(err, "처리 실패"), &parseErr)
+	assert.Equal(t, "없는파일.txt", parseErr.Path)
+	assert.Nil(t, result)
+}
+
+// 처리 결과를 훅으로 받은 순서대로 모음 (대기열에서 꺼낸 레코드가 먼저)
+func runRecords(ctx context.Context, p *Pipeline, users []*domain.User, hooks Hooks) (*Result, []Record, error) {
+	var records []Record
+	hooks.OnRecords = func(batch []Record) {
+		records = append(records, batch...)
+	}
+	result, err := p.Run(ctx, users, hooks)
+	return result, records, err
+}
+
+func createUser(t *testing.T, email, phoneNumber string, creditUp bool) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(email, phoneNumber, creditUp)
+	require.NoError(t, err)
+	return user
+}
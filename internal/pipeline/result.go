@@ -0,0 +1,339 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/parser"
+	"banksalad-backend-task/internal/processor"
+	"banksalad-backend-task/internal/service"
+)
+
+type Status string
+
+const (
+	StatusExcluded     Status = "excluded"
+	StatusDuplicate    Status = "duplicate"
+	StatusSuppressed   Status = "suppressed"
+	StatusDeferred     Status = "deferred"
+	StatusPending      Status = "pending"
+	StatusSending      Status = "sending" // 클라이언트를 호출했지만 아직 결과가 없음
+	StatusSent         Status = "sent"
+	StatusFailed       Status = "failed"
+	StatusParked       Status = "parked" // 회로 차단기가 열려 보내지 않고 아웃박스에 보관
+	StatusNotAttempted Status = "not_attempted"
+	StatusUnknown      Status = "unknown" // 전송 중에 강제 종료되어 전달 여부를 모름
+	StatusNone         Status = "none"    // 대기열에서 꺼낸 레코드의 이메일 (SMS만 전송)
+	StatusSkipped      Status = "skipped" // 중단된 이전 실행에서 이미 시도한 전송 (Hooks.Attempted)
+)
+
+// 입력 레코드별 처리 결과
+type UserReport struct {
+	Email        string `json:"email"`
+	PhoneNumber  string `json:"phone_number"`
+	Eligible     bool   `json:"eligible"`                // 공통 규칙 통과 여부 (대기열에서 꺼낸 레코드는 보관할 때 통과)
+	ExcludedBy   string `json:"excluded_by,omitempty"`   // 제외한 규칙 (채널 규칙은 "채널:규칙")
+	DuplicateKey string `json:"duplicate_key,omitempty"` // 레코드 단위 중복에서 일치한 키 (채널 기준 중복은 "채널:주소", 두 채널 모두면 "|"로 연결)
+	Released     bool   `json:"released,omitempty"`      // 이전 실행의 SMS 대기열에서 꺼낸 레코드 (입력 파일에 없음)
+	EmailStatus  Status `json:"email_status"`
+	EmailError   string `json:"email_error,omitempty"`
+	SMSStatus    Status `json:"sms_status"`
+	SMSError     string `json:"sms_error,omitempty"`
+}
+
+// 입력 레코드 하나와 처리 결과 (Hooks.OnRecords로 전달)
+type Record struct {
+	Index  int          // 입력 순서 (0부터, 대기열에서 꺼낸 레코드는 -1)
+	User   *domain.User // 입력 레코드
+	Target *domain.User // 전송에 사용한 사용자 (ByIdentity로 대표 연락처를 쓰면 복사본, 아니면 User)
+	Report UserReport
+}
+
+// 규칙별 제외 인원 (처음 제외한 순서)
+type ExclusionCount struct {
+	Channel string `json:"channel,omitempty"` // 채널별 규칙이면 채널
+	Rule    string `json:"rule"`
+	Count   int    `json:"count"`
+}
+
+// 채널별 전송 결과 인원 (Unsent는 중단되어 시작하지 않은 전송, Unknown은 전송 중에 중단되어 결과를 모르는 전송)
+type ChannelCounts struct {
+	Sent    int
+	Failed  int
+	Parked  int
+	Unsent  int
+	Unknown int
+}
+
+type Result struct {
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	TotalUsers      int       `json:"total_users"`
+	RejectedLines   int       `json:"rejected_lines,omitempty"` // 길이 초과 등으로 건너뛴 라인 수 (Rejects는 앞부분만)
+	EligibleUsers   int       `json:"eligible_users"`
+	UniqueUsers     int       `json:"unique_users"`
+	EmailTargets    int       `json:"email_targets"`
+	SMSTargets      int       `json:"sms_targets"`
+	EmailSuppressed int       `json:"email_suppressed"`
+	SMSSuppressed   int       `json:"sms_suppressed"`
+	SMSDeferred     int       `json:"sms_deferred"`
+	SMSReleased     int       `json:"sms_released"`     // 이전 실행의 SMS 대기열에서 꺼낸 사용자
+	EmailDuplicates int       `json:"email_duplicates"` // 채널 기준 중복 제거로 제외 (ByChannel)
+	SMSDuplicates   int       `json:"sms_duplicates"`
+	EmailSuccess    int       `json:"email_success"`
+	EmailFailed     int       `json:"email_failed"`
+	EmailParked     int       `json:"email_parked"`
+	EmailUnsent     int       `json:"email_unsent"`
+	EmailUnknown    int       `json:"email_unknown,omitempty"`
+	SMSSuccess      int       `json:"sms_success"`
+	SMSFailed       int       `json:"sms_failed"`
+	SMSParked       int       `json:"sms_parked"`
+	SMSUnsent       int       `json:"sms_unsent"`
+	SMSUnknown      int       `json:"sms_unknown,omitempty"`
+	SkippedSends    int       `json:"skipped_sends,omitempty"` // 이전 실행에서 이미 시도하여 건너뛴 전송 (채널별 건수 합)
+
+	Conflicts []processor.Conflict        `json:"conflicts,omitempty"`
+	Clusters  []processor.IdentityCluster `json:"identity_clusters,omitempty"` // ByIdentity로 병합된 연락처 묶음
+
+	Exclusions []ExclusionCount `json:"exclusions,omitempty"`
+	Rejects    []parser.Reject  `json:"rejects,omitempty"`
+
+	exclusionIndex map[string]int
+	inflight       []*Record                // 전송 중인 배치 (OnRecords로 넘기기 전)
+	targets        map[*domain.User]*Record // 전송 중인 배치의 전송 대상 → 레코드
+	mu             sync.Mutex
+}
+
+func newResult(startTime time.Time) *Result {
+	return &Result{
+		StartTime:      startTime,
+		exclusionIndex: make(map[string]int),
+	}
+}
+
+func (r *Result) addExclusion(user *domain.User, channel, rule string) {
+	log.WithFields(log.Fields{
+		"email":   user.Email,
+		"channel": channel,
+		"rule":    rule,
+	}).Debug("알림 대상 제외")
+
+	key := channel + ":" + rule
+	index, exists := r.exclusionIndex[key]
+	if !exists {
+		index = len(r.Exclusions)
+		r.exclusionIndex[key] = index
+		r.Exclusions = append(r.Exclusions, ExclusionCount{Channel: channel, Rule: rule})
+	}
+	r.Exclusions[index].Count++
+}
+
+// 허용 시간대 밖이라 대기열에 보관한 SMS 표시
+func (r *Result) markDeferred(records []*Record, deferred []*domain.User) {
+	if len(deferred) == 0 {
+		return
+	}
+
+	users := make(map[*domain.User]struct{}, len(deferred))
+	for _, user := range deferred {
+		users[user] = struct{}{}
+	}
+	for _, record := range records {
+		if _, exists := users[record.Target]; exists && record.Report.SMSStatus == StatusPending {
+			record.Report.SMSStatus = StatusDeferred
+		}
+	}
+	r.SMSDeferred += len(deferred)
+}
+
+// 전송을 시작하는 배치 등록 (강제 종료 시 Pending으로 남은 전송을 기록)
+func (r *Result) begin(records []*Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.inflight = records
+	r.targets = make(map[*domain.User]*Record, len(records))
+	for _, record := range records {
+		r.targets[record.Target] = record
+	}
+}
+
+// 시도조차 하지 못한 전송을 미전송으로 표시하고 채널별 인원을 더한 뒤 배치를 비움
+func (r *Result) finish(records []*Record) []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	finished := make([]Record, 0, len(records))
+	for _, record := range records {
+		record.Report.EmailStatus = unfinished(record.Report.EmailStatus)
+		record.Report.SMSStatus = unfinished(record.Report.SMSStatus)
+		r.count(record.Report)
+		finished = append(finished, *record)
+	}
+
+	r.inflight = nil
+	r.targets = nil
+	return finished
+}
+
+// 결과가 없는 전송의 최종 상태 (시작하지 않았으면 미전송, 시작했으면 결과를 모름)
+func unfinished(status Status) Status {
+	switch status {
+	case StatusPending:
+		return StatusNotAttempted
+	case StatusSending:
+		return StatusUnknown
+	default:
+		return status
+	}
+}
+
+// r.mu를 잡은 상태에서 호출
+func (r *Result) count(report UserReport) {
+	countStatus(report.EmailStatus, &r.EmailSuccess, &r.EmailFailed, &r.EmailParked, &r.EmailUnsent, &r.EmailUnknown)
+	countStatus(report.SMSStatus, &r.SMSSuccess, &r.SMSFailed, &r.SMSParked, &r.SMSUnsent, &r.SMSUnknown)
+}
+
+func countStatus(status Status, sent, failed, parked, unsent, unknown *int) {
+	switch status {
+	case StatusSent:
+		*sent++
+	case StatusFailed:
+		*failed++
+	case StatusParked:
+		*parked++
+	case StatusPending, StatusNotAttempted:
+		*unsent++
+	case StatusSending, StatusUnknown:
+		*unknown++
+	}
+}
+
+// 채널별 전송 결과 인원 (전송 중인 배치 포함, 중단된 실행도 정확히 집계)
+func (r *Result) Counts() (ChannelCounts, ChannelCounts) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	email := ChannelCounts{Sent: r.EmailSuccess, Failed: r.EmailFailed, Parked: r.EmailParked, Unsent: r.EmailUnsent, Unknown: r.EmailUnknown}
+	sms := ChannelCounts{Sent: r.SMSSuccess, Failed: r.SMSFailed, Parked: r.SMSParked, Unsent: r.SMSUnsent, Unknown: r.SMSUnknown}
+	for _, record := range r.inflight {
+		countStatus(record.Report.EmailStatus, &email.Sent, &email.Failed, &email.Parked, &email.Unsent, &email.Unknown)
+		countStatus(record.Report.SMSStatus, &sms.Sent, &sms.Failed, &sms.Parked, &sms.Unsent, &sms.Unknown)
+	}
+	return email, sms
+}
+
+// 클라이언트 호출을 시작한 전송 표시 (결과가 오기 전에 강제 종료되면 결과를 모르는 전송)
+func (r *Result) recordStart(user *domain.User, channel domain.NotificationChannel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record := r.targets[user]
+	if record == nil {
+		return
+	}
+
+	status := &record.Report.SMSStatus
+	if channel == domain.EmailChannel {
+		status = &record.Report.EmailStatus
+	}
+	if *status == StatusPending {
+		*status = StatusSending
+	}
+}
+
+// 전송 결과를 기록하고 그 전송의 입력 레코드 반환
+func (r *Result) recordSend(user *domain.User, channel domain.NotificationChannel, err error) *domain.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record := r.targets[user]
+	if record == nil {
+		return user
+	}
+
+	status := StatusSent
+	errMessage := ""
+	if err != nil {
+		status = StatusFailed
+		errMessage = err.Error()
+	}
+	if errors.Is(err, service.ErrCircuitOpen) {
+		status = StatusParked
+	}
+
+	if channel == domain.EmailChannel {
+		record.Report.EmailStatus = status
+		record.Report.EmailError = errMessage
+	} else {
+		record.Report.SMSStatus = status
+		record.Report.SMSError = errMessage
+	}
+	return record.User
+}
+
+// 전송 중인 배치의 레코드 (강제 종료로 OnRecords에 넘기지 못한 배치)
+// 결과가 없는 전송은 시작하지 않았으면 not_attempted, 클라이언트를 호출했으면 unknown
+func (r *Result) Pending() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]Record, 0, len(r.inflight))
+	for _, record := range r.inflight {
+		pending := *record
+		pending.Report.EmailStatus = unfinished(pending.Report.EmailStatus)
+		pending.Report.SMSStatus = unfinished(pending.Report.SMSStatus)
+		records = append(records, pending)
+	}
+	return records
+}
+
+// 다시 보내야 하는 전송을 채널별 아웃박스 항목으로 변환 (입력 순서, 같은 레코드는 이메일 먼저)
+// 회로 차단기로 보관한 전송, 중단되어 시작하지 않은 전송, 전송 중에 중단되어 결과를 모르는 전송(다시 보내지 않고 확인용)
+func OutboxEntries(runID string, records []Record) []service.OutboxEntry {
+	now := time.Now().In(domain.KST)
+	entries := make([]service.OutboxEntry, 0)
+	for _, record := range records {
+		// 대기열에서 꺼낸 레코드는 보내지 못하면 대기열에 남음
+		if record.Report.Released {
+			continue
+		}
+		for _, channel := range []struct {
+			channel domain.NotificationChannel
+			status  Status
+		}{
+			{domain.EmailChannel, record.Report.EmailStatus},
+			{domain.SMSChannel, record.Report.SMSStatus},
+		} {
+			reason := outboxReason(channel.status)
+			if reason == "" {
+				continue
+			}
+			entries = append(entries, service.OutboxEntry{
+				RunID:   runID,
+				Channel: channel.channel.String(),
+				User:    record.Target,
+				Reason:  reason,
+				Time:    now,
+			})
+		}
+	}
+	return entries
+}
+
+func outboxReason(status Status) string {
+	switch status {
+	case StatusParked:
+		return service.OutboxCircuitOpen
+	case StatusPending, StatusNotAttempted:
+		return service.OutboxInterrupted
+	case StatusSending, StatusUnknown:
+		return service.OutboxUnknown
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// 키가 없음을 확실히 판단하는 Bloom 필터 (있다는 판단은 오탐일 수 있음)
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes int
+}
+
+// 예상 키 수 n개를 넣었을 때 오탐률이 p가 되도록 비트 수 m과 해시 수 k 결정
+// m = -n ln p / (ln 2)^2, k = m/n ln 2
+func newBloomFilter(expectedKeys int, falsePositiveRate float64) *bloomFilter {
+	n := float64(expectedKeys)
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if size < 64 {
+		size = 64
+	}
+
+	hashes := int(math.Round(float64(size) / n * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+func (bf *bloomFilter) add(key string) {
+	h1, h2 := hashKey(key)
+	for i := 0; i < bf.hashes; i++ {
+		position := (h1 + uint64(i)*h2) % bf.size
+		bf.bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+func (bf *bloomFilter) mayContain(key string) bool {
+	h1, h2 := hashKey(key)
+	for i := 0; i < bf.hashes; i++ {
+		position := (h1 + uint64(i)*h2) % bf.size
+		if bf.bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (bf *bloomFilter) reset() {
+	clear(bf.bits)
+}
+
+// 128비트 FNV-1a 해시를 둘로 나누어 k개의 위치 계산에 사용 (Kirsch-Mitzenmacher)
+func hashKey(key string) (uint64, uint64) {
+	hash := fnv.New128a()
+	hash.Write([]byte(key))
+	sum := hash.Sum(nil)
+
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:]) | 1
+	return h1, h2
+}
@@ -0,0 +1,226 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Bloom 필터 오탐 확인 결과
+type BloomStats struct {
+	MaybeDuplicates int // Bloom 필터가 있다고 판단하여 디스크에서 확인한 횟수
+	FalsePositives  int // 디스크 확인 결과 없던 키 (Bloom 필터 오탐)
+}
+
+// 메모리는 Bloom 필터와 버킷 위치만 사용하고 키 원문은 디스크에 보관하는 중복 제거 저장소
+// Bloom 필터가 없다고 판단하면 디스크를 읽지 않고, 있다고 판단한 경우에만 디스크에서 정확히 확인
+type BloomKeySet struct {
+	mu     sync.Mutex // 디스크 파일은 한 번에 하나씩 읽고 씀
+	filter *bloomFilter
+	exact  *diskKeySet
+	count  int
+	stats  BloomStats
+}
+
+func NewBloomKeySet(expectedKeys int, falsePositiveRate float64, dir string) (*BloomKeySet, error) {
+	exact, err := newDiskKeySet(dir, expectedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BloomKeySet{
+		filter: newBloomFilter(expectedKeys, falsePositiveRate),
+		exact:  exact,
+	}, nil
+}
+
+func (s *BloomKeySet) Add(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.filter.mayContain(key) {
+		s.stats.MaybeDuplicates++
+
+		exists, err := s.exact.contains(key)
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			return false, nil
+		}
+		s.stats.FalsePositives++
+	}
+
+	if err := s.exact.add(key); err != nil {
+		return false, err
+	}
+	s.filter.add(key)
+	s.count++
+
+	return true, nil
+}
+
+func (s *BloomKeySet) Contains(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.filter.mayContain(key) {
+		return false, nil
+	}
+	return s.exact.contains(key)
+}
+
+func (s *BloomKeySet) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *BloomKeySet) Stats() BloomStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+func (s *BloomKeySet) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filter.reset()
+	s.count = 0
+	s.stats = BloomStats{}
+	return s.exact.reset()
+}
+
+func (s *BloomKeySet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.WithFields(log.Fields{
+		"keys":             s.count,
+		"maybe_duplicates": s.stats.MaybeDuplicates,
+		"false_positives":  s.stats.FalsePositives,
+	}).Debug("bloom key set closed")
+
+	return s.exact.close()
+}
+
+// 디스크 해시 집합 (버킷별 연결 리스트, 버킷 시작 위치만 메모리에 보관)
+// 레코드: [다음 레코드 위치 8바이트][키 길이 4바이트][키]
+type diskKeySet struct {
+	file    *os.File
+	writer  *bufio.Writer
+	heads   []int64 // 버킷별 마지막 레코드 위치 + 1 (0이면 비어 있음)
+	size    int64   // 기록한 전체 크기
+	flushed int64   // 파일에 반영된 크기
+}
+
+const diskRecordHeaderSize = 12
+
+func newDiskKeySet(dir string, expectedKeys int) (*diskKeySet, error) {
+	file, err := os.CreateTemp(dir, "dedup-*.db")
+	if err != nil {
+		return nil, errors.Wrap(err, "중복 제거 파일을 만들 수 없습니다")
+	}
+
+	// 버킷당 평균 4개 키
+	buckets := 1024
+	for buckets < expectedKeys/4 {
+		buckets *= 2
+	}
+
+	return &diskKeySet{
+		file:   file,
+		writer: bufio.NewWriterSize(file, 256*1024),
+		heads:  make([]int64, buckets),
+	}, nil
+}
+
+func (s *diskKeySet) bucket(key string) int {
+	// h2는 최하위 비트가 항상 1이므로 제외
+	_, h2 := hashKey(key)
+	return int((h2 >> 1) % uint64(len(s.heads)))
+}
+
+func (s *diskKeySet) add(key string) error {
+	bucket := s.bucket(key)
+
+	var header [diskRecordHeaderSize]byte
+	binary.LittleEndian.PutUint64(header[:8], uint64(s.heads[bucket]))
+	binary.LittleEndian.PutUint32(header[8:], uint32(len(key)))
+
+	if _, err := s.writer.Write(header[:]); err != nil {
+		return errors.Wrap(err, "중복 제거 파일 쓰기 실패")
+	}
+	if _, err := s.writer.WriteString(key); err != nil {
+		return errors.Wrap(err, "중복 제거 파일 쓰기 실패")
+	}
+
+	s.heads[bucket] = s.size + 1
+	s.size += int64(diskRecordHeaderSize + len(key))
+	return nil
+}
+
+func (s *diskKeySet) contains(key string) (bool, error) {
+	if s.flushed < s.size {
+		if err := s.writer.Flush(); err != nil {
+			return false, errors.Wrap(err, "중복 제거 파일 쓰기 실패")
+		}
+		s.flushed = s.size
+	}
+
+	var header [diskRecordHeaderSize]byte
+	buffer := make([]byte, 0, len(key))
+	for next := s.heads[s.bucket(key)]; next != 0; {
+		offset := next - 1
+		if _, err := s.file.ReadAt(header[:], offset); err != nil {
+			return false, errors.Wrap(err, "중복 제거 파일 읽기 실패")
+		}
+		next = int64(binary.LittleEndian.Uint64(header[:8]))
+
+		length := int(binary.LittleEndian.Uint32(header[8:]))
+		if length != len(key) {
+			continue
+		}
+
+		buffer = buffer[:length]
+		if _, err := s.file.ReadAt(buffer, offset+diskRecordHeaderSize); err != nil && err != io.EOF {
+			return false, errors.Wrap(err, "중복 제거 파일 읽기 실패")
+		}
+		if bytes.Equal(buffer, []byte(key)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *diskKeySet) reset() error {
+	s.writer.Reset(s.file)
+	if err := s.file.Truncate(0); err != nil {
+		return errors.Wrap(err, "중복 제거 파일 초기화 실패")
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "중복 제거 파일 초기화 실패")
+	}
+
+	clear(s.heads)
+	s.size = 0
+	s.flushed = 0
+	return nil
+}
+
+// 실행 중에만 사용하는 파일이므로 닫을 때 삭제
+func (s *diskKeySet) close() error {
+	closeErr := s.file.Close()
+	if err := os.Remove(s.file.Name()); err != nil {
+		return errors.Wrap(err, "중복 제거 파일 삭제 실패")
+	}
+	return errors.Wrap(closeErr, "중복 제거 파일 닫기 실패")
+}
@@ -2,12 +2,30 @@ package processor
 
 import (
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/rule"
 )
 
-type CreditProcessor struct{}
+// 규칙에 의해 알림 대상에서 제외된 사용자와 사유
+type Exclusion struct {
+	User *domain.User
+	Rule string
+}
+
+type CreditProcessor struct {
+	rules *rule.RuleSet
+}
 
 func NewCreditProcessor() *CreditProcessor {
-	return &CreditProcessor{}
+	return &CreditProcessor{
+		rules: rule.DefaultRuleSet(),
+	}
+}
+
+// 규칙을 지정하는 생성자
+func NewCreditProcessorWithRules(rules *rule.RuleSet) *CreditProcessor {
+	return &CreditProcessor{
+		rules: rules,
+	}
 }
 
 func (cp *CreditProcessor) FilterEligibleUsers(users []*domain.User) []*domain.User {
@@ -19,7 +37,7 @@ func (cp *CreditProcessor) FilterEligibleUsers(users []*domain.User) []*domain.U
 	eligible := make([]*domain.User, 0, len(users))
 
 	for _, user := range users {
-		if user.IsEligibleForNotification() {
+		if cp.rules.Evaluate(user).Eligible {
 			eligible = append(eligible, user)
 		}
 	}
@@ -39,10 +57,89 @@ func (cp *CreditProcessor) CountEligibleUsers(users []*domain.User) int {
 
 	count := 0
 	for _, user := range users {
-		if user.IsEligibleForNotification() {
+		if cp.rules.Evaluate(user).Eligible {
 			count++
 		}
 	}
 
 	return count
 }
+
+// 사용자 한 명의 판단 (레코드를 하나씩 처리할 때 사용)
+func (cp *CreditProcessor) Evaluate(user *domain.User) rule.Decision {
+	return cp.rules.Evaluate(user)
+}
+
+func (cp *CreditProcessor) EvaluateChannel(user *domain.User, channel domain.NotificationChannel) rule.Decision {
+	return cp.rules.EvaluateChannel(user, channel)
+}
+
+// 알림 대상과 제외된 사용자(제외 사유 포함)를 함께 반환
+func (cp *CreditProcessor) EvaluateUsers(users []*domain.User) ([]*domain.User, []Exclusion) {
+	return cp.evaluate(users, cp.rules.Evaluate)
+}
+
+// 채널별 규칙까지 적용한 알림 대상
+func (cp *CreditProcessor) EvaluateChannelUsers(users []*domain.User, channel domain.NotificationChannel) ([]*domain.User, []Exclusion) {
+	return cp.evaluate(users, func(user *domain.User) rule.Decision {
+		return cp.rules.EvaluateChannel(user, channel)
+	})
+}
+
+func (cp *CreditProcessor) evaluate(users []*domain.User, evaluate func(*domain.User) rule.Decision) ([]*domain.User, []Exclusion) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	eligible := make([]*domain.User, 0, len(users))
+	var exclusions []Exclusion
+
+	for _, user := range users {
+		decision := evaluate(user)
+		if decision.Eligible {
+			eligible = append(eligible, user)
+			continue
+		}
+		exclusions = append(exclusions, Exclusion{User: user, Rule: decision.Rule})
+	}
+
+	if len(eligible) == 0 {
+		return nil, exclusions
+	}
+
+	return eligible, exclusions
+}
+
+// 점수 상승폭이 최소 기준 이상인 사용자만 필터링 (점수 정보가 없으면 제외)
+func (cp *CreditProcessor) FilterByMinDelta(users []*domain.User, minDelta int) []*domain.User {
+	return cp.filter(users, func(user *domain.User) bool {
+		return user.HasScore() && user.ScoreDelta() >= minDelta
+	})
+}
+
+// 이번에 기준 점수를 넘어선 사용자만 필터링 (점수 정보가 없으면 제외)
+func (cp *CreditProcessor) FilterByThresholdCrossing(users []*domain.User, threshold int) []*domain.User {
+	return cp.filter(users, func(user *domain.User) bool {
+		return user.CrossedThreshold(threshold)
+	})
+}
+
+func (cp *CreditProcessor) filter(users []*domain.User, predicate func(*domain.User) bool) []*domain.User {
+	if len(users) == 0 {
+		return nil
+	}
+
+	filtered := make([]*domain.User, 0, len(users))
+
+	for _, user := range users {
+		if user.IsEligibleForNotification() && predicate(user) {
+			filtered = append(filtered, user)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return filtered
+}
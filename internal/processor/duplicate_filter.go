@@ -1,51 +1,113 @@
 package processor
 
 import (
+	"sync"
+
+	"github.com/pkg/errors"
+
 	"banksalad-backend-task/internal/domain"
 )
 
+// 이미 처리한 키가 있는데 전략을 바꾸려는 경우 (이전 전략의 키와 섞이면 중복 판단이 틀어짐)
+var ErrStrategyInUse = errors.New("처리한 사용자가 있어 중복 기준을 바꿀 수 없습니다")
+
+// 여러 고루틴에서 동시에 사용 가능 (스트리밍, 병렬 작업자, 서버 모드에서 공유)
+// 전략을 읽는 일반 처리는 읽기 잠금으로 동시에 진행하고 키 저장소가 키 단위로 동기화
+// 연락처 연결을 갱신하는 ByIdentity와 Reset은 단독 잠금
 type DuplicateFilter struct {
-	processed map[string]struct{}
-	strategy  domain.DuplicateStrategy
+	mu       sync.RWMutex
+	keys     KeySet
+	strategy domain.DuplicateStrategy
+	identity *identityGraph // ByIdentity일 때 지금까지 본 연락처 연결
+
+	errMu sync.Mutex
+	err   error
 }
 
 func NewDuplicateFilter() *DuplicateFilter {
 	return &DuplicateFilter{
-		processed: make(map[string]struct{}),
-		strategy:  domain.ByEmail, // 기본값
+		keys:     newMemoryKeySet(),
+		strategy: domain.ByEmail, // 기본값
 	}
 }
 
 // 전략을 지정하는 생성자
 func NewDuplicateFilterWithStrategy(strategy domain.DuplicateStrategy) *DuplicateFilter {
 	return &DuplicateFilter{
-		processed: make(map[string]struct{}),
-		strategy:  strategy,
+		keys:     newMemoryKeySet(),
+		strategy: strategy,
 	}
 }
 
-// 전략 변경 메서드
-func (df *DuplicateFilter) SetStrategy(strategy domain.DuplicateStrategy) {
+// 키 저장소를 지정하는 생성자 (대용량 입력은 BloomKeySet)
+func NewDuplicateFilterWithKeySet(strategy domain.DuplicateStrategy, keys KeySet) *DuplicateFilter {
+	return &DuplicateFilter{
+		keys:     keys,
+		strategy: strategy,
+	}
+}
+
+// 전략 변경 메서드 (처리를 시작한 뒤에는 Reset 전까지 ErrStrategyInUse)
+func (df *DuplicateFilter) SetStrategy(strategy domain.DuplicateStrategy) error {
+	df.mu.Lock()
+	defer df.mu.Unlock()
+
+	if strategy == df.strategy {
+		return nil
+	}
+	if df.keys.Len() > 0 {
+		return errors.Wrapf(ErrStrategyInUse, "%s → %s", df.strategy, strategy)
+	}
+
 	df.strategy = strategy
+	df.identity = nil
+	return nil
 }
 
 // 현재 전략 조회
 func (df *DuplicateFilter) GetStrategy() domain.DuplicateStrategy {
+	df.mu.RLock()
+	defer df.mu.RUnlock()
 	return df.strategy
 }
 
+// 처음 본 사용자이면 처리한 것으로 표시하고 true, 이미 처리한 사용자이면 false
+// 확인과 표시가 한 번에 일어나므로 여러 작업자가 같은 사용자를 동시에 넘겨도 한 번만 true
+// 키 저장소 오류도 false (Err로 확인)
+func (df *DuplicateFilter) CheckAndMark(user *domain.User) bool {
+	unlock := df.lock()
+	defer unlock()
+
+	df.link([]*domain.User{user})
+	added, err := df.keys.Add(df.keyOf(user))
+	if err != nil {
+		df.setErr(err)
+		return false
+	}
+	return added
+}
+
+// 키 저장소 오류가 나면 중단하고 nil 반환 (Err로 확인)
 func (df *DuplicateFilter) FilterDuplicates(users []*domain.User) []*domain.User {
 	if len(users) == 0 {
 		return nil
 	}
 
+	unlock := df.lock()
+	defer unlock()
+
+	df.link(users)
 	unique := make([]*domain.User, 0, len(users))
 
 	for _, user := range users {
-		key := user.UniqueKeyByStrategy(df.strategy) // 전략 사용
+		key := df.keyOf(user) // 전략 사용
 
-		if _, exists := df.processed[key]; !exists {
-			df.processed[key] = struct{}{}
+		added, err := df.keys.Add(key)
+		if err != nil {
+			df.setErr(err)
+			return nil
+		}
+		if added {
 			unique = append(unique, user)
 		}
 	}
@@ -57,16 +119,78 @@ func (df *DuplicateFilter) FilterDuplicates(users []*domain.User) []*domain.User
 	return unique
 }
 
+// 마지막 키 저장소 오류
+func (df *DuplicateFilter) Err() error {
+	df.errMu.Lock()
+	defer df.errMu.Unlock()
+	return df.err
+}
+
+func (df *DuplicateFilter) setErr(err error) {
+	df.errMu.Lock()
+	defer df.errMu.Unlock()
+	df.err = err
+}
+
 func (df *DuplicateFilter) Reset() {
-	df.processed = make(map[string]struct{})
+	df.mu.Lock()
+	defer df.mu.Unlock()
+
+	df.identity = nil
+	df.setErr(df.keys.Reset())
 }
 
 func (df *DuplicateFilter) GetProcessedCount() int {
-	return len(df.processed)
+	return df.keys.Len()
 }
 
 func (df *DuplicateFilter) IsProcessed(user *domain.User) bool {
-	key := user.UniqueKeyByStrategy(df.strategy)
-	_, exists := df.processed[key]
+	unlock := df.lock()
+	defer unlock()
+
+	key := df.keyOf(user)
+	exists, err := df.keys.Contains(key)
+	if err != nil {
+		df.setErr(err)
+	}
 	return exists
 }
+
+// 사용자의 중복 판단 키 (보고, 감사 기록용)
+func (df *DuplicateFilter) Key(user *domain.User) string {
+	unlock := df.lock()
+	defer unlock()
+	return df.keyOf(user)
+}
+
+// 전략에 맞는 잠금 (ByIdentity는 연락처 연결을 갱신하므로 단독 잠금)
+func (df *DuplicateFilter) lock() (unlock func()) {
+	df.mu.RLock()
+	if df.strategy != domain.ByIdentity {
+		return df.mu.RUnlock
+	}
+	df.mu.RUnlock()
+
+	df.mu.Lock()
+	return df.mu.Unlock
+}
+
+// ByIdentity는 키를 정하기 전에 입력의 연락처를 모두 연결해야 전이적으로 이어진 레코드가 같은 키를 가짐
+func (df *DuplicateFilter) link(users []*domain.User) {
+	if df.strategy != domain.ByIdentity {
+		return
+	}
+	if df.identity == nil {
+		df.identity = newIdentityGraph()
+	}
+	for _, user := range users {
+		df.identity.add(user)
+	}
+}
+
+func (df *DuplicateFilter) keyOf(user *domain.User) string {
+	if df.strategy == domain.ByIdentity && df.identity != nil {
+		return df.identity.key(user)
+	}
+	return user.UniqueKeyByStrategy(df.strategy)
+}
@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"banksalad-backend-task/internal/domain"
+)
+
+// 이메일과 전화번호를 노드로, 한 레코드의 연락처끼리를 간선으로 잇는 union-find
+// A(e1,p1), B(e2,p1), C(e2,p2)처럼 연락처를 건너 이어진 레코드도 한 사람으로 묶음
+type identityGraph struct {
+	parent   []int
+	contacts []string       // 노드 순서대로의 연락처
+	nodes    map[string]int // "email:" / "phone:" 접두어를 붙인 연락처 → 노드
+}
+
+func newIdentityGraph() *identityGraph {
+	return &identityGraph{nodes: make(map[string]int)}
+}
+
+func emailNode(email string) string { return "email:" + email }
+func phoneNode(phone string) string { return "phone:" + phone }
+
+func (g *identityGraph) node(contact string) int {
+	if id, exists := g.nodes[contact]; exists {
+		return id
+	}
+	id := len(g.parent)
+	g.parent = append(g.parent, id)
+	g.contacts = append(g.contacts, contact)
+	g.nodes[contact] = id
+	return id
+}
+
+func (g *identityGraph) find(id int) int {
+	for g.parent[id] != id {
+		g.parent[id] = g.parent[g.parent[id]]
+		id = g.parent[id]
+	}
+	return id
+}
+
+// 먼저 생긴 노드를 루트로 두어 클러스터 키가 처음 나온 레코드의 이메일로 유지되도록 함
+func (g *identityGraph) union(a, b int) {
+	rootA, rootB := g.find(a), g.find(b)
+	if rootA == rootB {
+		return
+	}
+	if rootB < rootA {
+		rootA, rootB = rootB, rootA
+	}
+	g.parent[rootB] = rootA
+}
+
+func (g *identityGraph) add(user *domain.User) {
+	g.union(g.node(emailNode(user.Email)), g.node(phoneNode(user.PhoneNumber)))
+}
+
+// 사용자가 속한 클러스터의 키 (그래프에 없는 사용자는 이메일 노드 기준)
+func (g *identityGraph) key(user *domain.User) string {
+	id, exists := g.nodes[emailNode(user.Email)]
+	if !exists {
+		return emailNode(user.Email)
+	}
+	return g.contacts[g.find(id)]
+}
+
+// 연락처를 공유해 하나로 병합된 레코드 묶음
+type IdentityCluster struct {
+	Key         string   `json:"key"`
+	Emails      []string `json:"emails"` // 처음 나온 순서
+	Phones      []string `json:"phones"`
+	Records     int      `json:"records"`
+	Email       string   `json:"email"`        // 대표 이메일 (채널별로 가장 많은 레코드에 쓰인 연락처)
+	PhoneNumber string   `json:"phone_number"` // 대표 전화번호
+}
+
+// 가장 많은 레코드에 쓰인 연락처 (같으면 먼저 나온 연락처)
+func mostFrequent(ordered []string, counts map[string]int, node func(string) string) string {
+	best := ""
+	for _, value := range ordered {
+		if best == "" || counts[node(value)] > counts[node(best)] {
+			best = value
+		}
+	}
+	return best
+}
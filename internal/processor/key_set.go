@@ -0,0 +1,143 @@
+package processor
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// 중복 제거에 사용하는 처리 완료 키 저장소 (여러 고루틴에서 동시에 호출해도 안전해야 함)
+type KeySet interface {
+	// 처음 추가된 키이면 true
+	Add(key string) (bool, error)
+	Contains(key string) (bool, error)
+	Len() int
+	Reset() error
+	Close() error
+}
+
+type DedupBackend string
+
+const (
+	DedupMemory DedupBackend = "memory" // 모든 키를 map에 보관
+	DedupBloom  DedupBackend = "bloom"  // Bloom 필터 + 디스크 정확 집합
+)
+
+const (
+	defaultExpectedKeys      = 1000000
+	defaultFalsePositiveRate = 0.01
+)
+
+// 중복 제거 저장소 설정
+type DedupConfig struct {
+	Backend           DedupBackend
+	ExpectedKeys      int     // 예상 키 수 (Bloom 필터 크기, 0이면 100만)
+	FalsePositiveRate float64 // 예상 키 수까지의 Bloom 필터 오탐률 (0이면 1%)
+	Dir               string  // 디스크 정확 집합 파일 위치 (비어 있으면 임시 디렉토리)
+}
+
+func ParseDedupBackend(name string) (DedupBackend, error) {
+	switch DedupBackend(strings.ToLower(strings.TrimSpace(name))) {
+	case "", DedupMemory:
+		return DedupMemory, nil
+	case DedupBloom:
+		return DedupBloom, nil
+	default:
+		return "", errors.Errorf("지원하지 않는 중복 제거 방식: %s (memory, bloom)", name)
+	}
+}
+
+func NewKeySet(cfg DedupConfig) (KeySet, error) {
+	switch cfg.Backend {
+	case "", DedupMemory:
+		return newMemoryKeySet(), nil
+	case DedupBloom:
+		expectedKeys := cfg.ExpectedKeys
+		if expectedKeys <= 0 {
+			expectedKeys = defaultExpectedKeys
+		}
+
+		falsePositiveRate := cfg.FalsePositiveRate
+		if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+			falsePositiveRate = defaultFalsePositiveRate
+		}
+
+		return NewBloomKeySet(expectedKeys, falsePositiveRate, cfg.Dir)
+	default:
+		return nil, errors.Errorf("지원하지 않는 중복 제거 방식: %s", cfg.Backend)
+	}
+}
+
+// 키 해시로 나눈 샤드마다 잠금을 두어 여러 작업자가 동시에 추가해도 서로 기다리는 일을 줄임
+const memoryKeyShards = 32
+
+type memoryKeySet struct {
+	shards [memoryKeyShards]memoryKeyShard
+}
+
+type memoryKeyShard struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func newMemoryKeySet() *memoryKeySet {
+	s := &memoryKeySet{}
+	for i := range s.shards {
+		s.shards[i].keys = make(map[string]struct{})
+	}
+	return s
+}
+
+func (s *memoryKeySet) shard(key string) *memoryKeyShard {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return &s.shards[hash.Sum32()%memoryKeyShards]
+}
+
+func (s *memoryKeySet) Add(key string) (bool, error) {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if _, exists := shard.keys[key]; exists {
+		return false, nil
+	}
+	shard.keys[key] = struct{}{}
+	return true, nil
+}
+
+func (s *memoryKeySet) Contains(key string) (bool, error) {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	_, exists := shard.keys[key]
+	return exists, nil
+}
+
+func (s *memoryKeySet) Len() int {
+	count := 0
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		count += len(shard.keys)
+		shard.mu.Unlock()
+	}
+	return count
+}
+
+func (s *memoryKeySet) Reset() error {
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		shard.keys = make(map[string]struct{})
+		shard.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *memoryKeySet) Close() error {
+	return nil
+}
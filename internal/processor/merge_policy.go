@@ -0,0 +1,455 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 같은 키의 레코드가 여러 개일 때 남길 레코드를 고르는 정책
+type MergePolicy string
+
+const (
+	MergeFirstWins   MergePolicy = "first-wins"   // 처음 나온 레코드
+	MergeLastWins    MergePolicy = "last-wins"    // 마지막 레코드
+	MergeAnyYWins    MergePolicy = "any-y-wins"   // 신용점수 상승(Y) 레코드가 하나라도 있으면 그중 처음 레코드
+	MergeUnionPhones MergePolicy = "union-phones" // 처음 레코드, 다른 레코드의 새 전화번호로는 SMS만 전송
+)
+
+func ParseMergePolicy(name string) (MergePolicy, error) {
+	switch policy := MergePolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case "":
+		return MergeFirstWins, nil
+	case MergeFirstWins, MergeLastWins, MergeAnyYWins, MergeUnionPhones:
+		return policy, nil
+	default:
+		return "", errors.Errorf("지원하지 않는 병합 정책: %s (first-wins, last-wins, any-y-wins, union-phones)", name)
+	}
+}
+
+// 같은 키의 레코드끼리 값이 다른 경우 (데이터 품질 확인용)
+type Conflict struct {
+	Key     string           `json:"key"`
+	Fields  []string         `json:"fields"`  // 값이 다른 필드
+	Records []ConflictRecord `json:"records"` // 입력 순서
+	Kept    int              `json:"kept"`    // 정책으로 남긴 레코드 (Records 위치, 알림 대상 레코드가 없으면 -1)
+}
+
+type ConflictRecord struct {
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phone_number"`
+	CreditUp    bool   `json:"credit_up"`
+	Score       string `json:"score,omitempty"` // 이전→현재
+	Bureau      string `json:"bureau,omitempty"`
+}
+
+func newConflictRecord(user *domain.User) ConflictRecord {
+	record := ConflictRecord{
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		CreditUp:    user.CreditUp,
+		Bureau:      user.Bureau,
+	}
+	if user.HasScore() {
+		record.Score = fmt.Sprintf("%d→%d", user.Score.Previous, user.Score.Current)
+	}
+	return record
+}
+
+// 레코드 하나의 병합 결과
+type MergeDecision struct {
+	Kept   bool         // 정책으로 남긴 레코드 (이메일과 SMS 전송)
+	SMS    bool         // SMS를 보낼 레코드 (union-phones는 남긴 레코드와 번호가 다른 레코드 포함)
+	Key    string       // 같은 키의 레코드가 더 있으면 그 키
+	Target *domain.User // 전송에 사용할 사용자 (ByIdentity로 남긴 레코드는 대표 연락처로 바꾼 복사본)
+}
+
+// 같은 키의 레코드를 병합 정책에 따라 정리 (입력을 같은 순서로 두 번 확인)
+// 1차 Observe: 키 저장소로 중복 키와 정책이 남길 레코드를 찾고 중복 키만 메모리에 보관
+// 2차 Decide: 레코드마다 전송 여부를 정하고 중복 키의 레코드를 충돌 보고용으로 모음
+// 알림 대상 레코드끼리만 병합하므로 먼저 나온 제외 대상 레코드가 뒤의 대상 레코드를 가리지 않음
+// 입력 레코드는 바꾸지 않음, 한 고루틴에서만 사용
+type Merger struct {
+	strategy domain.DuplicateStrategy
+	policy   MergePolicy
+	keys     KeySet
+	identity *identityGraph
+	nodes    []identityNode // ByIdentity 노드별 1차 집계
+
+	observed int
+	decided  int
+	unique   int
+	planned  bool
+
+	groups map[string]*mergeGroup // 레코드가 둘 이상인 키
+	order  []string               // 2차에서 중복 키가 처음 나온 순서
+}
+
+type mergeGroup struct {
+	multiEligible bool // 대상 레코드가 둘 이상 (1차)
+	last          int  // 마지막 대상 레코드 순번 (1차, last-wins)
+	creditUp      bool // 대상 레코드 중 신용점수 상승 레코드가 있음 (any-y-wins)
+	cluster       *IdentityCluster
+
+	kept    bool
+	keptAt  int
+	records []ConflictRecord
+	phones  map[string]struct{} // SMS를 보낼 전화번호 (정규화)
+}
+
+// ByIdentity에서 연락처 노드별 집계 (클러스터는 연결을 모두 마친 뒤에 정해짐)
+type identityNode struct {
+	uses     int // 이 연락처를 쓴 레코드 수
+	rows     int // 이 이메일의 레코드 수
+	eligible int
+	last     int
+	creditUp bool
+}
+
+// keys는 ByIdentity가 아닐 때 1차 확인에만 사용 (키마다 최대 세 개 추가)
+func NewMerger(strategy domain.DuplicateStrategy, policy MergePolicy, keys KeySet) *Merger {
+	if policy == "" {
+		policy = MergeFirstWins
+	}
+	merger := &Merger{
+		strategy: strategy,
+		policy:   policy,
+		keys:     keys,
+		groups:   make(map[string]*mergeGroup),
+	}
+	if strategy == domain.ByIdentity {
+		merger.identity = newIdentityGraph()
+	}
+	return merger
+}
+
+// 1차 확인 (eligible은 신용점수 상승 규칙 통과 여부)
+func (m *Merger) Observe(user *domain.User, eligible bool) error {
+	if m.planned {
+		return errors.New("병합 계획을 세운 뒤에는 레코드를 추가할 수 없습니다")
+	}
+	index := m.observed
+	m.observed++
+
+	if m.identity != nil {
+		m.observeIdentity(user, index, eligible)
+		return nil
+	}
+
+	key := user.UniqueKeyByStrategy(m.strategy)
+	added, err := m.keys.Add("a|" + key)
+	if err != nil {
+		return err
+	}
+	if !added && m.groups[key] == nil {
+		m.groups[key] = &mergeGroup{keptAt: -1}
+	}
+	if !eligible {
+		return nil
+	}
+
+	added, err = m.keys.Add("e|" + key)
+	if err != nil {
+		return err
+	}
+	if added {
+		m.unique++
+	} else {
+		group := m.groups[key]
+		group.multiEligible = true
+		group.last = index
+	}
+
+	if user.CreditUp && m.policy == MergeAnyYWins {
+		if _, err := m.keys.Add("y|" + key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Merger) observeIdentity(user *domain.User, index int, eligible bool) {
+	m.identity.add(user)
+	for len(m.nodes) < len(m.identity.parent) {
+		m.nodes = append(m.nodes, identityNode{last: -1})
+	}
+
+	emailID := m.identity.nodes[emailNode(user.Email)]
+	m.nodes[emailID].uses++
+	m.nodes[m.identity.nodes[phoneNode(user.PhoneNumber)]].uses++
+
+	node := &m.nodes[emailID]
+	node.rows++
+	if eligible {
+		node.eligible++
+		node.last = index
+		node.creditUp = node.creditUp || user.CreditUp
+	}
+}
+
+// 1차 확인을 마침 (ByIdentity는 연결을 모두 마친 뒤 클러스터별로 집계하고 대표 연락처를 고름)
+func (m *Merger) Plan() {
+	if m.planned {
+		return
+	}
+	m.planned = true
+	if m.identity == nil {
+		return
+	}
+
+	type clusterStats struct {
+		node           identityNode
+		emails, phones []string
+		uses           map[string]int
+	}
+	roots := make([]int, 0)
+	stats := make(map[int]*clusterStats)
+	for id, node := range m.nodes {
+		root := m.identity.find(id)
+		s := stats[root]
+		if s == nil {
+			s = &clusterStats{node: identityNode{last: -1}, uses: make(map[string]int)}
+			stats[root] = s
+			roots = append(roots, root)
+		}
+
+		s.node.rows += node.rows
+		s.node.eligible += node.eligible
+		s.node.creditUp = s.node.creditUp || node.creditUp
+		if node.last > s.node.last {
+			s.node.last = node.last
+		}
+
+		contact := m.identity.contacts[id]
+		if email := strings.TrimPrefix(contact, "email:"); email != contact {
+			s.emails = append(s.emails, email)
+			s.uses[contact] = node.uses
+		} else {
+			phone := strings.TrimPrefix(contact, "phone:")
+			s.phones = append(s.phones, phone)
+			s.uses[contact] = node.uses
+		}
+	}
+
+	for _, root := range roots {
+		s := stats[root]
+		if s.node.eligible > 0 {
+			m.unique++
+		}
+		if s.node.rows < 2 {
+			continue
+		}
+
+		key := m.identity.contacts[root]
+		m.groups[key] = &mergeGroup{
+			multiEligible: s.node.eligible > 1,
+			last:          s.node.last,
+			creditUp:      s.node.creditUp,
+			keptAt:        -1,
+			cluster: &IdentityCluster{
+				Key:         key,
+				Emails:      s.emails,
+				Phones:      s.phones,
+				Records:     s.node.rows,
+				Email:       mostFrequent(s.emails, s.uses, emailNode),
+				PhoneNumber: mostFrequent(s.phones, s.uses, phoneNode),
+			},
+		}
+	}
+	m.nodes = nil
+}
+
+// 2차 확인 (Observe와 같은 순서, 같은 eligible로 호출)
+func (m *Merger) Decide(user *domain.User, eligible bool) (MergeDecision, error) {
+	m.Plan()
+	index := m.decided
+	m.decided++
+	if index >= m.observed {
+		return MergeDecision{}, errors.New("1차 확인보다 많은 레코드를 병합할 수 없습니다")
+	}
+
+	key := m.keyOf(user)
+	group := m.groups[key]
+	decision := MergeDecision{Target: user}
+	if group == nil {
+		decision.Kept = eligible
+		decision.SMS = eligible
+		return decision, nil
+	}
+
+	decision.Key = key
+	if len(group.records) == 0 {
+		m.order = append(m.order, key)
+		if m.identity == nil && m.policy == MergeAnyYWins {
+			creditUp, err := m.keys.Contains("y|" + key)
+			if err != nil {
+				return MergeDecision{}, err
+			}
+			group.creditUp = creditUp
+		}
+	}
+	group.records = append(group.records, newConflictRecord(user))
+	if !eligible {
+		return decision, nil
+	}
+
+	if !group.kept && m.keeps(group, index, user) {
+		group.kept = true
+		group.keptAt = len(group.records) - 1
+		decision.Kept = true
+		if group.cluster != nil {
+			target := *user
+			target.Email = group.cluster.Email
+			target.PhoneNumber = group.cluster.PhoneNumber
+			decision.Target = &target
+		}
+	}
+
+	// union-phones는 대상 레코드의 전화번호마다 한 번씩 SMS 전송 (번호별로 결과를 남김)
+	if m.policy != MergeUnionPhones {
+		decision.SMS = decision.Kept
+		return decision, nil
+	}
+	if group.phones == nil {
+		group.phones = make(map[string]struct{})
+	}
+	phone := domain.NormalizePhoneNumber(decision.Target.PhoneNumber)
+	if _, used := group.phones[phone]; !used {
+		group.phones[phone] = struct{}{}
+		decision.SMS = true
+	}
+	return decision, nil
+}
+
+// 키의 처음 남길 대상 레코드인지 (이미 남긴 레코드가 없고 대상인 레코드만 확인)
+func (m *Merger) keeps(group *mergeGroup, index int, user *domain.User) bool {
+	switch m.policy {
+	case MergeLastWins:
+		return !group.multiEligible || index == group.last
+	case MergeAnyYWins:
+		return !group.creditUp || user.CreditUp
+	default:
+		return true
+	}
+}
+
+func (m *Merger) keyOf(user *domain.User) string {
+	if m.identity != nil {
+		return m.identity.key(user)
+	}
+	return user.UniqueKeyByStrategy(m.strategy)
+}
+
+// 남길 레코드 수 (대상 레코드의 서로 다른 키 수, Plan 이후)
+func (m *Merger) Unique() int {
+	return m.unique
+}
+
+// 같은 키의 레코드끼리 값이 다른 키 (2차 확인 이후, 중복 키가 처음 나온 순서)
+func (m *Merger) Conflicts() []Conflict {
+	conflicts := make([]Conflict, 0)
+	for _, key := range m.order {
+		group := m.groups[key]
+		fields := conflictFields(group.records)
+		if len(fields) == 0 {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Key:     key,
+			Fields:  fields,
+			Records: group.records,
+			Kept:    group.keptAt,
+		})
+	}
+	return conflicts
+}
+
+// ByIdentity로 묶인 레코드가 둘 이상인 클러스터 (2차 확인 이후)
+func (m *Merger) Clusters() []IdentityCluster {
+	clusters := make([]IdentityCluster, 0)
+	for _, key := range m.order {
+		if cluster := m.groups[key].cluster; cluster != nil {
+			clusters = append(clusters, *cluster)
+		}
+	}
+	return clusters
+}
+
+// 첫 레코드와 값이 다른 필드 (ByIdentity가 아니면 중복 키로 쓰인 필드는 항상 같음)
+func conflictFields(records []ConflictRecord) []string {
+	first := records[0]
+
+	differs := make(map[string]bool)
+	for _, record := range records[1:] {
+		differs["email"] = differs["email"] || record.Email != first.Email
+		differs["phone_number"] = differs["phone_number"] || record.PhoneNumber != first.PhoneNumber
+		differs["credit_up"] = differs["credit_up"] || record.CreditUp != first.CreditUp
+		differs["score"] = differs["score"] || record.Score != first.Score
+		differs["bureau"] = differs["bureau"] || record.Bureau != first.Bureau
+	}
+
+	fields := make([]string, 0)
+	for _, field := range []string{"email", "phone_number", "credit_up", "score", "bureau"} {
+		if differs[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// 채널 기준 중복 제거(ByChannel)의 채널별 병합 (이메일은 이메일 주소, SMS는 전화번호로 묶어 병합 정책 적용)
+// 레코드마다 채널별 대상 여부(공통 규칙, 채널 규칙, 수신 거부를 모두 통과)를 Merger와 같은 순서로 두 번 전달
+// 수신 거부한 레코드는 대상이 아니므로 같은 주소의 다른 레코드를 가리지 않음
+type ChannelMerger struct {
+	email *Merger
+	sms   *Merger
+}
+
+func NewChannelMerger(policy MergePolicy, emailKeys, smsKeys KeySet) *ChannelMerger {
+	return &ChannelMerger{
+		email: NewMerger(domain.ByEmail, policy, emailKeys),
+		sms:   NewMerger(domain.ByPhone, policy, smsKeys),
+	}
+}
+
+// 1차 확인
+func (cm *ChannelMerger) Observe(user *domain.User, email, sms bool) error {
+	if err := cm.email.Observe(user, email); err != nil {
+		return err
+	}
+	return cm.sms.Observe(user, sms)
+}
+
+// 2차 확인 (채널마다 정책으로 남긴 레코드면 true)
+func (cm *ChannelMerger) Decide(user *domain.User, email, sms bool) (bool, bool, error) {
+	emailDecision, err := cm.email.Decide(user, email)
+	if err != nil {
+		return false, false, err
+	}
+	smsDecision, err := cm.sms.Decide(user, sms)
+	if err != nil {
+		return false, false, err
+	}
+	return emailDecision.Kept, smsDecision.Kept, nil
+}
+
+// 채널 주소가 같은 레코드끼리 값이 다른 경우 (키는 "채널:주소", 이메일 다음 SMS)
+func (cm *ChannelMerger) Conflicts() []Conflict {
+	conflicts := make([]Conflict, 0)
+	for _, channel := range []struct {
+		name   string
+		merger *Merger
+	}{
+		{domain.EmailChannel.String(), cm.email},
+		{domain.SMSChannel.String(), cm.sms},
+	} {
+		for _, conflict := range channel.merger.Conflicts() {
+			conflict.Key = channel.name + ":" + conflict.Key
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
@@ -2,12 +2,16 @@ package processor
 
 import (
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/rule"
 )
 
 func TestCreditProcessor_FilterEligibleUsers(t *testing.T) {
@@ -234,6 +238,545 @@ func TestDuplicateFilter_FilterDuplicates_WithDifferentStrategies(t *testing.T)
 	}
 }
 
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	testCases := []struct {
+		name              string
+		expectedKeys      int
+		falsePositiveRate float64
+	}{
+		{name: "오탐률 1%", expectedKeys: 20000, falsePositiveRate: 0.01},
+		{name: "오탐률 0.1%", expectedKeys: 20000, falsePositiveRate: 0.001},
+		{name: "오탐률 5%", expectedKeys: 5000, falsePositiveRate: 0.05},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 예상 키 수만큼 채운 Bloom 필터
+			filter := newBloomFilter(tc.expectedKeys, tc.falsePositiveRate)
+			for i := 0; i < tc.expectedKeys; i++ {
+				filter.add(fmt.Sprintf("user%d@example.com", i))
+			}
+
+			// When: 넣지 않은 키 20만 개 확인
+			const queries = 200000
+			falsePositives := 0
+			for i := 0; i < queries; i++ {
+				if filter.mayContain(fmt.Sprintf("absent%d@example.com", i)) {
+					falsePositives++
+				}
+			}
+
+			// Then: 넣은 키는 모두 있다고 판단, 오탐률은 목표의 1.5배 이내
+			for i := 0; i < tc.expectedKeys; i++ {
+				require.True(t, filter.mayContain(fmt.Sprintf("user%d@example.com", i)))
+			}
+			rate := float64(falsePositives) / queries
+			assert.LessOrEqual(t, rate, tc.falsePositiveRate*1.5, "observed false positive rate %.5f", rate)
+		})
+	}
+}
+
+func TestBloomKeySet_ExactWithFalsePositives(t *testing.T) {
+	// Given: 오탐이 많이 나도록 작게 만든 Bloom 필터
+	dir := t.TempDir()
+	keys, err := NewBloomKeySet(10, 0.5, dir)
+	require.NoError(t, err)
+	expected := newMemoryKeySet()
+
+	// When: 중복이 섞인 키 추가
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("user%d@example.com", i%3000)
+
+		added, err := keys.Add(key)
+		require.NoError(t, err)
+		expectedAdded, _ := expected.Add(key)
+
+		// Then: 메모리 방식과 같은 결과 (오탐은 디스크에서 걸러냄)
+		require.Equal(t, expectedAdded, added, key)
+	}
+
+	assert.Equal(t, 3000, keys.Len())
+	assert.Equal(t, 2000+keys.Stats().FalsePositives, keys.Stats().MaybeDuplicates)
+	assert.Greater(t, keys.Stats().FalsePositives, 0)
+
+	exists, err := keys.Contains("user2999@example.com")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = keys.Contains("absent@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// When & Then: 초기화 후 다시 추가 가능
+	require.NoError(t, keys.Reset())
+	added, err := keys.Add("user1@example.com")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// When & Then: 닫으면 디스크 파일 삭제
+	require.NoError(t, keys.Close())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDuplicateFilter_WithBloomKeySet(t *testing.T) {
+	// Given: Bloom 방식 키 저장소를 사용하는 필터
+	keys, err := NewKeySet(DedupConfig{Backend: DedupBloom, ExpectedKeys: 100, Dir: t.TempDir()})
+	require.NoError(t, err)
+	defer keys.Close()
+	filter := NewDuplicateFilterWithKeySet(domain.ByEmail, keys)
+
+	// When: 중복 제거 실행
+	unique := filter.FilterDuplicates(createMixedTestUsers())
+
+	// Then: 메모리 방식과 같은 사용자, 같은 순서
+	expected := NewDuplicateFilter().FilterDuplicates(createMixedTestUsers())
+	require.NoError(t, filter.Err())
+	require.Len(t, unique, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Email, unique[i].Email)
+		assert.Equal(t, expected[i].PhoneNumber, unique[i].PhoneNumber)
+	}
+}
+
+// 경합 검사는 go test -race 로 실행
+func TestDuplicateFilter_CheckAndMark_Concurrent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		backend  DedupBackend
+		strategy domain.DuplicateStrategy
+	}{
+		{name: "메모리 방식", backend: DedupMemory, strategy: domain.ByEmail},
+		{name: "Bloom 방식", backend: DedupBloom, strategy: domain.ByEmail},
+		{name: "연락처 연결 기준", backend: DedupMemory, strategy: domain.ByIdentity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 여러 작업자가 같은 사용자 목록을 서로 다른 순서로 처리
+			const workers = 16
+			users := createUniqueTestUsers(500)
+
+			keys, err := NewKeySet(DedupConfig{Backend: tc.backend, ExpectedKeys: len(users), Dir: t.TempDir()})
+			require.NoError(t, err)
+			defer keys.Close()
+			filter := NewDuplicateFilterWithKeySet(tc.strategy, keys)
+
+			// When: 동시에 확인과 표시, 조회
+			var marked atomic.Int64
+			var wg sync.WaitGroup
+			for worker := 0; worker < workers; worker++ {
+				wg.Add(1)
+				go func(offset int) {
+					defer wg.Done()
+					for i := range users {
+						user := users[(i+offset*31)%len(users)]
+						if filter.CheckAndMark(user) {
+							marked.Add(1)
+						}
+						filter.IsProcessed(user)
+					}
+				}(worker)
+			}
+			wg.Wait()
+
+			// Then: 사용자마다 정확히 한 번만 처음 본 사용자로 판단
+			require.NoError(t, filter.Err())
+			assert.Equal(t, int64(len(users)), marked.Load())
+			assert.Equal(t, len(users), filter.GetProcessedCount())
+		})
+	}
+}
+
+func TestDuplicateFilter_FilterDuplicates_Concurrent(t *testing.T) {
+	// Given: 같은 사용자 묶음을 여러 작업자가 나누어 받은 경우
+	const workers = 8
+	users := createUniqueTestUsers(200)
+	filter := NewDuplicateFilter()
+
+	// When: 동시에 중복 제거
+	results := make([][]*domain.User, workers)
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			results[worker] = filter.FilterDuplicates(users)
+		}(worker)
+	}
+	wg.Wait()
+
+	// Then: 작업자 결과를 합치면 모든 사용자가 한 번씩
+	seen := make(map[string]int)
+	for _, unique := range results {
+		for _, user := range unique {
+			seen[user.Email]++
+		}
+	}
+	assert.Len(t, seen, len(users))
+	for email, count := range seen {
+		assert.Equal(t, 1, count, email)
+	}
+}
+
+func TestDuplicateFilter_SetStrategy(t *testing.T) {
+	// Given: 처리 전인 필터
+	filter := NewDuplicateFilter()
+
+	// When & Then: 처리 전에는 변경 가능
+	require.NoError(t, filter.SetStrategy(domain.ByPhone))
+	assert.Equal(t, domain.ByPhone, filter.GetStrategy())
+
+	// When & Then: 처리 중에는 변경 불가
+	filter.FilterDuplicates(createUniqueTestUsers(3))
+	assert.ErrorIs(t, filter.SetStrategy(domain.ByEmail), ErrStrategyInUse)
+	assert.Equal(t, domain.ByPhone, filter.GetStrategy())
+
+	// When & Then: 초기화 후 다시 변경 가능
+	filter.Reset()
+	require.NoError(t, filter.SetStrategy(domain.ByEmail))
+	assert.Equal(t, domain.ByEmail, filter.GetStrategy())
+}
+
+// 신용점수 상승(Y) 레코드를 대상으로 두 번 확인한 병합 결과
+func mergeUsers(t *testing.T, merger *Merger, users []*domain.User) []MergeDecision {
+	t.Helper()
+
+	for _, user := range users {
+		require.NoError(t, merger.Observe(user, user.CreditUp))
+	}
+	merger.Plan()
+
+	decisions := make([]MergeDecision, 0, len(users))
+	for _, user := range users {
+		decision, err := merger.Decide(user, user.CreditUp)
+		require.NoError(t, err)
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+// 남긴 레코드 위치
+func keptIndexes(decisions []MergeDecision) []int {
+	kept := make([]int, 0)
+	for i, decision := range decisions {
+		if decision.Kept {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+func TestMerger(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       MergePolicy
+		expectedKept []int
+		expectedSMS  []int
+	}{
+		{name: "처음 대상 레코드", policy: MergeFirstWins, expectedKept: []int{1, 3, 4, 7}, expectedSMS: []int{1, 3, 4, 7}},
+		{name: "마지막 대상 레코드", policy: MergeLastWins, expectedKept: []int{3, 5, 6, 7}, expectedSMS: []int{3, 5, 6, 7}},
+		{name: "상승 레코드 우선", policy: MergeAnyYWins, expectedKept: []int{1, 3, 4, 7}, expectedSMS: []int{1, 3, 4, 7}},
+		{name: "전화번호별 SMS", policy: MergeUnionPhones, expectedKept: []int{1, 3, 4, 7}, expectedSMS: []int{1, 3, 4, 6, 7}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 처음 레코드가 하락(N)인 중복 키, 값이 같은 중복 키, 중복 없는 사용자
+			users := []*domain.User{
+				createTestUser(t, "dup@example.com", "010-1111-1111", false),
+				createTestUser(t, "dup@example.com", "010-2222-2222", true),
+				createTestUser(t, "other@example.com", "010-9999-9999", false),
+				createTestUser(t, "other@example.com", "010-9999-9999", true),
+				createTestUser(t, "same@example.com", "010-8888-8888", true),
+				createTestUser(t, "same@example.com", "010-8888-8888", true),
+				createTestUser(t, "dup@example.com", "010-3333-3333", true),
+				createTestUser(t, "single@example.com", "010-7777-7777", true),
+			}
+			merger := NewMerger(domain.ByEmail, tc.policy, newMemoryKeySet())
+
+			// When: 두 번 확인하여 병합
+			decisions := mergeUsers(t, merger, users)
+
+			// Then: 대상 레코드끼리만 병합하여 키마다 하나를 남김
+			assert.Equal(t, tc.expectedKept, keptIndexes(decisions))
+			assert.Equal(t, 4, merger.Unique())
+			sms := make([]int, 0)
+			for i, decision := range decisions {
+				if decision.SMS {
+					sms = append(sms, i)
+				}
+			}
+			assert.Equal(t, tc.expectedSMS, sms)
+			assert.Equal(t, "dup@example.com", decisions[6].Key)
+			assert.Empty(t, decisions[7].Key)
+
+			// Then: 입력 레코드는 바꾸지 않음
+			assert.Same(t, users[1], decisions[1].Target)
+			assert.Equal(t, "010-1111-1111", users[0].PhoneNumber)
+
+			// Then: 제외 대상 레코드를 포함해 값이 다른 키를 충돌로 보고
+			conflicts := merger.Conflicts()
+			require.Len(t, conflicts, 2)
+			assert.Equal(t, "dup@example.com", conflicts[0].Key)
+			assert.Equal(t, []string{"phone_number", "credit_up"}, conflicts[0].Fields)
+			assert.Len(t, conflicts[0].Records, 3)
+			assert.Equal(t, "other@example.com", conflicts[1].Key)
+			assert.Equal(t, []string{"credit_up"}, conflicts[1].Fields)
+		})
+	}
+}
+
+func TestMerger_NoEligibleRecord(t *testing.T) {
+	// Given: 같은 키의 레코드가 모두 하락(N)
+	users := []*domain.User{
+		createTestUser(t, "down@example.com", "010-1111-1111", false),
+		createTestUser(t, "down@example.com", "010-2222-2222", false),
+	}
+	merger := NewMerger(domain.ByEmail, MergeFirstWins, newMemoryKeySet())
+
+	// When: 두 번 확인하여 병합
+	decisions := mergeUsers(t, merger, users)
+
+	// Then: 남긴 레코드 없이 충돌만 보고
+	assert.Empty(t, keptIndexes(decisions))
+	assert.Equal(t, 0, merger.Unique())
+	conflicts := merger.Conflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, -1, conflicts[0].Kept)
+}
+
+func TestMerger_DecideBeforeObserve(t *testing.T) {
+	// Given: 1차 확인 없이 2차 확인
+	merger := NewMerger(domain.ByEmail, MergeFirstWins, newMemoryKeySet())
+	user := createTestUser(t, "user@example.com", "010-1111-1111", true)
+
+	// When & Then: 1차 확인보다 많은 레코드, 계획 이후 추가는 오류
+	_, err := merger.Decide(user, true)
+	assert.Error(t, err)
+	assert.Error(t, merger.Observe(user, true))
+}
+
+func TestChannelMerger(t *testing.T) {
+	// Given: 이메일이 같고 전화번호가 다른 레코드, 전화번호가 같고 이메일이 다른 레코드 (한 레코드는 SMS 대상 아님)
+	users := []*domain.User{
+		createTestUser(t, "a@example.com", "010-1111-1111", true),
+		createTestUser(t, "a@example.com", "010-2222-2222", true),
+		createTestUser(t, "b@example.com", "010-1111-1111", true),
+		createTestUser(t, "c@example.com", "010-1111-1111", true),
+	}
+	sms := []bool{true, true, true, false}
+	merger := NewChannelMerger(MergeLastWins, newMemoryKeySet(), newMemoryKeySet())
+
+	// When: 채널별 대상 여부로 두 번 확인하여 병합
+	for i, user := range users {
+		require.NoError(t, merger.Observe(user, true, sms[i]))
+	}
+	emailKept := make([]int, 0)
+	smsKept := make([]int, 0)
+	for i, user := range users {
+		email, phone, err := merger.Decide(user, true, sms[i])
+		require.NoError(t, err)
+		if email {
+			emailKept = append(emailKept, i)
+		}
+		if phone {
+			smsKept = append(smsKept, i)
+		}
+	}
+
+	// Then: 채널 주소마다 정책(last-wins)으로 남기고 대상이 아닌 레코드는 고르지 않음
+	assert.Equal(t, []int{1, 2, 3}, emailKept)
+	assert.Equal(t, []int{1, 2}, smsKept)
+
+	// Then: 채널 주소별로 값이 다른 레코드를 충돌로 보고
+	conflicts := merger.Conflicts()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "email:a@example.com", conflicts[0].Key)
+	assert.Equal(t, []string{"phone_number"}, conflicts[0].Fields)
+	assert.Equal(t, 1, conflicts[0].Kept)
+	assert.Equal(t, "sms:010-1111-1111", conflicts[1].Key)
+	assert.Equal(t, []string{"email"}, conflicts[1].Fields)
+	assert.Equal(t, 1, conflicts[1].Kept)
+}
+
+func TestMerger_ByIdentity(t *testing.T) {
+	// Given: A(e1,p1), B(e2,p1), C(e2,p2)는 연락처를 건너 이어진 한 사람, D는 별개
+	users := []*domain.User{
+		createTestUser(t, "e1@example.com", "010-1111-1111", true),
+		createTestUser(t, "e2@example.com", "010-1111-1111", true),
+		createTestUser(t, "other@example.com", "010-9999-9999", true),
+		createTestUser(t, "e2@example.com", "010-2222-2222", true),
+	}
+	merger := NewMerger(domain.ByIdentity, MergeFirstWins, nil)
+
+	// When: 두 번 확인하여 병합
+	decisions := mergeUsers(t, merger, users)
+
+	// Then: 하나로 묶고 채널별로 가장 많이 쓰인 연락처의 복사본으로 전송
+	assert.Equal(t, []int{0, 2}, keptIndexes(decisions))
+	assert.Equal(t, 2, merger.Unique())
+	target := decisions[0].Target
+	assert.NotSame(t, users[0], target)
+	assert.Equal(t, "e2@example.com", target.Email)
+	assert.Equal(t, "010-1111-1111", target.PhoneNumber)
+	assert.Equal(t, "e1@example.com", users[0].Email)
+	assert.Same(t, users[2], decisions[2].Target)
+
+	clusters := merger.Clusters()
+	require.Len(t, clusters, 1)
+	assert.Equal(t, IdentityCluster{
+		Key:         "email:e1@example.com",
+		Emails:      []string{"e1@example.com", "e2@example.com"},
+		Phones:      []string{"010-1111-1111", "010-2222-2222"},
+		Records:     3,
+		Email:       "e2@example.com",
+		PhoneNumber: "010-1111-1111",
+	}, clusters[0])
+
+	conflicts := merger.Conflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, []string{"email", "phone_number"}, conflicts[0].Fields)
+	assert.Equal(t, "e1@example.com", conflicts[0].Records[0].Email)
+}
+
+func TestMerger_ByIdentityUnionPhones(t *testing.T) {
+	// Given: 전화번호가 다른 같은 사람의 레코드
+	users := []*domain.User{
+		createTestUser(t, "e1@example.com", "010-1111-1111", true),
+		createTestUser(t, "e1@example.com", "010-2222-2222", true),
+		createTestUser(t, "e2@example.com", "010-2222-2222", true),
+	}
+	merger := NewMerger(domain.ByIdentity, MergeUnionPhones, nil)
+
+	// When: 두 번 확인하여 병합
+	decisions := mergeUsers(t, merger, users)
+
+	// Then: 남긴 레코드는 대표 번호로, 대표 번호가 아닌 레코드는 자기 번호로 SMS만 전송
+	assert.Equal(t, []int{0}, keptIndexes(decisions))
+	assert.Equal(t, "010-2222-2222", decisions[0].Target.PhoneNumber)
+	assert.True(t, decisions[0].SMS)
+	assert.False(t, decisions[1].SMS)
+	assert.False(t, decisions[2].SMS)
+}
+
+func TestParseMergePolicy(t *testing.T) {
+	// Given & When & Then: 기본값, 대소문자, 잘못된 정책
+	policy, err := ParseMergePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, MergeFirstWins, policy)
+
+	policy, err = ParseMergePolicy("Any-Y-Wins")
+	require.NoError(t, err)
+	assert.Equal(t, MergeAnyYWins, policy)
+
+	_, err = ParseMergePolicy("newest")
+	assert.Error(t, err)
+}
+
+func TestCreditProcessor_FilterByScore(t *testing.T) {
+	// Given: 점수 정보가 다양한 사용자 목록
+	processor := NewCreditProcessor()
+	users := []*domain.User{
+		createScoredTestUser("a@example.com", true, 790, 805),  // +15, 800 돌파
+		createScoredTestUser("b@example.com", true, 810, 815),  // +5
+		createScoredTestUser("c@example.com", false, 700, 720), // 상승 플래그 없음
+		createScoredTestUser("d@example.com", true, 600, 640),  // +40
+		createTestUsers([]bool{true})[0],                       // 점수 정보 없음
+	}
+
+	testCases := []struct {
+		name           string
+		filter         func([]*domain.User) []*domain.User
+		expectedEmails []string
+	}{
+		{
+			name: "최소 상승폭 10점",
+			filter: func(users []*domain.User) []*domain.User {
+				return processor.FilterByMinDelta(users, 10)
+			},
+			expectedEmails: []string{"a@example.com", "d@example.com"},
+		},
+		{
+			name: "800점 돌파",
+			filter: func(users []*domain.User) []*domain.User {
+				return processor.FilterByThresholdCrossing(users, 800)
+			},
+			expectedEmails: []string{"a@example.com"},
+		},
+		{
+			name: "조건을 만족하는 사용자 없음",
+			filter: func(users []*domain.User) []*domain.User {
+				return processor.FilterByMinDelta(users, 100)
+			},
+			expectedEmails: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 점수 기준 필터링 실행
+			filtered := tc.filter(users)
+
+			// Then: 결과 검증
+			if tc.expectedEmails == nil {
+				assert.Nil(t, filtered)
+				return
+			}
+
+			emails := make([]string, 0, len(filtered))
+			for _, user := range filtered {
+				emails = append(emails, user.Email)
+			}
+			assert.Equal(t, tc.expectedEmails, emails)
+		})
+	}
+}
+
+func TestCreditProcessor_EvaluateUsers_WithRules(t *testing.T) {
+	// Given: 10점 이상 상승한 사용자만 알림, 20점 이상 상승한 사용자에게만 SMS
+	rules, err := rule.BuildRuleSet(rule.RuleSetConfig{
+		Eligibility: &rule.Config{All: []rule.Config{{Rule: "credit_up"}, {Rule: "min_delta", Value: intValue(10)}}},
+		Channels: map[string]rule.Config{
+			"sms": {Rule: "min_delta", Value: intValue(20)},
+		},
+	})
+	require.NoError(t, err)
+
+	processor := NewCreditProcessorWithRules(rules)
+	users := []*domain.User{
+		createScoredTestUser("a@example.com", true, 790, 805),
+		createScoredTestUser("b@example.com", true, 810, 815),
+		createScoredTestUser("c@example.com", false, 700, 720),
+	}
+
+	// When: 규칙 평가
+	eligible, exclusions := processor.EvaluateUsers(users)
+
+	// Then: 대상자와 제외 사유 검증
+	require.Len(t, eligible, 1)
+	assert.Equal(t, "a@example.com", eligible[0].Email)
+
+	require.Len(t, exclusions, 2)
+	assert.Equal(t, "b@example.com", exclusions[0].User.Email)
+	assert.Equal(t, "min_delta(10)", exclusions[0].Rule)
+	assert.Equal(t, "c@example.com", exclusions[1].User.Email)
+	assert.Equal(t, "credit_up", exclusions[1].Rule)
+
+	// When: 채널별 규칙 평가
+	emailUsers, _ := processor.EvaluateChannelUsers(eligible, domain.EmailChannel)
+	smsUsers, smsExclusions := processor.EvaluateChannelUsers(eligible, domain.SMSChannel)
+
+	// Then: 상승폭이 작은 사용자는 SMS 대상에서 제외
+	assert.Len(t, emailUsers, 1)
+	assert.Nil(t, smsUsers)
+	require.Len(t, smsExclusions, 1)
+	assert.Equal(t, "min_delta(20)", smsExclusions[0].Rule)
+}
+
 // 테스트 헬퍼 함수들
 
 func createTestUsers(creditUpStates []bool) []*domain.User {
@@ -299,3 +842,20 @@ func createDifferentEmailSamePhoneUsers() []*domain.User {
 
 	return []*domain.User{user1, user2}
 }
+
+func createScoredTestUser(email string, creditUp bool, previous, current int) *domain.User {
+	user, _ := domain.NewUser(email, "010-0000-0000", creditUp)
+	user.Score, _ = domain.NewCreditScore(previous, current)
+	return user
+}
+
+func createTestUser(t *testing.T, email, phone string, creditUp bool) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(email, phone, creditUp)
+	require.NoError(t, err)
+	return user
+}
+
+func intValue(v int) *int {
+	return &v
+}
@@ -0,0 +1,269 @@
+package reconcile
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+var ErrMismatch = errors.New("입력과 출력 파일이 일치하지 않습니다")
+
+// 실행 뒤에 대상 판단 설정이 바뀌어 실행과 같은 조건으로 대상을 다시 구하지 못함
+var ErrConfigChanged = errors.New("실행 뒤에 대상 판단 설정이 바뀌었습니다")
+
+// 출력 파일에 있어서는 안 되는 주소의 분류
+type UnexpectedReason string
+
+const (
+	ReasonNotEligible UnexpectedReason = "not_eligible" // 입력에는 있지만 알림 대상이 아님 (미상승, 규칙 제외, 수신 거부, 중복)
+	ReasonDeferred    UnexpectedReason = "deferred"     // 허용 시간대 밖이라 대기열에 보관했는데 출력에도 있음 (꺼낼 때 다시 전송)
+	ReasonUnknown     UnexpectedReason = "unknown"      // 입력에 없고 대기열에서 꺼낸 주소도 아님
+)
+
+// 채널 하나의 기대 수신자 (입력 전체 주소와 그중 알림을 받아야 하는 주소)
+type Expectation struct {
+	expected map[string]struct{}
+	order    []string // 누락 목록을 입력 순서로 보여주기 위한 기대 주소 순서
+	known    map[string]struct{}
+	deferred map[string]struct{}
+	released func(address string) bool
+}
+
+func NewExpectation() *Expectation {
+	return &Expectation{
+		expected: make(map[string]struct{}),
+		known:    make(map[string]struct{}),
+		deferred: make(map[string]struct{}),
+	}
+}
+
+// 대기열에 보관되어 이 실행의 출력에 없어야 하는 주소 (대상이어도 누락이 아님)
+func (e *Expectation) Defer(address string) {
+	e.deferred[address] = struct{}{}
+}
+
+// 입력에 없지만 이전 실행의 대기열에서 꺼내 보낸 주소인지 확인하는 함수 (감사 기록의 해시로 확인)
+func (e *Expectation) SetReleased(released func(address string) bool) {
+	e.released = released
+}
+
+// 입력에 나온 주소 (eligible이면 알림을 받아야 함, 같은 주소가 한 번이라도 대상이면 대상)
+func (e *Expectation) Add(address string, eligible bool) {
+	e.known[address] = struct{}{}
+	if !eligible {
+		return
+	}
+	if _, exists := e.expected[address]; !exists {
+		e.expected[address] = struct{}{}
+		e.order = append(e.order, address)
+	}
+}
+
+type Duplicate struct {
+	Address string `json:"address"`
+	Count   int    `json:"count"`
+}
+
+type Unexpected struct {
+	Address string           `json:"address"`
+	Reason  UnexpectedReason `json:"reason"`
+}
+
+// 채널 하나의 대사 결과
+type ChannelReport struct {
+	Expected   int          `json:"expected"`  // 알림을 받아야 하는 주소 수
+	Delivered  int          `json:"delivered"` // 출력 파일의 줄 수
+	Released   int          `json:"released"`  // 대기열에서 꺼내 보낸 주소의 출력 줄 수
+	Missing    []string     `json:"missing"`   // 대상인데 출력에 없음 (입력 순서)
+	Deferred   []string     `json:"deferred"`  // 대상이지만 대기열에 보관되어 출력에 없음 (입력 순서)
+	Duplicates []Duplicate  `json:"duplicates"`
+	Unexpected []Unexpected `json:"unexpected"` // 출력 순서
+}
+
+func (r ChannelReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Duplicates) == 0 && len(r.Unexpected) == 0
+}
+
+// 출력 파일에 기록된 주소 목록과 비교
+func (e *Expectation) Compare(delivered []string) ChannelReport {
+	report := ChannelReport{
+		Expected:   len(e.expected),
+		Delivered:  len(delivered),
+		Missing:    make([]string, 0),
+		Deferred:   make([]string, 0),
+		Duplicates: make([]Duplicate, 0),
+		Unexpected: make([]Unexpected, 0),
+	}
+
+	counts := make(map[string]int, len(delivered))
+	order := make([]string, 0, len(delivered))
+	for _, address := range delivered {
+		if counts[address] == 0 {
+			order = append(order, address)
+		}
+		counts[address]++
+	}
+
+	for _, address := range order {
+		if counts[address] > 1 {
+			report.Duplicates = append(report.Duplicates, Duplicate{Address: address, Count: counts[address]})
+		}
+		_, expected := e.expected[address]
+		_, deferred := e.deferred[address]
+		if expected && !deferred {
+			continue
+		}
+
+		reason := ReasonUnknown
+		if _, exists := e.known[address]; exists {
+			reason = ReasonNotEligible
+		}
+		switch {
+		case deferred:
+			reason = ReasonDeferred
+		case reason == ReasonUnknown && e.released != nil && e.released(address):
+			report.Released += counts[address]
+			continue
+		}
+		report.Unexpected = append(report.Unexpected, Unexpected{Address: address, Reason: reason})
+	}
+
+	for _, address := range e.order {
+		if counts[address] > 0 {
+			continue
+		}
+		if _, deferred := e.deferred[address]; deferred {
+			report.Deferred = append(report.Deferred, address)
+		} else {
+			report.Missing = append(report.Missing, address)
+		}
+	}
+
+	return report
+}
+
+// 처리 흐름(규칙, 중복 제거 등)을 거치지 않고 입력 파일만으로 확인하는 채널 하나의 검사
+// 대상 판단 코드나 설정이 잘못되어도 걸러지도록 출력의 주소는 입력에서 신용점수 상승(Y)인 레코드의 주소이고 주소마다 한 줄이어야 함
+// 주소는 정규화하여 비교 (이메일은 대소문자, 전화번호는 하이픈 무관)
+type InputCheck struct {
+	creditUp map[string]struct{}
+	known    map[string]struct{}
+	released func(address string) bool
+}
+
+func NewInputCheck() *InputCheck {
+	return &InputCheck{
+		creditUp: make(map[string]struct{}),
+		known:    make(map[string]struct{}),
+	}
+}
+
+// 입력 레코드의 주소와 신용점수 상승 여부
+func (c *InputCheck) Add(address string, creditUp bool) {
+	address = domain.NormalizeContact(address)
+	c.known[address] = struct{}{}
+	if creditUp {
+		c.creditUp[address] = struct{}{}
+	}
+}
+
+// 입력에 없지만 이전 실행의 대기열에서 꺼내 보낸 주소인지 확인하는 함수
+func (c *InputCheck) SetReleased(released func(address string) bool) {
+	c.released = released
+}
+
+// 입력만으로 확인한 결과
+type InputReport struct {
+	NotCreditUp []string    `json:"not_credit_up"` // 입력에 있지만 신용점수 상승(Y)인 레코드가 없는 주소 (출력 순서)
+	NotInInput  []string    `json:"not_in_input"`  // 입력에 없는 주소 (출력 순서, 대기열에서 꺼낸 주소는 두 검사 모두 제외)
+	Duplicates  []Duplicate `json:"duplicates"`    // 정규화한 주소가 같은 줄이 2번 이상
+}
+
+func (r InputReport) OK() bool {
+	return len(r.NotCreditUp) == 0 && len(r.NotInInput) == 0 && len(r.Duplicates) == 0
+}
+
+func (c *InputCheck) Compare(delivered []string) InputReport {
+	report := InputReport{
+		NotCreditUp: make([]string, 0),
+		NotInInput:  make([]string, 0),
+		Duplicates:  make([]Duplicate, 0),
+	}
+
+	counts := make(map[string]int, len(delivered))
+	order := make([]string, 0, len(delivered))
+	first := make(map[string]string, len(delivered)) // 정규화한 주소 → 출력에 처음 나온 주소
+	for _, address := range delivered {
+		normalized := domain.NormalizeContact(address)
+		if counts[normalized] == 0 {
+			order = append(order, normalized)
+			first[normalized] = address
+		}
+		counts[normalized]++
+	}
+
+	for _, normalized := range order {
+		address := first[normalized]
+		if counts[normalized] > 1 {
+			report.Duplicates = append(report.Duplicates, Duplicate{Address: address, Count: counts[normalized]})
+		}
+		if _, ok := c.creditUp[normalized]; ok {
+			continue
+		}
+		if c.released != nil && c.released(address) {
+			continue
+		}
+		if _, known := c.known[normalized]; known {
+			report.NotCreditUp = append(report.NotCreditUp, address)
+		} else {
+			report.NotInInput = append(report.NotInInput, address)
+		}
+	}
+	return report
+}
+
+// 한 줄에 주소 하나인 출력 파일 읽기 (빈 줄 무시)
+func ReadAddresses(r io.Reader) ([]string, error) {
+	addresses := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if address := strings.TrimSpace(scanner.Text()); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "출력 파일 읽기 오류")
+	}
+	return addresses, nil
+}
+
+// 경로가 비어 있거나 파일이 없으면 아무에게도 보내지 않은 것으로 봄
+func ReadAddressFile(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "출력 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close output file")
+		}
+	}()
+
+	addresses, err := ReadAddresses(file)
+	return addresses, errors.Wrap(err, path)
+}
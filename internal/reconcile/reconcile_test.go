@@ -0,0 +1,132 @@
+package reconcile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectation_Compare(t *testing.T) {
+	// Given: 대상 3명(그중 한 주소는 대상 아닌 레코드에도 있음)과 대상 아닌 1명
+	expectation := NewExpectation()
+	expectation.Add("a@example.com", true)
+	expectation.Add("b@example.com", true)
+	expectation.Add("down@example.com", false)
+	expectation.Add("a@example.com", false)
+	expectation.Add("c@example.com", true)
+
+	// When: 누락, 중복, 대상 아님, 입력에 없는 주소가 섞인 출력과 비교
+	report := expectation.Compare([]string{
+		"a@example.com",
+		"down@example.com",
+		"a@example.com",
+		"stranger@example.com",
+		"c@example.com",
+		"a@example.com",
+	})
+
+	// Then: 유형별로 분류
+	assert.False(t, report.OK())
+	assert.Equal(t, 3, report.Expected)
+	assert.Equal(t, 6, report.Delivered)
+	assert.Equal(t, []string{"b@example.com"}, report.Missing)
+	assert.Equal(t, []Duplicate{{Address: "a@example.com", Count: 3}}, report.Duplicates)
+	assert.Equal(t, []Unexpected{
+		{Address: "down@example.com", Reason: ReasonNotEligible},
+		{Address: "stranger@example.com", Reason: ReasonUnknown},
+	}, report.Unexpected)
+}
+
+func TestExpectation_Compare_OK(t *testing.T) {
+	// Given: 대상 2명
+	expectation := NewExpectation()
+	expectation.Add("010-0000-0001", true)
+	expectation.Add("010-0000-0002", true)
+	expectation.Add("010-0000-0003", false)
+
+	// When: 순서와 관계없이 한 번씩 전송한 출력과 비교
+	report := expectation.Compare([]string{"010-0000-0002", "010-0000-0001"})
+
+	// Then: 일치
+	assert.True(t, report.OK())
+	assert.Empty(t, report.Missing)
+}
+
+func TestExpectation_Compare_DeferredAndReleased(t *testing.T) {
+	// Given: 대상 3명 중 2명은 허용 시간대 밖이라 대기열 보관, 이전 실행의 대기열에서 꺼낸 번호 1개
+	expectation := NewExpectation()
+	expectation.Add("010-0000-0001", true)
+	expectation.Add("010-0000-0002", true)
+	expectation.Add("010-0000-0003", true)
+	expectation.Defer("010-0000-0002")
+	expectation.Defer("010-0000-0003")
+	expectation.SetReleased(func(address string) bool {
+		return address == "010-9999-9999"
+	})
+
+	// When: 즉시 보낸 번호, 대기열에서 꺼낸 번호, 대기열에 보관했는데 보낸 번호, 입력에 없는 번호가 있는 출력과 비교
+	report := expectation.Compare([]string{"010-0000-0001", "010-9999-9999", "010-0000-0003", "010-5555-5555"})
+
+	// Then: 보관한 번호는 누락이 아니고, 꺼낸 번호는 대상 아님이 아님
+	assert.Empty(t, report.Missing)
+	assert.Equal(t, []string{"010-0000-0002"}, report.Deferred)
+	assert.Equal(t, 1, report.Released)
+	assert.Equal(t, []Unexpected{
+		{Address: "010-0000-0003", Reason: ReasonDeferred},
+		{Address: "010-5555-5555", Reason: ReasonUnknown},
+	}, report.Unexpected)
+}
+
+func TestInputCheck_Compare(t *testing.T) {
+	// Given: 상승(Y) 2명, 미상승(N) 1명인 입력과 이전 실행의 대기열에서 꺼낸 번호
+	check := NewInputCheck()
+	check.Add("010-0000-0001", true)
+	check.Add("010-0000-0002", true)
+	check.Add("010-0000-0003", false)
+	check.SetReleased(func(address string) bool {
+		return address == "010-9999-9999"
+	})
+
+	// When: 표기만 다른 같은 번호, 미상승 번호, 꺼낸 번호, 입력에 없는 번호가 있는 출력과 비교
+	report := check.Compare([]string{"010-0000-0001", "01000000001", "010-0000-0003", "010-9999-9999", "010-5555-5555", "010-0000-0002"})
+
+	// Then: 정규화한 주소로 중복을 찾고 미상승, 입력에 없는 주소를 분류
+	assert.False(t, report.OK())
+	assert.Equal(t, []Duplicate{{Address: "010-0000-0001", Count: 2}}, report.Duplicates)
+	assert.Equal(t, []string{"010-0000-0003"}, report.NotCreditUp)
+	assert.Equal(t, []string{"010-5555-5555"}, report.NotInInput)
+
+	// When & Then: 상승 레코드의 주소를 한 번씩 보낸 출력은 일치 (대소문자 무관)
+	emails := NewInputCheck()
+	emails.Add("User@Example.com", true)
+	assert.True(t, emails.Compare([]string{"user@example.com"}).OK())
+}
+
+func TestReadAddresses(t *testing.T) {
+	// Given & When: 빈 줄과 공백이 섞인 출력
+	addresses, err := ReadAddresses(strings.NewReader("a@example.com\n\n  b@example.com \n"))
+
+	// Then: 주소만
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, addresses)
+}
+
+func TestReadAddressFile(t *testing.T) {
+	// Given: 없는 파일과 있는 파일
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notified_emails.txt")
+	require.NoError(t, os.WriteFile(path, []byte("a@example.com\n"), 0644))
+
+	// When & Then: 없는 파일은 전송 없음
+	addresses, err := ReadAddressFile(filepath.Join(dir, "missing.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+
+	addresses, err = ReadAddressFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, addresses)
+}
@@ -0,0 +1,205 @@
+package rule
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 규칙 설정 파일의 노드 (rule / all / any / not 중 하나만 지정)
+type Config struct {
+	Rule  string   `json:"rule,omitempty"`
+	Value *int     `json:"value,omitempty"` // min_delta, threshold_crossed의 기준 (필수)
+	All   []Config `json:"all,omitempty"`
+	Any   []Config `json:"any,omitempty"`
+	Not   *Config  `json:"not,omitempty"`
+}
+
+type RuleSetConfig struct {
+	Eligibility *Config           `json:"eligibility"`
+	Channels    map[string]Config `json:"channels"`
+}
+
+// 공통 알림 대상 규칙과 채널별 추가 규칙
+type RuleSet struct {
+	eligibility Rule
+	channels    map[domain.NotificationChannel]Rule
+}
+
+// 기존 동작과 동일하게 신용점수 상승 여부만 확인
+func DefaultRuleSet() *RuleSet {
+	return NewRuleSet(CreditUp(), nil)
+}
+
+func NewRuleSet(eligibility Rule, channels map[domain.NotificationChannel]Rule) *RuleSet {
+	if channels == nil {
+		channels = make(map[domain.NotificationChannel]Rule)
+	}
+	return &RuleSet{
+		eligibility: eligibility,
+		channels:    channels,
+	}
+}
+
+func LoadRuleSet(path string) (*RuleSet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "규칙 설정 파일을 읽을 수 없습니다")
+	}
+
+	var cfg RuleSetConfig
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, errors.Wrap(err, "규칙 설정 파일 형식 오류")
+	}
+
+	return BuildRuleSet(cfg)
+}
+
+func BuildRuleSet(cfg RuleSetConfig) (*RuleSet, error) {
+	eligibility := CreditUp()
+	if cfg.Eligibility != nil {
+		built, err := Build(*cfg.Eligibility)
+		if err != nil {
+			return nil, errors.Wrap(err, "공통 규칙 생성 실패")
+		}
+		eligibility = built
+	}
+
+	channels := make(map[domain.NotificationChannel]Rule, len(cfg.Channels))
+	for name, channelCfg := range cfg.Channels {
+		channel, err := domain.ParseNotificationChannel(name)
+		if err != nil {
+			return nil, err
+		}
+
+		built, err := Build(channelCfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s 채널 규칙 생성 실패", name)
+		}
+		channels[channel] = built
+	}
+
+	return NewRuleSet(eligibility, channels), nil
+}
+
+func Build(cfg Config) (Rule, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(cfg.All) > 0:
+		rules, err := buildAll(cfg.All)
+		if err != nil {
+			return nil, err
+		}
+		return All(rules...), nil
+	case len(cfg.Any) > 0:
+		rules, err := buildAll(cfg.Any)
+		if err != nil {
+			return nil, err
+		}
+		return Any(rules...), nil
+	case cfg.Not != nil:
+		inner, err := Build(*cfg.Not)
+		if err != nil {
+			return nil, err
+		}
+		return Not(inner), nil
+	}
+
+	switch cfg.Rule {
+	case "credit_up":
+		return CreditUp(), nil
+	case "min_delta":
+		if cfg.Value == nil {
+			return nil, errors.New("min_delta 규칙에는 value(최소 상승폭)가 필요합니다")
+		}
+		return MinDelta(*cfg.Value), nil
+	case "threshold_crossed":
+		if cfg.Value == nil {
+			return nil, errors.New("threshold_crossed 규칙에는 value(기준 점수)가 필요합니다")
+		}
+		return ThresholdCrossed(*cfg.Value), nil
+	case "has_email":
+		return HasEmail(), nil
+	case "has_phone":
+		return HasPhone(), nil
+	case "not_opted_out":
+		return nil, errors.New("not_opted_out 규칙은 지원하지 않습니다 (수신 거부는 -suppression 목록으로 지정)")
+	default:
+		return nil, errors.Errorf("알 수 없는 규칙: %q", cfg.Rule)
+	}
+}
+
+// rule, all, any, not 중 정확히 하나만 지정되어야 함
+func (cfg Config) validate() error {
+	kinds := make([]string, 0, 4)
+	if cfg.Rule != "" {
+		kinds = append(kinds, "rule")
+	}
+	if cfg.All != nil {
+		kinds = append(kinds, "all")
+	}
+	if cfg.Any != nil {
+		kinds = append(kinds, "any")
+	}
+	if cfg.Not != nil {
+		kinds = append(kinds, "not")
+	}
+
+	switch len(kinds) {
+	case 0:
+		return errors.New("규칙 노드에 rule, all, any, not 중 하나를 지정해야 합니다")
+	case 1:
+		if (cfg.All != nil && len(cfg.All) == 0) || (cfg.Any != nil && len(cfg.Any) == 0) {
+			return errors.New("all, any에는 규칙이 하나 이상 필요합니다")
+		}
+		return nil
+	default:
+		return errors.Errorf("규칙 노드에 %s 를 함께 지정할 수 없습니다 (하나만 지정)", strings.Join(kinds, ", "))
+	}
+}
+
+func buildAll(configs []Config) ([]Rule, error) {
+	rules := make([]Rule, 0, len(configs))
+	for _, cfg := range configs {
+		r, err := Build(cfg)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// 공통 규칙에 규칙을 추가한 새 RuleSet 반환 (채널 규칙은 공유)
+func (rs *RuleSet) With(r Rule) *RuleSet {
+	return &RuleSet{
+		eligibility: All(rs.eligibility, r),
+		channels:    rs.channels,
+	}
+}
+
+func (rs *RuleSet) Evaluate(user *domain.User) Decision {
+	return rs.eligibility.Evaluate(user)
+}
+
+// 공통 규칙과 채널 규칙을 모두 만족해야 해당 채널로 알림
+func (rs *RuleSet) EvaluateChannel(user *domain.User, channel domain.NotificationChannel) Decision {
+	decision := rs.Evaluate(user)
+	if !decision.Eligible {
+		return decision
+	}
+
+	channelRule, exists := rs.channels[channel]
+	if !exists {
+		return decision
+	}
+
+	return channelRule.Evaluate(user)
+}
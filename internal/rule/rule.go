@@ -0,0 +1,146 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 평가 결과와 제외 사유가 된 규칙 이름
+type Decision struct {
+	Eligible bool
+	Rule     string
+}
+
+type Rule interface {
+	Name() string
+	Evaluate(user *domain.User) Decision
+}
+
+type predicateRule struct {
+	name      string
+	predicate func(user *domain.User) bool
+}
+
+func (pr *predicateRule) Name() string {
+	return pr.name
+}
+
+func (pr *predicateRule) Evaluate(user *domain.User) Decision {
+	return Decision{
+		Eligible: pr.predicate(user),
+		Rule:     pr.name,
+	}
+}
+
+func CreditUp() Rule {
+	return &predicateRule{
+		name:      "credit_up",
+		predicate: (*domain.User).IsEligibleForNotification,
+	}
+}
+
+// 점수 정보가 없으면 불만족
+func MinDelta(minDelta int) Rule {
+	return &predicateRule{
+		name: fmt.Sprintf("min_delta(%d)", minDelta),
+		predicate: func(user *domain.User) bool {
+			return user.HasScore() && user.ScoreDelta() >= minDelta
+		},
+	}
+}
+
+func ThresholdCrossed(threshold int) Rule {
+	return &predicateRule{
+		name: fmt.Sprintf("threshold_crossed(%d)", threshold),
+		predicate: func(user *domain.User) bool {
+			return user.CrossedThreshold(threshold)
+		},
+	}
+}
+
+// 보낼 수 있는 이메일 주소가 있는 경우 (채널 규칙에서 not과 함께 "이메일이 없는 사용자에게만 SMS" 등으로 사용)
+func HasEmail() Rule {
+	return &predicateRule{
+		name:      "has_email",
+		predicate: (*domain.User).HasEmail,
+	}
+}
+
+// 보낼 수 있는 휴대전화 번호가 있는 경우
+func HasPhone() Rule {
+	return &predicateRule{
+		name:      "has_phone",
+		predicate: (*domain.User).HasPhoneNumber,
+	}
+}
+
+type allRule struct {
+	rules []Rule
+}
+
+// 모든 규칙을 만족해야 하며, 처음 불만족한 규칙을 제외 사유로 반환
+func All(rules ...Rule) Rule {
+	return &allRule{rules: rules}
+}
+
+func (ar *allRule) Name() string {
+	return "all(" + joinNames(ar.rules) + ")"
+}
+
+func (ar *allRule) Evaluate(user *domain.User) Decision {
+	for _, r := range ar.rules {
+		if decision := r.Evaluate(user); !decision.Eligible {
+			return decision
+		}
+	}
+	return Decision{Eligible: true, Rule: ar.Name()}
+}
+
+type anyRule struct {
+	rules []Rule
+}
+
+// 하나 이상의 규칙을 만족하면 통과
+func Any(rules ...Rule) Rule {
+	return &anyRule{rules: rules}
+}
+
+func (ar *anyRule) Name() string {
+	return "any(" + joinNames(ar.rules) + ")"
+}
+
+func (ar *anyRule) Evaluate(user *domain.User) Decision {
+	for _, r := range ar.rules {
+		if decision := r.Evaluate(user); decision.Eligible {
+			return decision
+		}
+	}
+	return Decision{Eligible: false, Rule: ar.Name()}
+}
+
+type notRule struct {
+	rule Rule
+}
+
+func Not(r Rule) Rule {
+	return &notRule{rule: r}
+}
+
+func (nr *notRule) Name() string {
+	return "not(" + nr.rule.Name() + ")"
+}
+
+func (nr *notRule) Evaluate(user *domain.User) Decision {
+	decision := nr.rule.Evaluate(user)
+	return Decision{Eligible: !decision.Eligible, Rule: nr.Name()}
+}
+
+func joinNames(rules []Rule) string {
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Name())
+	}
+	return strings.Join(names, ",")
+}
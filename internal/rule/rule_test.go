@@ -0,0 +1,268 @@
+package rule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+func TestRule_Evaluate(t *testing.T) {
+	// Given: 점수가 23점 상승한 사용자
+	user := createScoredUser("user@example.com", "010-1234-5678", 780, 803)
+
+	testCases := []struct {
+		name             string
+		rule             Rule
+		expectedEligible bool
+		expectedRule     string
+	}{
+		{
+			name:             "신용점수 상승",
+			rule:             CreditUp(),
+			expectedEligible: true,
+			expectedRule:     "credit_up",
+		},
+		{
+			name:             "최소 상승폭 미달",
+			rule:             MinDelta(30),
+			expectedEligible: false,
+			expectedRule:     "min_delta(30)",
+		},
+		{
+			name:             "AND 조합 - 처음 실패한 규칙 보고",
+			rule:             All(CreditUp(), MinDelta(30), ThresholdCrossed(800)),
+			expectedEligible: false,
+			expectedRule:     "min_delta(30)",
+		},
+		{
+			name:             "OR 조합 - 하나라도 만족",
+			rule:             Any(MinDelta(30), ThresholdCrossed(800)),
+			expectedEligible: true,
+			expectedRule:     "threshold_crossed(800)",
+		},
+		{
+			name:             "OR 조합 - 모두 불만족",
+			rule:             Any(MinDelta(30), ThresholdCrossed(900)),
+			expectedEligible: false,
+			expectedRule:     "any(min_delta(30),threshold_crossed(900))",
+		},
+		{
+			name:             "NOT 조합",
+			rule:             Not(MinDelta(30)),
+			expectedEligible: true,
+			expectedRule:     "not(min_delta(30))",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 규칙 평가
+			decision := tc.rule.Evaluate(user)
+
+			// Then: 결과 및 사유 검증
+			assert.Equal(t, tc.expectedEligible, decision.Eligible)
+			assert.Equal(t, tc.expectedRule, decision.Rule)
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      Config
+		expectError bool
+		expected    string
+	}{
+		{
+			name: "중첩 규칙 생성",
+			config: Config{All: []Config{
+				{Rule: "credit_up"},
+				{Any: []Config{{Rule: "min_delta", Value: intValue(10)}, {Rule: "threshold_crossed", Value: intValue(800)}}},
+				{Not: &Config{Rule: "threshold_crossed", Value: intValue(900)}},
+			}},
+			expected: "all(credit_up,any(min_delta(10),threshold_crossed(800)),not(threshold_crossed(900)))",
+		},
+		{
+			name:     "연락처 규칙 생성",
+			config:   Config{Any: []Config{{Rule: "has_phone"}, {Not: &Config{Rule: "has_email"}}}},
+			expected: "any(has_phone,not(has_email))",
+		},
+		{
+			name:        "알 수 없는 규칙",
+			config:      Config{Rule: "unknown"},
+			expectError: true,
+		},
+		{
+			name:        "중첩된 알 수 없는 규칙",
+			config:      Config{All: []Config{{Rule: "credit_up"}, {Rule: ""}}},
+			expectError: true,
+		},
+		{
+			name:        "한 노드에 여러 종류 지정",
+			config:      Config{Rule: "credit_up", Not: &Config{Rule: "min_delta", Value: intValue(10)}},
+			expectError: true,
+		},
+		{
+			name:        "빈 all",
+			config:      Config{All: []Config{}},
+			expectError: true,
+		},
+		{
+			name:        "기준 없는 min_delta",
+			config:      Config{Rule: "min_delta"},
+			expectError: true,
+		},
+		{
+			name:        "중첩된 기준 없는 threshold_crossed",
+			config:      Config{Any: []Config{{Rule: "credit_up"}, {Not: &Config{Rule: "threshold_crossed"}}}},
+			expectError: true,
+		},
+		{
+			name:     "기준 0은 지정한 값",
+			config:   Config{Rule: "min_delta", Value: intValue(0)},
+			expected: "min_delta(0)",
+		},
+		{
+			name:        "수신 거부는 규칙으로 지정할 수 없음",
+			config:      Config{Rule: "not_opted_out"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 설정으로부터 규칙 생성
+			r, err := Build(tc.config)
+
+			// Then: 결과 검증
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r.Name())
+		})
+	}
+}
+
+func TestRuleSet_EvaluateChannel(t *testing.T) {
+	// Given: 30점 이상 상승한 사용자에게만 SMS를 보내는 규칙
+	ruleSet, err := BuildRuleSet(RuleSetConfig{
+		Channels: map[string]Config{
+			"sms": {Rule: "min_delta", Value: intValue(30)},
+		},
+	})
+	require.NoError(t, err)
+
+	smallRise := createScoredUser("small@example.com", "010-1234-5678", 780, 803)
+	largeRise := createScoredUser("large@example.com", "010-1234-5679", 700, 760)
+	notCreditUp := &domain.User{Email: "down@example.com", PhoneNumber: "010-1234-5678", CreditUp: false}
+
+	// When & Then: 채널별 평가 결과 검증
+	assert.True(t, ruleSet.EvaluateChannel(smallRise, domain.EmailChannel).Eligible)
+	assert.False(t, ruleSet.EvaluateChannel(smallRise, domain.SMSChannel).Eligible)
+	assert.True(t, ruleSet.EvaluateChannel(largeRise, domain.SMSChannel).Eligible)
+
+	decision := ruleSet.EvaluateChannel(notCreditUp, domain.SMSChannel)
+	assert.False(t, decision.Eligible)
+	assert.Equal(t, "credit_up", decision.Rule, "공통 규칙이 먼저 평가되어야 함")
+}
+
+func TestRuleSet_EvaluateChannel_HasEmail(t *testing.T) {
+	// Given: 보낼 수 있는 이메일이 없는 사용자에게만 SMS를 보내는 규칙
+	ruleSet, err := BuildRuleSet(RuleSetConfig{
+		Channels: map[string]Config{
+			"email": {Rule: "has_email"},
+			"sms":   {Not: &Config{Rule: "has_email"}},
+		},
+	})
+	require.NoError(t, err)
+
+	withEmail := &domain.User{Email: "user@example.com", PhoneNumber: "010-1234-5678", CreditUp: true}
+	withoutEmail := &domain.User{Email: "-", PhoneNumber: "010-1234-5679", CreditUp: true}
+
+	// When & Then: 이메일이 있으면 이메일만, 없으면 SMS만
+	assert.True(t, ruleSet.EvaluateChannel(withEmail, domain.EmailChannel).Eligible)
+	decision := ruleSet.EvaluateChannel(withEmail, domain.SMSChannel)
+	assert.False(t, decision.Eligible)
+	assert.Equal(t, "not(has_email)", decision.Rule)
+
+	decision = ruleSet.EvaluateChannel(withoutEmail, domain.EmailChannel)
+	assert.False(t, decision.Eligible)
+	assert.Equal(t, "has_email", decision.Rule)
+	assert.True(t, ruleSet.EvaluateChannel(withoutEmail, domain.SMSChannel).Eligible)
+}
+
+func TestLoadRuleSet(t *testing.T) {
+	t.Run("예제 설정 파일 로딩", func(t *testing.T) {
+		// When: 저장소에 포함된 예제 설정 로딩
+		ruleSet, err := LoadRuleSet(filepath.Join("..", "..", "files", "config", "rules.example.json"))
+
+		// Then: 정상 로딩 및 평가
+		require.NoError(t, err)
+
+		user := createScoredUser("user@example.fake", "010-1234-5678", 780, 795)
+		assert.True(t, ruleSet.Evaluate(user).Eligible)
+
+		decision := ruleSet.EvaluateChannel(user, domain.SMSChannel)
+		assert.False(t, decision.Eligible)
+		assert.Equal(t, "any(min_delta(30),threshold_crossed(800))", decision.Rule)
+	})
+
+	t.Run("여러 종류를 지정한 노드", func(t *testing.T) {
+		// Given: rule과 all을 함께 지정한 설정
+		path := filepath.Join(t.TempDir(), "rules.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"eligibility": {"rule": "credit_up", "all": [{"rule": "min_delta", "value": 10}]}}`), 0644))
+
+		// When: 설정 로딩
+		ruleSet, err := LoadRuleSet(path)
+
+		// Then: 에러 확인
+		assert.Error(t, err)
+		assert.Nil(t, ruleSet)
+	})
+
+	t.Run("기준이 없는 규칙", func(t *testing.T) {
+		// Given: value를 빠뜨린 채널 규칙
+		path := filepath.Join(t.TempDir(), "rules.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"channels": {"sms": {"rule": "threshold_crossed"}}}`), 0644))
+
+		// When: 설정 로딩
+		ruleSet, err := LoadRuleSet(path)
+
+		// Then: 기준 0으로 만들지 않고 에러
+		assert.ErrorContains(t, err, "value")
+		assert.Nil(t, ruleSet)
+	})
+
+	t.Run("알 수 없는 채널", func(t *testing.T) {
+		// Given: 지원하지 않는 채널이 포함된 설정
+		path := filepath.Join(t.TempDir(), "rules.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"channels": {"fax": {"rule": "credit_up"}}}`), 0644))
+
+		// When: 설정 로딩
+		ruleSet, err := LoadRuleSet(path)
+
+		// Then: 에러 확인
+		assert.Error(t, err)
+		assert.Nil(t, ruleSet)
+	})
+}
+
+func createScoredUser(email, phone string, previous, current int) *domain.User {
+	user, _ := domain.NewUser(email, phone, true)
+	user.Score, _ = domain.NewCreditScore(previous, current)
+	return user
+}
+
+func intValue(v int) *int {
+	return &v
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/ingest"
+	"banksalad-backend-task/internal/job"
+	"banksalad-backend-task/internal/pipeline"
+)
+
+// 작업 제출 요청 (file 또는 records 중 하나만 지정)
+type SubmitRequest struct {
+	File    string   `json:"file,omitempty"`
+	Records []string `json:"records,omitempty"` // 입력 파일과 같은 형식의 레코드 (예: "user@example.com 010-1234-5678 Y 780 803 KCB")
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type reportResponse struct {
+	ID        string                `json:"id"`
+	Reports   []pipeline.UserReport `json:"reports"`
+	Truncated bool                  `json:"truncated,omitempty"` // 앞부분만 보관 (전체는 audit 명령으로 조회)
+}
+
+// 요청 본문 최대 크기 기본값 (레코드 약 1만 건)
+const DefaultMaxBodyBytes = 1 << 20
+
+type Config struct {
+	Token        string // 모든 요청의 Authorization: Bearer 토큰 (비어 있으면 모든 요청 거부)
+	InputDir     string // file로 지정할 수 있는 입력 파일 디렉토리 (비어 있으면 file 제출 거부)
+	MaxBodyBytes int64  // 요청 본문 최대 크기 (0이면 DefaultMaxBodyBytes)
+}
+
+// 알림 작업 제출과 조회를 위한 HTTP API
+type Server struct {
+	manager      *job.Manager
+	pipeline     *pipeline.Pipeline
+	token        string
+	inputDir     string
+	maxBodyBytes int64
+}
+
+func New(manager *job.Manager, p *pipeline.Pipeline, cfg Config) *Server {
+	maxBodyBytes := cfg.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return &Server{
+		manager:      manager,
+		pipeline:     p,
+		token:        cfg.Token,
+		inputDir:     cfg.InputDir,
+		maxBodyBytes: maxBodyBytes,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", s.handleSubmit)
+	mux.HandleFunc("GET /jobs/{id}", s.handleGet)
+	mux.HandleFunc("POST /jobs/{id}/cancel", s.handleCancel)
+	mux.HandleFunc("GET /jobs/{id}/report", s.handleReport)
+	return s.authenticate(mux)
+}
+
+// 토큰이 일치하지 않으면 401 (토큰을 설정하지 않았으면 모든 요청 거부)
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("인증 토큰이 없거나 올바르지 않습니다"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req SubmitRequest
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, errors.Errorf("요청 본문이 %d바이트를 넘습니다", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "요청 형식 오류"))
+		return
+	}
+
+	run, source, inputHash, err := s.newRunFunc(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	j := s.manager.Submit(source, inputHash, run)
+	log.WithFields(log.Fields{
+		"job":    j.ID(),
+		"source": source,
+	}).Info("알림 작업 제출")
+
+	writeJSON(w, http.StatusAccepted, j.Snapshot())
+}
+
+// 레코드는 요청 시점에 파싱하여 형식 오류를 바로 응답, 파일은 작업 실행 시 파싱 (둘 다 처리 흐름의 입력 형식 설정 사용)
+func (s *Server) newRunFunc(ctx context.Context, req SubmitRequest) (job.RunFunc, string, string, error) {
+	switch {
+	case req.File != "" && len(req.Records) > 0:
+		return nil, "", "", errors.New("file과 records 중 하나만 지정해야 합니다")
+
+	case req.File != "":
+		path, err := s.resolveInput(req.File)
+		if err != nil {
+			return nil, "", "", err
+		}
+		inputHash, err := ingest.HashFile(path)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+			return s.pipeline.RunFile(ctx, path, hooks)
+		}, path, inputHash, nil
+
+	case len(req.Records) > 0:
+		content := strings.Join(req.Records, "\n")
+		users, err := s.pipeline.ParseRecords(ctx, strings.NewReader(content))
+		if err != nil {
+			return nil, "", "", errors.Wrap(err, "레코드 파싱 오류")
+		}
+		return func(ctx context.Context, hooks pipeline.Hooks) (*pipeline.Result, error) {
+			return s.pipeline.Run(ctx, users, hooks)
+		}, "records", ingest.HashBytes([]byte(content)), nil
+
+	default:
+		return nil, "", "", errors.New("file 또는 records가 필요합니다")
+	}
+}
+
+// 입력 디렉토리 기준 상대 경로만 허용 (절대 경로, 상위 디렉토리, 밖을 가리키는 심볼릭 링크 거부)
+func (s *Server) resolveInput(name string) (string, error) {
+	if s.inputDir == "" {
+		return "", errors.New("입력 디렉토리가 설정되지 않아 file로 제출할 수 없습니다")
+	}
+
+	cleaned := filepath.Clean(name)
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("file은 입력 디렉토리 기준 상대 경로여야 합니다: %s", name)
+	}
+
+	root, err := filepath.EvalSymlinks(s.inputDir)
+	if err != nil {
+		return "", errors.Wrap(err, "입력 디렉토리를 찾을 수 없습니다")
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(root, cleaned))
+	if err != nil {
+		return "", errors.Errorf("입력 파일을 찾을 수 없습니다: %s", name)
+	}
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("file은 입력 디렉토리 안에 있어야 합니다: %s", name)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", errors.Errorf("입력 파일이 아닙니다: %s", name)
+	}
+	return path, nil
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	j, err := s.manager.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, j.Snapshot())
+}
+
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	j, err := s.manager.Cancel(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, j.Snapshot())
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	j, err := s.manager.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	reports, truncated, ok := j.Reports()
+	if !ok {
+		writeError(w, http.StatusConflict, errors.New("작업이 아직 끝나지 않았거나 결과가 없습니다"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reportResponse{ID: j.ID(), Reports: reports, Truncated: truncated})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.WithError(err).Error("failed to write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/job"
+	"banksalad-backend-task/internal/message"
+	"banksalad-backend-task/internal/parser"
+	"banksalad-backend-task/internal/pipeline"
+	"banksalad-backend-task/internal/service"
+)
+
+type mockClient struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (m *mockClient) Send(to string, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, to)
+	return nil
+}
+
+const testToken = "test-token"
+
+// inputDir는 file로 제출할 수 있는 디렉토리
+func newTestServer(t *testing.T, inputDir string) *httptest.Server {
+	t.Helper()
+	return newTestServerWithParser(t, inputDir, nil)
+}
+
+// newParser는 처리 흐름의 입력 형식 설정 (nil이면 확장자로 판단)
+func newTestServerWithParser(t *testing.T, inputDir string, newParser pipeline.ParserFactory) *httptest.Server {
+	t.Helper()
+
+	rateLimiter := service.NewRateLimiter(100, time.Second)
+	t.Cleanup(rateLimiter.Stop)
+
+	p := pipeline.New(pipeline.Config{
+		NewParser: newParser,
+		NewNotifier: func() *service.NotificationManager {
+			return service.NewNotificationManagerWithServices(
+				service.NewEmailServiceWithClient(&mockClient{}),
+				service.NewSMSServiceWithRateLimiter(&mockClient{}, message.NewDefaultRenderer(), rateLimiter),
+			)
+		},
+	})
+
+	ts := httptest.NewServer(New(job.NewManager(context.Background(), nil), p, Config{
+		Token:        testToken,
+		InputDir:     inputDir,
+		MaxBodyBytes: 1024,
+	}).Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestServer_SubmitRecords(t *testing.T) {
+	// Given: 테스트 서버
+	ts := newTestServer(t, "")
+
+	// When: 레코드로 작업 제출
+	body := `{"records": ["user@example.com 010-1234-5678 Y 780 803 KCB", "down@example.com 010-0000-0000 N"]}`
+	resp := request(t, http.MethodPost, ts.URL+"/jobs", body, testToken)
+	defer resp.Body.Close()
+
+	// Then: 작업 접수
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var submitted job.Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&submitted))
+	assert.Equal(t, "records", submitted.Source)
+
+	// When: 작업 완료까지 상태 조회
+	var snapshot job.Snapshot
+	require.Eventually(t, func() bool {
+		getJSON(t, ts.URL+"/jobs/"+submitted.ID, &snapshot)
+		return snapshot.Status.IsFinished()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Then: 완료 상태와 사용자별 결과 확인
+	assert.Equal(t, job.StatusCompleted, snapshot.Status)
+	assert.Equal(t, 2, snapshot.Progress.TotalUsers)
+	assert.Equal(t, 1, snapshot.Progress.EmailSuccess)
+
+	var report reportResponse
+	status := getJSON(t, ts.URL+"/jobs/"+submitted.ID+"/report", &report)
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, report.Reports, 2)
+	assert.Equal(t, pipeline.StatusSent, report.Reports[0].EmailStatus)
+	assert.Equal(t, pipeline.StatusExcluded, report.Reports[1].SMSStatus)
+}
+
+func TestServer_SubmitRecords_InputFormat(t *testing.T) {
+	// Given: 입력 형식을 JSONL로 지정한 테스트 서버
+	ts := newTestServerWithParser(t, "", func(path string) (parser.Parser, error) {
+		return parser.New(parser.FormatJSONL, parser.Options{})
+	})
+
+	// When: JSONL 레코드로 작업 제출
+	body := `{"records": ["{\"email\": \"user@example.com\", \"phone_number\": \"010-1234-5678\", \"credit_up\": true}"]}`
+	resp := request(t, http.MethodPost, ts.URL+"/jobs", body, testToken)
+	defer resp.Body.Close()
+
+	// Then: 지정한 형식으로 파싱하여 작업 접수 후 완료
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var submitted job.Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&submitted))
+
+	var snapshot job.Snapshot
+	require.Eventually(t, func() bool {
+		getJSON(t, ts.URL+"/jobs/"+submitted.ID, &snapshot)
+		return snapshot.Status.IsFinished()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, job.StatusCompleted, snapshot.Status)
+	assert.Equal(t, 1, snapshot.Progress.EmailSuccess)
+
+	// When & Then: 지정한 형식이 아닌 레코드는 형식 오류로 거부
+	resp = request(t, http.MethodPost, ts.URL+"/jobs", `{"records": ["user@example.com 010-1234-5678 Y"]}`, testToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServer_SubmitFile(t *testing.T) {
+	// Given: 입력 디렉토리의 파일
+	inputDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "data.txt"), []byte("user@example.com 010-1234-5678 Y\n"), 0644))
+	ts := newTestServer(t, inputDir)
+
+	// When: 입력 디렉토리 기준 상대 경로로 작업 제출
+	resp := request(t, http.MethodPost, ts.URL+"/jobs", `{"file": "data.txt"}`, testToken)
+	defer resp.Body.Close()
+
+	// Then: 작업 접수 후 완료
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var submitted job.Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&submitted))
+
+	var snapshot job.Snapshot
+	require.Eventually(t, func() bool {
+		getJSON(t, ts.URL+"/jobs/"+submitted.ID, &snapshot)
+		return snapshot.Status.IsFinished()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, job.StatusCompleted, snapshot.Status)
+	assert.Equal(t, 1, snapshot.Progress.EmailSuccess)
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	root := t.TempDir()
+	inputDir := filepath.Join(root, "input")
+	require.NoError(t, os.Mkdir(inputDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("user@example.com 010-1234-5678 Y\n"), 0644))
+	require.NoError(t, os.Symlink(filepath.Join(root, "secret.txt"), filepath.Join(inputDir, "link.txt")))
+	ts := newTestServer(t, inputDir)
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			name:           "토큰 없음",
+			method:         http.MethodGet,
+			path:           "/jobs/없는작업",
+			token:          "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "잘못된 토큰",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"records": ["user@example.com 010-1234-5678 Y"]}`,
+			token:          "wrong-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "본문 크기 초과",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"records": ["` + strings.Repeat("a", 2048) + `"]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "입력 디렉토리 밖 - 상위 디렉토리",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"file": "../secret.txt"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "입력 디렉토리 밖 - 절대 경로",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"file": "` + filepath.ToSlash(filepath.Join(root, "secret.txt")) + `"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "입력 디렉토리 밖 - 심볼릭 링크",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"file": "link.txt"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "입력 디렉토리 - 없는 파일",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"file": "없는파일.txt"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "잘못된 JSON",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "입력 없음",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "파일과 레코드 동시 지정",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"file": "data.txt", "records": ["user@example.com 010-1234-5678 Y"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "레코드 형식 오류",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           `{"records": ["user@example.com"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "없는 작업 조회",
+			method:         http.MethodGet,
+			path:           "/jobs/없는작업",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "없는 작업 취소",
+			method:         http.MethodPost,
+			path:           "/jobs/없는작업/cancel",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When: 요청 전송 (토큰을 지정하지 않은 경우는 올바른 토큰)
+			token := tc.token
+			if token == "" && tc.expectedStatus != http.StatusUnauthorized {
+				token = testToken
+			}
+			resp := request(t, tc.method, ts.URL+tc.path, tc.body, token)
+			defer resp.Body.Close()
+
+			// Then: 상태 코드와 에러 메시지 확인
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var body errorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.NotEmpty(t, body.Error)
+		})
+	}
+}
+
+func request(t *testing.T, method, url, body, token string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func getJSON(t *testing.T, url string, target interface{}) int {
+	t.Helper()
+	resp := request(t, http.MethodGet, url, "", testToken)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(target))
+	return resp.StatusCode
+}
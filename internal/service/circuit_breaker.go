@@ -0,0 +1,218 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrCircuitOpen = errors.New("전송 차단 중 (회로 차단기 열림)")
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // 정상 전송
+	BreakerOpen     BreakerState = "open"      // 전송하지 않고 ErrCircuitOpen 반환
+	BreakerHalfOpen BreakerState = "half_open" // 시험 전송으로 복구 여부 확인
+)
+
+type BreakerConfig struct {
+	Window           time.Duration // 실패율을 계산하는 최근 구간
+	MinRequests      int           // 구간 안의 요청이 이보다 적으면 열지 않음
+	FailureRate      float64       // 구간 안의 실패율이 이 값 이상이면 열림 (0~1)
+	OpenTimeout      time.Duration // 열린 뒤 시험 전송을 시작하기까지 대기
+	HalfOpenRequests int           // 시험 전송 수 (모두 성공하면 닫힘, 하나라도 실패하면 다시 열림)
+}
+
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Window:           10 * time.Second,
+		MinRequests:      20,
+		FailureRate:      0.5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenRequests: 5,
+	}
+}
+
+type breakerEvent struct {
+	time   time.Time
+	failed bool
+}
+
+// 채널 하나의 회로 차단기 (여러 고루틴에서 사용 가능)
+type CircuitBreaker struct {
+	name string
+	cfg  BreakerConfig
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	events   []breakerEvent // 최근 구간의 전송 결과 (시간순)
+	failures int
+	openedAt time.Time
+	trials   int // half-open에서 허용한 시험 전송 수
+	passed   int // half-open에서 성공한 시험 전송 수
+}
+
+func NewCircuitBreaker(name string, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.HalfOpenRequests < 1 {
+		cfg.HalfOpenRequests = 1
+	}
+
+	return &CircuitBreaker{
+		name:  name,
+		cfg:   cfg,
+		now:   time.Now,
+		state: BreakerClosed,
+	}
+}
+
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(cb.now())
+	return cb.state
+}
+
+// 열려 있어 전송할 수 없는지 확인 (시험 전송 자리는 차지하지 않음)
+func (cb *CircuitBreaker) Blocked() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(cb.now())
+	return cb.state == BreakerOpen || (cb.state == BreakerHalfOpen && cb.trials >= cb.cfg.HalfOpenRequests)
+}
+
+// 전송 전에 호출, 허용하면 결과를 Record로 알려야 함
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(cb.now())
+	switch cb.state {
+	case BreakerOpen:
+		return errors.Wrap(ErrCircuitOpen, cb.name)
+	case BreakerHalfOpen:
+		if cb.trials >= cb.cfg.HalfOpenRequests {
+			return errors.Wrap(ErrCircuitOpen, cb.name)
+		}
+		cb.trials++
+	}
+	return nil
+}
+
+func (cb *CircuitBreaker) Record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	cb.advance(now)
+
+	if cb.state == BreakerHalfOpen {
+		if err != nil {
+			cb.open(now)
+			return
+		}
+		cb.passed++
+		if cb.passed >= cb.cfg.HalfOpenRequests {
+			cb.transition(BreakerClosed)
+			cb.events = nil
+			cb.failures = 0
+		}
+		return
+	}
+
+	// 열린 동안 끝난 전송(열리기 전에 시작한 전송)은 판단에 쓰지 않음
+	if cb.state == BreakerOpen {
+		return
+	}
+
+	cb.events = append(cb.events, breakerEvent{time: now, failed: err != nil})
+	if err != nil {
+		cb.failures++
+	}
+	cb.prune(now)
+
+	if len(cb.events) >= cb.cfg.MinRequests && float64(cb.failures)/float64(len(cb.events)) >= cb.cfg.FailureRate {
+		cb.open(now)
+	}
+}
+
+// cb.mu를 잡은 상태에서 호출, 열린 뒤 대기 시간이 지났으면 half-open으로 전환
+func (cb *CircuitBreaker) advance(now time.Time) {
+	if cb.state == BreakerOpen && now.Sub(cb.openedAt) >= cb.cfg.OpenTimeout {
+		cb.transition(BreakerHalfOpen)
+		cb.trials = 0
+		cb.passed = 0
+	}
+}
+
+// cb.mu를 잡은 상태에서 호출
+func (cb *CircuitBreaker) open(now time.Time) {
+	cb.transition(BreakerOpen)
+	cb.openedAt = now
+	cb.events = nil
+	cb.failures = 0
+}
+
+// cb.mu를 잡은 상태에서 호출, 구간 밖의 결과 제거
+func (cb *CircuitBreaker) prune(now time.Time) {
+	i := 0
+	for ; i < len(cb.events) && now.Sub(cb.events[i].time) > cb.cfg.Window; i++ {
+		if cb.events[i].failed {
+			cb.failures--
+		}
+	}
+	cb.events = cb.events[i:]
+}
+
+// cb.mu를 잡은 상태에서 호출
+func (cb *CircuitBreaker) transition(state BreakerState) {
+	if cb.state == state {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"channel": cb.name,
+		"from":    cb.state,
+		"to":      state,
+	}).Warn("회로 차단기 상태 변경")
+	cb.state = state
+}
+
+// 채널 구분 없는 전송 클라이언트 (EmailSender, SMSSender와 같은 메서드)
+type Sender interface {
+	Send(to string, message string) error
+}
+
+// 회로 차단기를 거쳐 전송하는 클라이언트 (EmailSender, SMSSender 모두로 사용)
+// 열려 있으면 전송하지 않고 ErrCircuitOpen 반환 (파이프라인이 아웃박스에 보관)
+type BreakerSender struct {
+	sender  Sender
+	breaker *CircuitBreaker
+}
+
+func NewBreakerSender(sender Sender, breaker *CircuitBreaker) *BreakerSender {
+	return &BreakerSender{
+		sender:  sender,
+		breaker: breaker,
+	}
+}
+
+func (bs *BreakerSender) Send(to string, message string) error {
+	if err := bs.breaker.Allow(); err != nil {
+		return err
+	}
+
+	err := bs.sender.Send(to, message)
+	bs.breaker.Record(err)
+	return err
+}
+
+// 속도 제한 대기 전에 차단 여부 확인 (SMS 서비스가 토큰을 쓰지 않고 바로 보관하도록)
+func (bs *BreakerSender) Blocked() bool {
+	return bs.breaker.Blocked()
+}
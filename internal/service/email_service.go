@@ -5,10 +5,12 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"banksalad-backend-task/clients"
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/message"
 )
 
 type EmailSender interface {
@@ -17,25 +19,47 @@ type EmailSender interface {
 
 type EmailService interface {
 	SendEmails(ctx context.Context, users []*domain.User) (int, error)
+	SetObserver(observer SendObserver)
 }
 
 type emailService struct {
-	client EmailSender
+	client   EmailSender
+	renderer *message.Renderer
+	observer SendObserver
+	starter  StartObserver
 }
 
 func NewEmailService() EmailService {
 	client := clients.NewEmailClient()
 	return &emailService{
-		client: client,
+		client:   client,
+		renderer: message.NewDefaultRenderer(),
 	}
 }
 
 func NewEmailServiceWithClient(client EmailSender) EmailService {
 	return &emailService{
-		client: client,
+		client:   client,
+		renderer: message.NewDefaultRenderer(),
 	}
 }
 
+// 템플릿을 지정하는 생성자
+func NewEmailServiceWithRenderer(client EmailSender, renderer *message.Renderer) EmailService {
+	return &emailService{
+		client:   client,
+		renderer: renderer,
+	}
+}
+
+func (es *emailService) SetObserver(observer SendObserver) {
+	es.observer = observer
+}
+
+func (es *emailService) SetStartObserver(observer StartObserver) {
+	es.starter = observer
+}
+
 func (es *emailService) SendEmails(ctx context.Context, users []*domain.User) (int, error) {
 	if len(users) == 0 {
 		return 0, nil
@@ -45,15 +69,26 @@ func (es *emailService) SendEmails(ctx context.Context, users []*domain.User) (i
 	errChan := make(chan error, len(users))
 	successCount := int64(0)
 	failureCount := int64(0)
+	parkedCount := int64(0)
 
 	for _, user := range users {
 		wg.Add(1)
 		go func(u *domain.User) {
 			defer wg.Done()
+
+			// 패닉이 나도 전송 결과가 감사 기록과 진행 기록에 남도록 아직 알리지 않았으면 실패로 알림
+			notified := false
+			notify := func(err error) {
+				notified = true
+				es.observer.Notify(u, domain.EmailChannel, err)
+			}
 			defer func() {
 				if r := recover(); r != nil {
 					log.WithField("panic", r).Error("recovered from panic")
 					atomic.AddInt64(&failureCount, 1)
+					if !notified {
+						notify(errors.Errorf("이메일 전송 중 패닉: %v", r))
+					}
 				}
 			}()
 
@@ -62,16 +97,34 @@ func (es *emailService) SendEmails(ctx context.Context, users []*domain.User) (i
 				errChan <- ctx.Err()
 				return
 			default:
-				if err := es.client.Send(u.Email, "신용점수 상승 알림"); err != nil {
+				msg, err := es.renderer.RenderEmail(u)
+				if err != nil {
+					log.WithError(err).WithField("email", u.Email).Error("이메일 메시지 생성 실패 (계속 진행)")
+					atomic.AddInt64(&failureCount, 1)
+					notify(err)
+					return
+				}
+
+				es.starter.Notify(u, domain.EmailChannel)
+				err = es.client.Send(u.Email, msg.MIME())
+				switch {
+				case errors.Is(err, ErrCircuitOpen):
+					// 회로 차단기가 열려 보내지 않음 (파이프라인이 아웃박스에 보관)
+					atomic.AddInt64(&parkedCount, 1)
+					notify(err)
+				case err != nil:
 					log.WithError(err).WithField("email", u.Email).Error("이메일 전송 실패 (계속 진행)")
 					atomic.AddInt64(&failureCount, 1)
-				} else {
+					notify(err)
+				default:
 					atomic.AddInt64(&successCount, 1)
+					notify(nil)
 				}
 			}
 		}(user)
 	}
 
+	// 취소되어도 이미 전송을 시작한 고루틴은 끝날 때까지 대기 (새 전송만 시작하지 않음)
 	wg.Wait()
 	close(errChan)
 
@@ -86,6 +139,7 @@ func (es *emailService) SendEmails(ctx context.Context, users []*domain.User) (i
 		"success": successCount,
 		"total":   len(users),
 		"failure": failureCount,
+		"parked":  parkedCount,
 	}).Info("이메일 전송 완료")
 
 	return int(successCount), nil
@@ -4,9 +4,34 @@ import (
 	"context"
 	"sync"
 
+	"banksalad-backend-task/clients"
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/message"
 )
 
+// 사용자별 전송 결과를 전달받는 함수 (성공 시 err는 nil, 여러 고루틴에서 호출될 수 있음)
+type SendObserver func(user *domain.User, channel domain.NotificationChannel, err error)
+
+func (so SendObserver) Notify(user *domain.User, channel domain.NotificationChannel, err error) {
+	if so != nil {
+		so(user, channel, err)
+	}
+}
+
+// 클라이언트 호출 직전에 전달받는 함수 (강제 종료 시 결과를 모르는 전송과 시작하지 않은 전송을 구분)
+type StartObserver func(user *domain.User, channel domain.NotificationChannel)
+
+func (so StartObserver) Notify(user *domain.User, channel domain.NotificationChannel) {
+	if so != nil {
+		so(user, channel)
+	}
+}
+
+// 전송 시작을 알릴 수 있는 서비스 (emailService, smsService)
+type startNotifier interface {
+	SetStartObserver(observer StartObserver)
+}
+
 type NotificationManager struct {
 	emailService EmailService
 	smsService   SMSService
@@ -19,8 +44,43 @@ func NewNotificationManager() *NotificationManager {
 	}
 }
 
+// 채널별 템플릿을 지정하는 생성자
+func NewNotificationManagerWithRenderer(renderer *message.Renderer) *NotificationManager {
+	return &NotificationManager{
+		emailService: NewEmailServiceWithRenderer(clients.NewEmailClient(), renderer),
+		smsService:   NewSMSServiceWithRenderer(clients.NewSmsClient(), renderer),
+	}
+}
+
+// 채널별 서비스를 지정하는 생성자
+func NewNotificationManagerWithServices(emailService EmailService, smsService SMSService) *NotificationManager {
+	return &NotificationManager{
+		emailService: emailService,
+		smsService:   smsService,
+	}
+}
+
+func (nm *NotificationManager) SetObserver(observer SendObserver) {
+	nm.emailService.SetObserver(observer)
+	nm.smsService.SetObserver(observer)
+}
+
+// 전송 시작을 알릴 수 없는 서비스는 건너뜀
+func (nm *NotificationManager) SetStartObserver(observer StartObserver) {
+	for _, svc := range []any{nm.emailService, nm.smsService} {
+		if notifier, ok := svc.(startNotifier); ok {
+			notifier.SetStartObserver(observer)
+		}
+	}
+}
+
 func (nm *NotificationManager) SendNotifications(ctx context.Context, users []*domain.User) (int, int, error) {
-	if len(users) == 0 {
+	return nm.SendChannelNotifications(ctx, users, users)
+}
+
+// 채널별로 서로 다른 대상에게 알림 전송
+func (nm *NotificationManager) SendChannelNotifications(ctx context.Context, emailUsers, smsUsers []*domain.User) (int, int, error) {
+	if len(emailUsers) == 0 && len(smsUsers) == 0 {
 		return 0, 0, nil
 	}
 
@@ -32,14 +92,14 @@ func (nm *NotificationManager) SendNotifications(ctx context.Context, users []*d
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		emailSuccess, emailErr = nm.emailService.SendEmails(ctx, users)
+		emailSuccess, emailErr = nm.emailService.SendEmails(ctx, emailUsers)
 	}()
 
 	// SMS 전송
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		smsSuccess, smsErr = nm.smsService.SendSMS(ctx, users)
+		smsSuccess, smsErr = nm.smsService.SendSMS(ctx, smsUsers)
 	}()
 
 	wg.Wait()
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+// 아웃박스에 보관한 이유
+const (
+	OutboxInterrupted = "interrupted"  // 종료 요청으로 전송을 시작하지 못함
+	OutboxCircuitOpen = "circuit_open" // 회로 차단기가 열려 전송하지 않음
+	OutboxUnknown     = "unknown"      // 전송 중에 강제 종료되어 전달 여부를 모름 (다시 보내면 중복일 수 있어 replay에서 건너뜀)
+	OutboxFailed      = "failed"       // 다시 보냈지만 실패 (감시 모드의 자동 재전송에서 제외, replay에서 다시 보냄)
+	OutboxDead        = "dead"         // 다시 보낸 실패가 최대 횟수에 이름 (더는 보내지 않고 직접 확인)
+)
+
+// 보내지 못한 알림 하나 (채널 단위)
+type OutboxEntry struct {
+	RunID   string       `json:"run_id"`
+	Channel string       `json:"channel"`
+	User    *domain.User `json:"user"`
+	Reason  string       `json:"reason"`
+	Time    time.Time    `json:"time"`
+
+	Attempts  int    `json:"attempts,omitempty"`   // 다시 보내다 실패한 횟수
+	LastError string `json:"last_error,omitempty"` // 마지막으로 다시 보낼 때의 오류
+}
+
+// 보내지 못한 알림을 나중에 다시 보낼 수 있도록 보관하는 파일 (JSON Lines, replay가 다시 보낸 항목만 지움)
+type Outbox struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewOutbox(path string) *Outbox {
+	return &Outbox{
+		path: path,
+	}
+}
+
+func (o *Outbox) Append(entries []OutboxEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return errors.Wrap(err, "아웃박스 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(o.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "아웃박스 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close outbox file")
+		}
+	}()
+
+	// 종료 직전에 호출되므로 디스크까지 기록한 뒤 반환
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return errors.Wrap(err, "아웃박스 기록 실패")
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return errors.Wrap(err, "아웃박스 기록 실패")
+	}
+	return errors.Wrap(file.Sync(), "아웃박스 기록 실패")
+}
+
+// 기록된 순서대로 전체 항목 반환
+func (o *Outbox) Entries() ([]OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.load()
+}
+
+// 다시 보낼 항목을 꺼냄 (파일에서는 Commit 전까지 지우지 않고, 꺼낸 뒤 추가된 항목은 다음에 꺼냄)
+// 같은 아웃박스를 꺼내는 실행은 한 번에 하나여야 함
+func (o *Outbox) Claim() (*OutboxClaim, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entries, err := o.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxClaim{
+		Entries: entries,
+		outbox:  o,
+		removed: make(map[int]struct{}),
+	}, nil
+}
+
+// 아웃박스에서 꺼낸 항목 (다시 보낸 결과에 따라 Commit으로 정리)
+type OutboxClaim struct {
+	Entries []OutboxEntry
+
+	outbox  *Outbox
+	removed map[int]struct{} // 이미 지운 항목 (Entries의 순서)
+}
+
+// 처리를 마친 항목(Entries의 순서)을 파일에서 지우고 나머지와 꺼낸 뒤 추가된 항목은 남김
+// 중간에 종료되어도 이미 보낸 항목을 다시 보내지 않도록 배치마다 호출
+func (c *OutboxClaim) Commit(finished []int) error {
+	return c.update(finished, nil)
+}
+
+// 다시 보냈지만 실패한 항목(Entries의 순서 → 오류)의 시도 횟수와 마지막 오류를 기록하고 failed로 바꿈
+// 실패가 maxAttempts 번에 이른 항목은 dead로 바꿔 더는 다시 보내지 않음 (0이면 제한 없음)
+func (c *OutboxClaim) MarkFailed(failures map[int]error, maxAttempts int) error {
+	changes := make(map[int]func(*OutboxEntry), len(failures))
+	for index, err := range failures {
+		message := err.Error()
+		changes[index] = func(entry *OutboxEntry) {
+			entry.Attempts++
+			entry.LastError = message
+			entry.Reason = OutboxFailed
+			if maxAttempts > 0 && entry.Attempts >= maxAttempts {
+				entry.Reason = OutboxDead
+			}
+		}
+	}
+	return c.update(nil, changes)
+}
+
+// 전송을 시작했지만 결과를 받지 못한 항목(Entries의 순서)을 결과 모름으로 바꿈 (다시 보내지 않도록)
+func (c *OutboxClaim) MarkUnknown(started []int) error {
+	changes := make(map[int]func(*OutboxEntry), len(started))
+	for _, index := range started {
+		changes[index] = func(entry *OutboxEntry) {
+			entry.Reason = OutboxUnknown
+		}
+	}
+	return c.update(nil, changes)
+}
+
+// finished는 지우고 changes는 파일과 Entries의 항목을 함께 바꿈
+func (c *OutboxClaim) update(finished []int, changes map[int]func(*OutboxEntry)) error {
+	if len(finished) == 0 && len(changes) == 0 {
+		return nil
+	}
+
+	c.outbox.mu.Lock()
+	defer c.outbox.mu.Unlock()
+
+	entries, err := c.outbox.load()
+	if err != nil {
+		return err
+	}
+
+	// 파일 앞부분은 꺼낸 항목 중 아직 지우지 않은 항목 (순서 유지)
+	kept := make([]int, 0, len(c.Entries)-len(c.removed))
+	for index := range c.Entries {
+		if _, removed := c.removed[index]; !removed {
+			kept = append(kept, index)
+		}
+	}
+	if len(entries) < len(kept) {
+		return errors.New("아웃박스 파일이 꺼낸 뒤에 바뀌었습니다")
+	}
+
+	done := make(map[int]struct{}, len(finished))
+	for _, index := range finished {
+		done[index] = struct{}{}
+	}
+
+	remaining := make([]OutboxEntry, 0, len(entries))
+	for position, index := range kept {
+		if _, exists := done[index]; exists {
+			continue
+		}
+		entry := entries[position]
+		if change, exists := changes[index]; exists {
+			change(&entry)
+		}
+		remaining = append(remaining, entry)
+	}
+	remaining = append(remaining, entries[len(kept):]...)
+
+	if err := c.outbox.rewrite(remaining); err != nil {
+		return err
+	}
+	for index := range done {
+		c.removed[index] = struct{}{}
+	}
+	for index, change := range changes {
+		change(&c.Entries[index])
+	}
+	return nil
+}
+
+func (o *Outbox) load() ([]OutboxEntry, error) {
+	file, err := os.Open(o.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "아웃박스 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close outbox file")
+		}
+	}()
+
+	var entries []OutboxEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry OutboxEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrap(err, "아웃박스 항목 파싱 실패")
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "아웃박스 파일 읽기 오류")
+	}
+
+	return entries, nil
+}
+
+// 임시 파일에 기록 후 교체하여 중간에 실패해도 기존 아웃박스 보존
+func (o *Outbox) rewrite(entries []OutboxEntry) error {
+	tmpPath := o.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "아웃박스 임시 파일 생성 실패")
+	}
+
+	encoder := json.NewEncoder(file)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			file.Close()
+			return errors.Wrap(err, "아웃박스 기록 실패")
+		}
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrap(err, "아웃박스 기록 실패")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "아웃박스 임시 파일 닫기 실패")
+	}
+
+	return errors.Wrap(os.Rename(tmpPath, o.path), "아웃박스 파일 교체 실패")
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 하루 중 SMS 전송이 허용되는 시간대 (예: 08:00-21:00, KST 기준)
+type QuietHoursPolicy struct {
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+func NewQuietHoursPolicy(window string, location *time.Location) (*QuietHoursPolicy, error) {
+	startText, endText, found := strings.Cut(window, "-")
+	if !found {
+		return nil, errors.Errorf("허용 시간대 형식 오류: %s (예: 08:00-21:00)", window)
+	}
+
+	start, err := parseClock(startText)
+	if err != nil {
+		return nil, errors.Wrap(err, "허용 시작 시각 파싱 실패")
+	}
+
+	end, err := parseClock(endText)
+	if err != nil {
+		return nil, errors.Wrap(err, "허용 종료 시각 파싱 실패")
+	}
+
+	if start == end {
+		return nil, errors.Errorf("허용 시작과 종료 시각이 같습니다: %s", window)
+	}
+
+	return &QuietHoursPolicy{
+		start:    start,
+		end:      end,
+		location: location,
+	}, nil
+}
+
+func parseClock(text string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+func (p *QuietHoursPolicy) IsAllowed(t time.Time) bool {
+	offset := p.sinceMidnight(t)
+
+	// 자정을 넘기는 시간대 (예: 22:00-06:00)
+	if p.start > p.end {
+		return offset >= p.start || offset < p.end
+	}
+	return offset >= p.start && offset < p.end
+}
+
+// 다음 전송 가능 시각 (이미 허용 시간대면 그대로 반환)
+func (p *QuietHoursPolicy) NextAllowed(t time.Time) time.Time {
+	if p.IsAllowed(t) {
+		return t
+	}
+
+	local := t.In(p.location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, p.location)
+	next := midnight.Add(p.start)
+	if !next.After(local) {
+		next = midnight.AddDate(0, 0, 1).Add(p.start)
+	}
+	return next
+}
+
+func (p *QuietHoursPolicy) sinceMidnight(t time.Time) time.Duration {
+	local := t.In(p.location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, p.location)
+	return local.Sub(midnight)
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/message"
 )
 
 func setupTestDir(t *testing.T) string {
@@ -160,6 +163,37 @@ func TestEmailService_SendEmails_Unit(t *testing.T) {
 	}
 }
 
+type panicEmailClient struct{}
+
+func (panicEmailClient) Send(email string, message string) error {
+	panic("client failure")
+}
+
+func TestEmailService_SendEmails_Panic(t *testing.T) {
+	// Given: 전송 중 패닉이 나는 클라이언트를 사용한 이메일 서비스
+	users := createTestUsers(2)
+	emailService := NewEmailServiceWithClient(panicEmailClient{})
+
+	var mu sync.Mutex
+	outcomes := make(map[string]error)
+	emailService.SetObserver(func(user *domain.User, channel domain.NotificationChannel, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes[user.Email] = err
+	})
+
+	// When: 이메일 전송 실행
+	successCount, err := emailService.SendEmails(context.Background(), users)
+
+	// Then: 패닉이 난 전송마다 실패로 알림
+	require.NoError(t, err)
+	assert.Equal(t, 0, successCount)
+	require.Len(t, outcomes, len(users))
+	for _, user := range users {
+		assert.ErrorContains(t, outcomes[user.Email], "패닉")
+	}
+}
+
 func TestEmailService_SendEmails_Integration(t *testing.T) {
 	// Given: 테스트 환경 설정
 	setupTestDir(t)
@@ -258,6 +292,238 @@ func TestSMSService_SendSMS_Unit(t *testing.T) {
 	}
 }
 
+func TestSMSService_SendSMS_CancelledMidRun(t *testing.T) {
+	// Given: 첫 번째 전송 중에 종료 요청을 받는 클라이언트
+	ctx, cancel := context.WithCancel(context.Background())
+	users := createTestUsers(3)
+
+	mockClient := &cancellingSMSClient{cancel: cancel}
+	smsService := NewSMSServiceWithClient(mockClient)
+	t.Cleanup(smsService.Stop)
+
+	var notified []*domain.User
+	smsService.SetObserver(func(user *domain.User, channel domain.NotificationChannel, err error) {
+		notified = append(notified, user)
+	})
+
+	// When: SMS 전송 실행
+	successCount, err := smsService.SendSMS(ctx, users)
+
+	// Then: 전송을 시작한 사용자의 결과는 남기고 나머지 사용자는 시도하지 않음
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, successCount)
+	assert.Equal(t, []*domain.User{users[0]}, notified)
+	assert.Equal(t, []string{users[0].PhoneNumber}, mockClient.sentSMS)
+}
+
+func TestOutbox(t *testing.T) {
+	// Given: 임시 아웃박스
+	outbox := NewOutbox(filepath.Join(t.TempDir(), "state", "outbox.jsonl"))
+	users := createTestUsers(2)
+
+	entries, err := outbox.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// When: 두 번에 나누어 기록
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, domain.KST)
+	require.NoError(t, outbox.Append([]OutboxEntry{
+		{RunID: "run-1", Channel: domain.EmailChannel.String(), User: users[0], Reason: OutboxInterrupted, Time: now},
+	}))
+	require.NoError(t, outbox.Append([]OutboxEntry{
+		{RunID: "run-1", Channel: domain.SMSChannel.String(), User: users[1], Reason: OutboxInterrupted, Time: now},
+	}))
+
+	// Then: 기록된 순서대로 조회
+	entries, err = outbox.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "email", entries[0].Channel)
+	assert.Equal(t, users[0].Email, entries[0].User.Email)
+	assert.Equal(t, users[1].PhoneNumber, entries[1].User.PhoneNumber)
+	assert.True(t, now.Equal(entries[1].Time))
+}
+
+func TestOutbox_Claim(t *testing.T) {
+	// Given: 세 항목이 기록된 아웃박스
+	outbox := NewOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	users := createTestUsers(4)
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, domain.KST)
+	for _, user := range users[:3] {
+		require.NoError(t, outbox.Append([]OutboxEntry{
+			{RunID: "run-1", Channel: domain.EmailChannel.String(), User: user, Reason: OutboxCircuitOpen, Time: now},
+		}))
+	}
+
+	// When: 꺼낸 뒤 새 항목이 추가되고 두 번에 나누어 처리한 항목을 정리, 한 항목은 결과 모름으로 표시
+	claim, err := outbox.Claim()
+	require.NoError(t, err)
+	require.Len(t, claim.Entries, 3)
+
+	require.NoError(t, outbox.Append([]OutboxEntry{
+		{RunID: "run-2", Channel: domain.SMSChannel.String(), User: users[3], Reason: OutboxInterrupted, Time: now},
+	}))
+	require.NoError(t, claim.Commit([]int{0}))
+	require.NoError(t, claim.MarkUnknown([]int{1}))
+	require.NoError(t, claim.Commit([]int{2}))
+
+	// Then: 처리하지 않은 항목과 꺼낸 뒤 추가된 항목만 순서대로 남고 결과를 모르는 항목은 unknown으로 바뀜
+	entries, err := outbox.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, users[1].Email, entries[0].User.Email)
+	assert.Equal(t, OutboxUnknown, entries[0].Reason)
+	assert.Equal(t, "run-2", entries[1].RunID)
+	assert.Equal(t, OutboxInterrupted, entries[1].Reason)
+}
+
+func TestOutbox_ClaimMarkFailed(t *testing.T) {
+	// Given: 회로 차단으로 보관한 항목 하나
+	outbox := NewOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	user := createTestUsers(1)[0]
+	require.NoError(t, outbox.Append([]OutboxEntry{
+		{RunID: "run-1", Channel: domain.EmailChannel.String(), User: user, Reason: OutboxCircuitOpen},
+	}))
+
+	// When: 다시 보내다 한 번 실패
+	claim, err := outbox.Claim()
+	require.NoError(t, err)
+	require.NoError(t, claim.MarkFailed(map[int]error{0: errors.New("잘못된 주소")}, 2))
+
+	// Then: 시도 횟수와 오류를 남기고 failed로 바꿈
+	entries, err := outbox.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, OutboxFailed, entries[0].Reason)
+	assert.Equal(t, 1, entries[0].Attempts)
+	assert.Equal(t, "잘못된 주소", entries[0].LastError)
+
+	// When: 다시 꺼내 최대 횟수만큼 실패
+	claim, err = outbox.Claim()
+	require.NoError(t, err)
+	require.NoError(t, claim.MarkFailed(map[int]error{0: errors.New("잘못된 주소")}, 2))
+
+	// Then: dead로 바꿔 더는 보내지 않음
+	entries, err = outbox.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, OutboxDead, entries[0].Reason)
+	assert.Equal(t, 2, entries[0].Attempts)
+	assert.Equal(t, OutboxDead, claim.Entries[0].Reason)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	// Given: 최근 4건 중 절반 이상 실패하면 열리고 1분 뒤 시험 전송 2건을 허용하는 회로 차단기
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, domain.KST)
+	breaker := NewCircuitBreaker("sms", BreakerConfig{
+		Window:           10 * time.Second,
+		MinRequests:      4,
+		FailureRate:      0.5,
+		OpenTimeout:      time.Minute,
+		HalfOpenRequests: 2,
+	})
+	breaker.now = func() time.Time { return now }
+	sendErr := fmt.Errorf("SMS 전송 실패")
+
+	// When: 최소 요청 수 전에는 모두 실패해도 열리지 않음
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(sendErr)
+	}
+	assert.Equal(t, BreakerClosed, breaker.State())
+
+	// Then: 구간 밖으로 밀려난 실패는 빼고 계산
+	now = now.Add(11 * time.Second)
+	require.NoError(t, breaker.Allow())
+	breaker.Record(nil)
+	assert.Equal(t, BreakerClosed, breaker.State())
+
+	// When: 구간 안의 실패율이 기준을 넘음
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(sendErr)
+	}
+
+	// Then: 열려서 전송을 막음
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.True(t, breaker.Blocked())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// When: 대기 시간이 지나 시험 전송 중 하나가 실패
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, breaker.State())
+	require.NoError(t, breaker.Allow())
+	breaker.Record(sendErr)
+
+	// Then: 다시 열림
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	// When: 다시 대기 후 시험 전송이 모두 성공
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	require.NoError(t, breaker.Allow())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen, "시험 전송 수를 넘는 요청은 막음")
+	breaker.Record(nil)
+	breaker.Record(nil)
+
+	// Then: 닫힘
+	assert.Equal(t, BreakerClosed, breaker.State())
+	assert.False(t, breaker.Blocked())
+}
+
+func TestBreakerSender_Send(t *testing.T) {
+	// Given: 실패 한 번으로 열리는 회로 차단기를 거치는 실패하는 클라이언트
+	mockClient := &MockSMSClient{shouldFail: true}
+	sender := NewBreakerSender(mockClient, NewCircuitBreaker("sms", BreakerConfig{
+		Window:      time.Minute,
+		MinRequests: 1,
+		FailureRate: 0.5,
+		OpenTimeout: time.Minute,
+	}))
+
+	// When: 두 번 전송
+	firstErr := sender.Send("010-1234-0000", "메시지")
+	secondErr := sender.Send("010-1234-0001", "메시지")
+
+	// Then: 첫 번째는 클라이언트의 실패, 두 번째는 클라이언트를 호출하지 않고 차단
+	require.Error(t, firstErr)
+	assert.NotErrorIs(t, firstErr, ErrCircuitOpen)
+	assert.ErrorIs(t, secondErr, ErrCircuitOpen)
+	assert.True(t, sender.Blocked())
+
+	mockClient.shouldFail = false
+	assert.ErrorIs(t, sender.Send("010-1234-0002", "메시지"), ErrCircuitOpen)
+	assert.Empty(t, mockClient.sentSMS)
+}
+
+func TestSMSService_SendSMS_CircuitOpen(t *testing.T) {
+	// Given: 첫 번째 사용자에게 보내다 실패하면 열리는 회로 차단기
+	users := createTestUsers(3)
+	mockClient := &MockSMSClient{shouldFail: true}
+	sender := NewBreakerSender(mockClient, NewCircuitBreaker("sms", BreakerConfig{
+		Window:      time.Minute,
+		MinRequests: 1,
+		FailureRate: 0.5,
+		OpenTimeout: time.Minute,
+	}))
+	smsService := NewSMSServiceWithClient(sender)
+	t.Cleanup(smsService.Stop)
+
+	var parked []*domain.User
+	smsService.SetObserver(func(user *domain.User, channel domain.NotificationChannel, err error) {
+		if errors.Is(err, ErrCircuitOpen) {
+			parked = append(parked, user)
+		}
+	})
+
+	// When: SMS 전송 실행
+	successCount, err := smsService.SendSMS(context.Background(), users)
+
+	// Then: 나머지 사용자는 전송하지 않고 보관 대상으로 알림
+	require.NoError(t, err)
+	assert.Equal(t, 0, successCount)
+	assert.Equal(t, users[1:], parked)
+}
+
 func TestSMSService_SendSMS_Integration(t *testing.T) {
 	// Given: 테스트 환경 설정
 	setupTestDir(t)
@@ -409,6 +675,271 @@ func TestNotificationManager_SendNotifications_Unit(t *testing.T) {
 	}
 }
 
+func TestNotificationManager_SharedRateLimiter(t *testing.T) {
+	// Given: 속도 제한기를 공유하는 두 작업의 알림 매니저
+	rateLimiter := NewRateLimiter(100, time.Second)
+	t.Cleanup(func() {
+		rateLimiter.Stop()
+	})
+
+	newManager := func(smsClient *MockSMSClient) *NotificationManager {
+		return NewNotificationManagerWithServices(
+			NewEmailServiceWithClient(&MockEmailClient{shouldFail: true}),
+			NewSMSServiceWithRateLimiter(smsClient, message.NewDefaultRenderer(), rateLimiter),
+		)
+	}
+
+	var mu sync.Mutex
+	outcomes := make(map[domain.NotificationChannel][]error)
+	observer := func(user *domain.User, channel domain.NotificationChannel, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes[channel] = append(outcomes[channel], err)
+	}
+
+	firstClient := &MockSMSClient{}
+	first := newManager(firstClient)
+	first.SetObserver(observer)
+
+	// When: 첫 번째 작업 전송 후 종료
+	_, smsSuccess, err := first.SendNotifications(context.Background(), createTestUsers(3))
+	require.NoError(t, err)
+	require.NoError(t, first.Close())
+
+	// Then: 사용자별 결과가 전달되고, 공유 속도 제한기는 계속 사용 가능
+	assert.Equal(t, 3, smsSuccess)
+	assert.Len(t, outcomes[domain.SMSChannel], 3)
+	assert.Len(t, outcomes[domain.EmailChannel], 3)
+	for _, err := range outcomes[domain.EmailChannel] {
+		assert.Error(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	secondClient := &MockSMSClient{}
+	second := newManager(secondClient)
+	_, smsSuccess, err = second.SendNotifications(ctx, createTestUsers(2))
+	require.NoError(t, err)
+	assert.Equal(t, 2, smsSuccess)
+}
+
+func TestQuietHoursPolicy(t *testing.T) {
+	// Given: 08:00-21:00 허용 정책과 자정을 넘기는 22:00-06:00 정책
+	daytime, err := NewQuietHoursPolicy("08:00-21:00", domain.KST)
+	require.NoError(t, err)
+	overnight, err := NewQuietHoursPolicy("22:00-06:00", domain.KST)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		policy          *QuietHoursPolicy
+		now             time.Time
+		expectedAllowed bool
+		expectedNext    time.Time
+	}{
+		{
+			name:            "허용 시간대 내",
+			policy:          daytime,
+			now:             time.Date(2025, 7, 1, 12, 0, 0, 0, domain.KST),
+			expectedAllowed: true,
+			expectedNext:    time.Date(2025, 7, 1, 12, 0, 0, 0, domain.KST),
+		},
+		{
+			name:            "허용 시작 전 (새벽)",
+			policy:          daytime,
+			now:             time.Date(2025, 7, 1, 6, 30, 0, 0, domain.KST),
+			expectedAllowed: false,
+			expectedNext:    time.Date(2025, 7, 1, 8, 0, 0, 0, domain.KST),
+		},
+		{
+			name:            "허용 종료 시각 (21:00) - 다음날로 미룸",
+			policy:          daytime,
+			now:             time.Date(2025, 7, 1, 21, 0, 0, 0, domain.KST),
+			expectedAllowed: false,
+			expectedNext:    time.Date(2025, 7, 2, 8, 0, 0, 0, domain.KST),
+		},
+		{
+			name:            "UTC 시각도 KST 기준으로 판단",
+			policy:          daytime,
+			now:             time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC), // KST 22:00
+			expectedAllowed: false,
+			expectedNext:    time.Date(2025, 7, 2, 8, 0, 0, 0, domain.KST),
+		},
+		{
+			name:            "자정을 넘기는 시간대 - 새벽 허용",
+			policy:          overnight,
+			now:             time.Date(2025, 7, 1, 3, 0, 0, 0, domain.KST),
+			expectedAllowed: true,
+			expectedNext:    time.Date(2025, 7, 1, 3, 0, 0, 0, domain.KST),
+		},
+		{
+			name:            "자정을 넘기는 시간대 - 낮에는 금지",
+			policy:          overnight,
+			now:             time.Date(2025, 7, 1, 12, 0, 0, 0, domain.KST),
+			expectedAllowed: false,
+			expectedNext:    time.Date(2025, 7, 1, 22, 0, 0, 0, domain.KST),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// When & Then: 허용 여부와 다음 전송 가능 시각 검증
+			assert.Equal(t, tc.expectedAllowed, tc.policy.IsAllowed(tc.now))
+			assert.True(t, tc.expectedNext.Equal(tc.policy.NextAllowed(tc.now)),
+				"expected %v, got %v", tc.expectedNext, tc.policy.NextAllowed(tc.now))
+		})
+	}
+}
+
+func TestNewQuietHoursPolicy_InvalidWindow(t *testing.T) {
+	for _, window := range []string{"08:00", "8시-21시", "25:00-21:00", "08:00-08:00"} {
+		window := window
+		t.Run(window, func(t *testing.T) {
+			// When: 잘못된 형식으로 정책 생성
+			policy, err := NewQuietHoursPolicy(window, domain.KST)
+
+			// Then: 에러 확인
+			assert.Error(t, err)
+			assert.Nil(t, policy)
+		})
+	}
+}
+
+func TestSMSScheduler_Schedule(t *testing.T) {
+	// Given: 08:00-21:00 정책과 임시 대기열
+	policy, err := NewQuietHoursPolicy("08:00-21:00", domain.KST)
+	require.NoError(t, err)
+
+	queue := NewDeferredQueue(filepath.Join(t.TempDir(), "deferred_sms.jsonl"))
+	scheduler := NewSMSScheduler(policy, queue)
+	users := createTestUsers(3)
+
+	// When: 밤 시간대에 스케줄링
+	scheduler.now = func() time.Time { return time.Date(2025, 7, 1, 23, 0, 0, 0, domain.KST) }
+	sendNow, deferred, err := scheduler.Schedule(users)
+
+	// Then: 모두 대기열에 보관
+	require.NoError(t, err)
+	assert.Empty(t, sendNow)
+	assert.Len(t, deferred, 3)
+
+	queued, err := queue.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 3, queued)
+
+	// When: 다음날 새벽, 아직 허용 시간대 전
+	scheduler.now = func() time.Time { return time.Date(2025, 7, 2, 7, 59, 0, 0, domain.KST) }
+	claim, err := scheduler.Release()
+
+	// Then: 여전히 대기
+	require.NoError(t, err)
+	assert.Nil(t, claim)
+
+	// When: 허용 시간대가 열린 후 대기열에서 꺼내고 새 사용자를 스케줄링
+	scheduler.now = func() time.Time { return time.Date(2025, 7, 2, 8, 0, 0, 0, domain.KST) }
+	claim, err = scheduler.Release()
+	require.NoError(t, err)
+	newUser, _ := domain.NewUser("new@example.com", "010-9999-9999", true)
+	sendNow, deferred, err = scheduler.Schedule([]*domain.User{newUser})
+
+	// Then: 이번 대상은 그대로, 대기열 사용자는 꺼내지만 Commit 전까지 파일에 남음
+	require.NoError(t, err)
+	assert.Empty(t, deferred)
+	assert.Equal(t, []*domain.User{newUser}, sendNow)
+	require.NotNil(t, claim)
+	require.Len(t, claim.Users, 3)
+	assert.Equal(t, users[0].PhoneNumber, claim.Users[0].PhoneNumber)
+
+	queued, err = queue.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 3, queued)
+
+	// When: 같은 프로세스의 다른 작업이 동시에 꺼냄
+	other, err := scheduler.Release()
+
+	// Then: 이미 꺼낸 사용자는 다시 꺼내지 않음
+	require.NoError(t, err)
+	assert.Empty(t, other.Users)
+
+	// When: 2명만 전송을 마치고 정리
+	require.NoError(t, claim.Commit(claim.Users[:2]))
+
+	// Then: 보내지 못한 1명은 다음 실행에서 다시 꺼냄
+	queued, err = queue.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	claim, err = scheduler.Release()
+	require.NoError(t, err)
+	require.Len(t, claim.Users, 1)
+	assert.Equal(t, users[2].PhoneNumber, claim.Users[0].PhoneNumber)
+}
+
+func TestDeferredQueue_Claim_Uncommitted(t *testing.T) {
+	// Given: 전송 시각이 지난 대기열 항목
+	path := filepath.Join(t.TempDir(), "deferred_sms.jsonl")
+	now := time.Date(2025, 7, 2, 9, 0, 0, 0, domain.KST)
+	require.NoError(t, NewDeferredQueue(path).Enqueue(createTestUsers(2), now.Add(-time.Hour)))
+
+	// When: 꺼낸 뒤 정리하지 못하고 종료 (새 프로세스가 같은 파일을 엶)
+	claim, err := NewDeferredQueue(path).Claim(now)
+	require.NoError(t, err)
+	require.Len(t, claim.Users, 2)
+
+	claim, err = NewDeferredQueue(path).Claim(now)
+
+	// Then: 대기열 항목을 잃지 않음
+	require.NoError(t, err)
+	assert.Len(t, claim.Users, 2)
+
+	users, err := NewDeferredQueue(path).Users()
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestDeferredQueue_Claim_NormalizedPhone(t *testing.T) {
+	// Given: 같은 번호를 하이픈 유무만 다르게 보관한 대기열
+	queue := NewDeferredQueue(filepath.Join(t.TempDir(), "deferred_sms.jsonl"))
+	now := time.Date(2025, 7, 2, 9, 0, 0, 0, domain.KST)
+	hyphen, err := domain.NewUser("a@example.com", "010-1234-5678", true)
+	require.NoError(t, err)
+	plain, err := domain.NewUser("b@example.com", "01012345678", true)
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue([]*domain.User{hyphen, plain}, now.Add(-time.Hour)))
+
+	// When: 꺼냄
+	due, err := queue.Due(now)
+	require.NoError(t, err)
+	claim, err := queue.Claim(now)
+
+	// Then: 같은 번호로 보고 한 명만 꺼냄
+	require.NoError(t, err)
+	assert.Equal(t, 1, due)
+	require.Len(t, claim.Users, 1)
+	assert.Equal(t, hyphen.Email, claim.Users[0].Email)
+
+	// When: 꺼낸 사용자에게 보내고 정리
+	require.NoError(t, claim.Commit(claim.Users))
+
+	// Then: 표기가 다른 같은 번호의 항목도 지워 다시 보내지 않음
+	queued, err := queue.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 0, queued)
+}
+
+// 첫 번째 전송 중에 컨텍스트를 취소하는 SMS 클라이언트
+type cancellingSMSClient struct {
+	MockSMSClient
+	cancel context.CancelFunc
+}
+
+func (c *cancellingSMSClient) Send(phoneNumber string, message string) error {
+	c.cancel()
+	return c.MockSMSClient.Send(phoneNumber, message)
+}
+
 // 테스트 헬퍼 함수
 func createTestUsers(count int) []*domain.User {
 	users := make([]*domain.User, count)
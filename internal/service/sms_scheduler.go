@@ -0,0 +1,307 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+type deferredEntry struct {
+	User      *domain.User `json:"user"`
+	ReleaseAt time.Time    `json:"release_at"`
+}
+
+// 허용 시간대 밖의 SMS를 보관하는 파일 기반 대기열 (JSON Lines)
+// 꺼낸 사용자는 전송을 마친 뒤 Commit해야 파일에서 지워짐 (중간에 종료되면 다음 실행에서 다시 꺼냄)
+type DeferredQueue struct {
+	path    string
+	mu      sync.Mutex
+	claimed map[string]struct{} // 전송 중인 전화번호 (정규화, 같은 프로세스의 다른 작업이 다시 꺼내지 않도록)
+}
+
+func NewDeferredQueue(path string) *DeferredQueue {
+	return &DeferredQueue{
+		path:    path,
+		claimed: make(map[string]struct{}),
+	}
+}
+
+func (dq *DeferredQueue) Enqueue(users []*domain.User, releaseAt time.Time) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(dq.path), 0755); err != nil {
+		return errors.Wrap(err, "대기열 디렉토리 생성 실패")
+	}
+
+	file, err := os.OpenFile(dq.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "대기열 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close deferred queue file")
+		}
+	}()
+
+	encoder := json.NewEncoder(file)
+	for _, user := range users {
+		if err := encoder.Encode(deferredEntry{User: user, ReleaseAt: releaseAt}); err != nil {
+			return errors.Wrap(err, "대기열 기록 실패")
+		}
+	}
+
+	return nil
+}
+
+// 전송 시각이 된 사용자를 전화번호당 한 명씩 꺼냄 (하이픈 유무 등은 정규화하여 같은 번호, 파일에서는 Commit 전까지 지우지 않음)
+func (dq *DeferredQueue) Claim(now time.Time) (*DeferredClaim, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	entries, err := dq.load()
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &DeferredClaim{
+		queue: dq,
+		now:   now,
+	}
+	for _, entry := range entries {
+		if entry.ReleaseAt.After(now) {
+			continue
+		}
+		phoneNumber := domain.NormalizePhoneNumber(entry.User.PhoneNumber)
+		if _, exists := dq.claimed[phoneNumber]; exists {
+			continue
+		}
+		dq.claimed[phoneNumber] = struct{}{}
+		claim.Users = append(claim.Users, entry.User)
+	}
+
+	return claim, nil
+}
+
+// 대기열에서 꺼낸 사용자 (전송 결과에 따라 Commit으로 정리)
+type DeferredClaim struct {
+	Users []*domain.User
+
+	queue *DeferredQueue
+	now   time.Time
+	once  sync.Once
+}
+
+// 전송에 성공했거나 더 보낼 필요가 없는 사용자(수신 거부 등)를 대기열에서 지우고 나머지는 다음 실행을 위해 남김
+// 한 번만 적용되며 이후 호출은 무시
+func (c *DeferredClaim) Commit(finished []*domain.User) error {
+	var err error
+	c.once.Do(func() {
+		err = c.queue.commit(c, finished)
+	})
+	return err
+}
+
+func (dq *DeferredQueue) commit(claim *DeferredClaim, finished []*domain.User) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	for _, user := range claim.Users {
+		delete(dq.claimed, domain.NormalizePhoneNumber(user.PhoneNumber))
+	}
+
+	if len(finished) == 0 {
+		return nil
+	}
+
+	done := make(map[string]struct{}, len(finished))
+	for _, user := range finished {
+		done[domain.NormalizePhoneNumber(user.PhoneNumber)] = struct{}{}
+	}
+
+	entries, err := dq.load()
+	if err != nil {
+		return err
+	}
+
+	// 꺼낸 뒤 같은 번호로 다시 보관된 항목(전송 시각이 나중)은 남김
+	remaining := make([]deferredEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, exists := done[domain.NormalizePhoneNumber(entry.User.PhoneNumber)]; exists && !entry.ReleaseAt.After(claim.now) {
+			continue
+		}
+		remaining = append(remaining, entry)
+	}
+
+	if len(remaining) == len(entries) {
+		return nil
+	}
+	return dq.rewrite(remaining)
+}
+
+// 전송 시각이 된 사용자 수 (다른 작업이 꺼낸 전화번호 제외, 꺼내지 않고 읽기만 함)
+func (dq *DeferredQueue) Due(now time.Time) (int, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	entries, err := dq.load()
+	if err != nil {
+		return 0, err
+	}
+
+	due := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if entry.ReleaseAt.After(now) {
+			continue
+		}
+		phoneNumber := domain.NormalizePhoneNumber(entry.User.PhoneNumber)
+		if _, exists := dq.claimed[phoneNumber]; exists {
+			continue
+		}
+		due[phoneNumber] = struct{}{}
+	}
+	return len(due), nil
+}
+
+func (dq *DeferredQueue) Len() (int, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	entries, err := dq.load()
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// 대기열에 남은 사용자 (꺼내지 않고 읽기만 함, 대사에서 사용)
+func (dq *DeferredQueue) Users() ([]*domain.User, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	entries, err := dq.load()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0, len(entries))
+	for _, entry := range entries {
+		users = append(users, entry.User)
+	}
+	return users, nil
+}
+
+func (dq *DeferredQueue) load() ([]deferredEntry, error) {
+	file, err := os.Open(dq.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "대기열 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close deferred queue file")
+		}
+	}()
+
+	var entries []deferredEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry deferredEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrap(err, "대기열 항목 파싱 실패")
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "대기열 파일 읽기 오류")
+	}
+
+	return entries, nil
+}
+
+// 임시 파일에 기록 후 교체하여 중간에 실패해도 기존 대기열 보존
+func (dq *DeferredQueue) rewrite(entries []deferredEntry) error {
+	tmpPath := dq.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "대기열 임시 파일 생성 실패")
+	}
+
+	encoder := json.NewEncoder(file)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			file.Close()
+			return errors.Wrap(err, "대기열 기록 실패")
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "대기열 임시 파일 닫기 실패")
+	}
+
+	return errors.Wrap(os.Rename(tmpPath, dq.path), "대기열 파일 교체 실패")
+}
+
+// 허용 시간대에 따라 SMS를 즉시 전송하거나 대기열로 미룸
+type SMSScheduler struct {
+	policy *QuietHoursPolicy
+	queue  *DeferredQueue
+	now    func() time.Time
+}
+
+func NewSMSScheduler(policy *QuietHoursPolicy, queue *DeferredQueue) *SMSScheduler {
+	return &SMSScheduler{
+		policy: policy,
+		queue:  queue,
+		now:    time.Now,
+	}
+}
+
+// 허용 시간대면 대기열에서 전송 시각이 된 사용자를 꺼냄 (허용 시간대 밖이면 nil)
+// 꺼낸 사용자는 호출한 쪽이 규칙과 수신 거부를 다시 적용하고 전송 결과로 Commit해야 함
+func (s *SMSScheduler) Release() (*DeferredClaim, error) {
+	now := s.now()
+	if !s.policy.IsAllowed(now) {
+		return nil, nil
+	}
+	return s.queue.Claim(now)
+}
+
+// 허용 시간대면 대기열에서 지금 꺼낼 수 있는 사용자 수 (허용 시간대 밖이면 0)
+func (s *SMSScheduler) Due() (int, error) {
+	now := s.now()
+	if !s.policy.IsAllowed(now) {
+		return 0, nil
+	}
+	return s.queue.Due(now)
+}
+
+// 허용 시간대면 이번 대상은 그대로 전송, 아니면 모두 대기열에 보관 (입력을 나누어 보낼 때 나눈 대상마다 호출)
+func (s *SMSScheduler) Schedule(users []*domain.User) (sendNow, deferred []*domain.User, err error) {
+	now := s.now()
+	if s.policy.IsAllowed(now) {
+		return users, nil, nil
+	}
+
+	if err := s.queue.Enqueue(users, s.policy.NextAllowed(now)); err != nil {
+		return nil, nil, err
+	}
+	return nil, users, nil
+}
@@ -9,20 +9,31 @@ import (
 
 	"banksalad-backend-task/clients"
 	"banksalad-backend-task/internal/domain"
+	"banksalad-backend-task/internal/message"
 )
 
 type SMSSender interface {
 	Send(phoneNumber string, message string) error
 }
 
+// 회로 차단기로 전송을 막고 있는지 전송 전에 확인할 수 있는 클라이언트 (BreakerSender)
+type blockable interface {
+	Blocked() bool
+}
+
 type SMSService interface {
 	SendSMS(ctx context.Context, users []*domain.User) (int, error)
+	SetObserver(observer SendObserver)
 	Stop()
 }
 
 type smsService struct {
-	client      SMSSender
-	rateLimiter *RateLimiter
+	client          SMSSender
+	rateLimiter     *RateLimiter
+	ownsRateLimiter bool
+	renderer        *message.Renderer
+	observer        SendObserver
+	starter         StartObserver
 }
 
 func NewSMSService() SMSService {
@@ -30,19 +41,51 @@ func NewSMSService() SMSService {
 	rateLimiter := NewRateLimiter(100, time.Second)
 
 	return &smsService{
-		client:      client,
-		rateLimiter: rateLimiter,
+		client:          client,
+		rateLimiter:     rateLimiter,
+		ownsRateLimiter: true,
+		renderer:        message.NewDefaultRenderer(),
 	}
 }
 
 func NewSMSServiceWithClient(client SMSSender) SMSService {
 	rateLimiter := NewRateLimiter(100, time.Second)
+	return &smsService{
+		client:          client,
+		rateLimiter:     rateLimiter,
+		ownsRateLimiter: true,
+		renderer:        message.NewDefaultRenderer(),
+	}
+}
+
+// 템플릿을 지정하는 생성자
+func NewSMSServiceWithRenderer(client SMSSender, renderer *message.Renderer) SMSService {
+	rateLimiter := NewRateLimiter(100, time.Second)
+	return &smsService{
+		client:          client,
+		rateLimiter:     rateLimiter,
+		ownsRateLimiter: true,
+		renderer:        renderer,
+	}
+}
+
+// 여러 작업이 속도 제한기를 공유하는 생성자 (Stop 시 속도 제한기는 중지하지 않음)
+func NewSMSServiceWithRateLimiter(client SMSSender, renderer *message.Renderer, rateLimiter *RateLimiter) SMSService {
 	return &smsService{
 		client:      client,
 		rateLimiter: rateLimiter,
+		renderer:    renderer,
 	}
 }
 
+func (ss *smsService) SetObserver(observer SendObserver) {
+	ss.observer = observer
+}
+
+func (ss *smsService) SetStartObserver(observer StartObserver) {
+	ss.starter = observer
+}
+
 func (ss *smsService) SendSMS(ctx context.Context, users []*domain.User) (int, error) {
 	if len(users) == 0 {
 		return 0, nil
@@ -50,24 +93,50 @@ func (ss *smsService) SendSMS(ctx context.Context, users []*domain.User) (int, e
 
 	successCount := 0
 	failureCount := 0
+	parkedCount := 0
+	gate, _ := ss.client.(blockable)
 
 	for _, user := range users {
 		select {
 		case <-ctx.Done():
 			return successCount, ctx.Err()
 		default:
+			msg, err := ss.renderer.RenderSMS(user)
+			if err != nil {
+				log.WithError(err).WithField("phoneNumber", user.PhoneNumber).Error("SMS 메시지 생성 실패 (계속 진행)")
+				failureCount++
+				ss.observer.Notify(user, domain.SMSChannel, err)
+				continue
+			}
+
+			// 차단 중이면 속도 제한 토큰을 쓰지 않고 바로 보관 대상으로 알림
+			if gate != nil && gate.Blocked() {
+				parkedCount++
+				ss.observer.Notify(user, domain.SMSChannel, errors.Wrap(ErrCircuitOpen, "sms"))
+				continue
+			}
+
 			// 속도 제한 대기
 			if err := ss.rateLimiter.Wait(ctx); err != nil {
 				return successCount, errors.Wrap(err, "속도 제한 대기 중 오류")
 			}
 
-			if err := ss.client.Send(user.PhoneNumber, "신용점수 상승 알림"); err != nil {
+			ss.starter.Notify(user, domain.SMSChannel)
+			sendErr := ss.client.Send(user.PhoneNumber, msg.Body)
+			if sendErr != nil && !errors.Is(sendErr, ErrCircuitOpen) {
 				// 에러를 로그로 기록하고 계속 진행
-				log.WithError(err).WithField("phoneNumber", user.PhoneNumber).Error("SMS 전송 실패 (계속 진행)")
+				log.WithError(sendErr).WithField("phoneNumber", user.PhoneNumber).Error("SMS 전송 실패 (계속 진행)")
+			}
+
+			switch {
+			case errors.Is(sendErr, ErrCircuitOpen):
+				parkedCount++
+			case sendErr != nil:
 				failureCount++
-			} else {
+			default:
 				successCount++
 			}
+			ss.observer.Notify(user, domain.SMSChannel, sendErr)
 		}
 	}
 
@@ -75,11 +144,14 @@ func (ss *smsService) SendSMS(ctx context.Context, users []*domain.User) (int, e
 		"success": successCount,
 		"total":   len(users),
 		"failure": failureCount,
+		"parked":  parkedCount,
 	}).Info("SMS 전송 완료")
 
 	return successCount, nil
 }
 
 func (ss *smsService) Stop() {
-	ss.rateLimiter.Stop()
+	if ss.ownsRateLimiter {
+		ss.rateLimiter.Stop()
+	}
 }
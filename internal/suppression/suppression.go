@@ -0,0 +1,204 @@
+package suppression
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+type Scope int
+
+const (
+	ScopeAll Scope = iota
+	ScopeEmail
+	ScopeSMS
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeAll:
+		return "all"
+	case ScopeEmail:
+		return "email"
+	case ScopeSMS:
+		return "sms"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseScope(name string) (Scope, error) {
+	switch name {
+	case "all":
+		return ScopeAll, nil
+	case "email":
+		return ScopeEmail, nil
+	case "sms":
+		return ScopeSMS, nil
+	default:
+		return 0, errors.Errorf("알 수 없는 수신 거부 범위: %s", name)
+	}
+}
+
+func (s Scope) Covers(channel domain.NotificationChannel) bool {
+	switch s {
+	case ScopeAll:
+		return true
+	case ScopeEmail:
+		return channel == domain.EmailChannel
+	case ScopeSMS:
+		return channel == domain.SMSChannel
+	default:
+		return false
+	}
+}
+
+// 수신 거부 항목 (ExpiresAt이 zero면 영구 거부)
+type Entry struct {
+	Contact   string
+	Scope     Scope
+	ExpiresAt time.Time
+}
+
+func (e Entry) IsActive(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)
+}
+
+// 연락처는 정규화하여 비교 (대소문자, 전화번호 구분자 차이로 거부를 놓치지 않도록)
+type List struct {
+	entries map[string][]Entry
+	now     func() time.Time
+}
+
+func NewList(entries []Entry) *List {
+	list := &List{
+		entries: make(map[string][]Entry, len(entries)),
+		now:     time.Now,
+	}
+
+	for _, entry := range entries {
+		contact := domain.NormalizeContact(entry.Contact)
+		list.entries[contact] = append(list.entries[contact], entry)
+	}
+
+	return list
+}
+
+// 파일 형식: [이메일 또는 전화번호, 범위(all/email/sms), 만료일(YYYY-MM-DD, 선택)]
+func Load(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "수신 거부 파일을 열 수 없습니다")
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("failed to close suppression file")
+		}
+	}()
+
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		// 빈 라인, 주석 스킵
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry, err := parseEntry(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%d번째 라인 파싱 오류", lineNumber)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "수신 거부 파일 읽기 오류")
+	}
+
+	return NewList(entries), nil
+}
+
+func parseEntry(line string) (Entry, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return Entry{}, errors.New("필드가 부족합니다: 최소 2개 필요")
+	}
+
+	scope, err := ParseScope(fields[1])
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{
+		Contact: fields[0],
+		Scope:   scope,
+	}
+
+	if len(fields) > 2 {
+		// 만료일 당일(KST)까지 수신 거부 유지
+		expiry, err := time.ParseInLocation("2006-01-02", fields[2], domain.KST)
+		if err != nil {
+			return Entry{}, errors.Wrapf(err, "만료일 형식 오류: %s", fields[2])
+		}
+		entry.ExpiresAt = expiry.AddDate(0, 0, 1)
+	}
+
+	return entry, nil
+}
+
+func (l *List) Len() int {
+	return len(l.entries)
+}
+
+func (l *List) IsSuppressed(user *domain.User, channel domain.NotificationChannel) bool {
+	now := l.now()
+	return l.matches(domain.NormalizeEmail(user.Email), channel, now) ||
+		l.matches(domain.NormalizePhoneNumber(user.PhoneNumber), channel, now)
+}
+
+// 전화번호 하나의 수신 거부 여부
+func (l *List) IsPhoneSuppressed(phoneNumber string, channel domain.NotificationChannel) bool {
+	return l.matches(domain.NormalizePhoneNumber(phoneNumber), channel, l.now())
+}
+
+// contact는 정규화된 연락처
+func (l *List) matches(contact string, channel domain.NotificationChannel, now time.Time) bool {
+	for _, entry := range l.entries[contact] {
+		if entry.Scope.Covers(channel) && entry.IsActive(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// 채널 기준으로 전송 가능한 사용자와 수신 거부 사용자 분리
+func (l *List) Filter(users []*domain.User, channel domain.NotificationChannel) ([]*domain.User, []*domain.User) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	allowed := make([]*domain.User, 0, len(users))
+	var suppressed []*domain.User
+
+	for _, user := range users {
+		if l.IsSuppressed(user, channel) {
+			suppressed = append(suppressed, user)
+			continue
+		}
+		allowed = append(allowed, user)
+	}
+
+	return allowed, suppressed
+}
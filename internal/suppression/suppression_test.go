@@ -0,0 +1,196 @@
+package suppression
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"banksalad-backend-task/internal/domain"
+)
+
+func TestList_IsSuppressed(t *testing.T) {
+	// Given: 범위와 만료일이 다양한 수신 거부 목록
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, domain.KST)
+	list := NewList([]Entry{
+		{Contact: "all@example.com", Scope: ScopeAll},
+		{Contact: "010-1111-1111", Scope: ScopeSMS},
+		{Contact: "email@example.com", Scope: ScopeEmail},
+		{Contact: "expired@example.com", Scope: ScopeAll, ExpiresAt: now.Add(-time.Hour)},
+		{Contact: "active@example.com", Scope: ScopeAll, ExpiresAt: now.Add(time.Hour)},
+		{Contact: "Mixed@Example.COM", Scope: ScopeAll},
+		{Contact: "01022223333", Scope: ScopeSMS},
+	})
+	list.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name          string
+		email         string
+		phone         string
+		channel       domain.NotificationChannel
+		expectedBlock bool
+	}{
+		{
+			name:          "전체 수신 거부 - 이메일 채널",
+			email:         "all@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.EmailChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "전체 수신 거부 - SMS 채널",
+			email:         "all@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.SMSChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "SMS 수신 거부 전화번호 - SMS 채널",
+			email:         "user@example.com",
+			phone:         "010-1111-1111",
+			channel:       domain.SMSChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "SMS 수신 거부 전화번호 - 이메일 채널은 허용",
+			email:         "user@example.com",
+			phone:         "010-1111-1111",
+			channel:       domain.EmailChannel,
+			expectedBlock: false,
+		},
+		{
+			name:          "이메일 수신 거부 - SMS 채널은 허용",
+			email:         "email@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.SMSChannel,
+			expectedBlock: false,
+		},
+		{
+			name:          "만료된 수신 거부",
+			email:         "expired@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.EmailChannel,
+			expectedBlock: false,
+		},
+		{
+			name:          "만료 전 수신 거부",
+			email:         "active@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.EmailChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "이메일 대소문자 차이",
+			email:         "mixed@example.com",
+			phone:         "010-9999-9999",
+			channel:       domain.EmailChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "전화번호 구분자 차이",
+			email:         "user@example.com",
+			phone:         "010-2222-3333",
+			channel:       domain.SMSChannel,
+			expectedBlock: true,
+		},
+		{
+			name:          "국가번호가 붙은 전화번호",
+			email:         "user@example.com",
+			phone:         "+82 10-2222-3333",
+			channel:       domain.SMSChannel,
+			expectedBlock: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: 사용자 생성
+			user, err := domain.NewUser(tc.email, tc.phone, true)
+			require.NoError(t, err)
+
+			// When: 수신 거부 여부 확인
+			result := list.IsSuppressed(user, tc.channel)
+
+			// Then: 결과 검증
+			assert.Equal(t, tc.expectedBlock, result)
+		})
+	}
+}
+
+func TestList_Filter(t *testing.T) {
+	// Given: SMS만 수신 거부한 사용자가 포함된 목록
+	list := NewList([]Entry{{Contact: "010-1111-1111", Scope: ScopeSMS}})
+	user1, _ := domain.NewUser("user1@example.com", "010-1111-1111", true)
+	user2, _ := domain.NewUser("user2@example.com", "010-2222-2222", true)
+	users := []*domain.User{user1, user2}
+
+	// When: 채널별 필터링
+	smsAllowed, smsSuppressed := list.Filter(users, domain.SMSChannel)
+	emailAllowed, emailSuppressed := list.Filter(users, domain.EmailChannel)
+
+	// Then: SMS에서만 제외
+	assert.Equal(t, []*domain.User{user2}, smsAllowed)
+	assert.Equal(t, []*domain.User{user1}, smsSuppressed)
+	assert.Len(t, emailAllowed, 2)
+	assert.Empty(t, emailSuppressed)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("예제 파일 로딩", func(t *testing.T) {
+		// When: 저장소에 포함된 예제 파일 로딩
+		list, err := Load(filepath.Join("..", "..", "files", "config", "suppression.example.txt"))
+
+		// Then: 주석을 제외한 항목 로딩
+		require.NoError(t, err)
+		assert.Equal(t, 3, list.Len())
+	})
+
+	t.Run("만료일 당일까지 유지", func(t *testing.T) {
+		// Given: 만료일이 지정된 파일
+		path := filepath.Join(t.TempDir(), "suppression.txt")
+		require.NoError(t, os.WriteFile(path, []byte("user@example.com email 2025-07-01\n"), 0644))
+
+		list, err := Load(path)
+		require.NoError(t, err)
+
+		user, _ := domain.NewUser("user@example.com", "010-1234-5678", true)
+
+		// When & Then: 만료일 당일 밤에는 거부, 다음날 0시부터 허용
+		list.now = func() time.Time { return time.Date(2025, 7, 1, 23, 59, 0, 0, domain.KST) }
+		assert.True(t, list.IsSuppressed(user, domain.EmailChannel))
+
+		list.now = func() time.Time { return time.Date(2025, 7, 2, 0, 0, 0, 0, domain.KST) }
+		assert.False(t, list.IsSuppressed(user, domain.EmailChannel))
+	})
+
+	t.Run("잘못된 형식", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			content string
+		}{
+			{name: "범위 누락", content: "user@example.com\n"},
+			{name: "알 수 없는 범위", content: "user@example.com push\n"},
+			{name: "잘못된 만료일", content: "user@example.com all 2025/07/01\n"},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				// Given: 잘못된 형식의 파일
+				path := filepath.Join(t.TempDir(), "suppression.txt")
+				require.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+
+				// When: 파일 로딩
+				list, err := Load(path)
+
+				// Then: 에러 확인
+				assert.Error(t, err)
+				assert.Nil(t, list)
+			})
+		}
+	})
+}